@@ -0,0 +1,265 @@
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+// RotateListenerCertificate upload the PEM encoded certificate and private key (e.g. renewed by an ACME client)
+// as a new server certificate, bind it to the HTTPS or SSL listeners of the loadBalancer and apply the loadBalancer.
+// On each listener only the certificate superseded by the new one is replaced, which is the only certificate of
+// the listener or the one sharing a domain name with the new one, the other SNI certificates are kept.
+// The superseded certificates which are no longer used by any listener of the loadBalancers are deleted
+// after gracePeriod, unless ctx is done before. It returns the ID of the new server certificate.
+func RotateListenerCertificate(ctx context.Context, lbService *service.LoadBalancerService, jobService *service.JobService, loadBalancerID string, listenerIDs []string, certificateContent string, privateKey string, gracePeriod time.Duration, timeout time.Duration, waitInterval time.Duration) (string, error) {
+	if len(listenerIDs) == 0 {
+		return "", fmt.Errorf("No listener of LoadBalancer [%s] to rotate certificate", loadBalancerID)
+	}
+	leaf, err := service.ParseServerCertificate(certificateContent, privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	listeners, err := describeListeners(lbService, loadBalancerID, listenerIDs)
+	if err != nil {
+		return "", err
+	}
+	if len(listeners) != len(listenerIDs) {
+		return "", fmt.Errorf("Some listeners of %v not exist in LoadBalancer [%s]", listenerIDs, loadBalancerID)
+	}
+	currentIDs := []string{}
+	for _, listener := range listeners {
+		protocol := strings.ToLower(service.StringValue(listener.ListenerProtocol))
+		if protocol != ListenerProtocolHTTPS && protocol != ListenerProtocolSSL {
+			return "", fmt.Errorf("Listener [%s] of LoadBalancer [%s] is not https or ssl", service.StringValue(listener.LoadBalancerListenerID), loadBalancerID)
+		}
+		currentIDs = append(currentIDs, service.StringValueSlice(listener.ServerCertificateID)...)
+	}
+	certificates, err := describeCertificateLeaves(lbService, currentIDs)
+	if err != nil {
+		return "", err
+	}
+	superseded := map[string]bool{}
+	replaced := make([]map[string]bool, len(listeners))
+	for i, listener := range listeners {
+		replaced[i], err = supersededCertificates(service.StringValueSlice(listener.ServerCertificateID), certificates, leaf)
+		if err != nil {
+			return "", fmt.Errorf("Listener [%s] of LoadBalancer [%s]: %s", service.StringValue(listener.LoadBalancerListenerID), loadBalancerID, err.Error())
+		}
+		for id := range replaced[i] {
+			superseded[id] = true
+		}
+	}
+
+	output, err := lbService.CreateServerCertificate(&service.CreateServerCertificateInput{
+		ServerCertificateName: service.String(service.ServerCertificateName(leaf)),
+		CertificateContent:    service.String(certificateContent),
+		PrivateKey:            service.String(privateKey),
+	})
+	if err != nil {
+		return "", err
+	}
+	if output.ServerCertificateID == nil {
+		return "", fmt.Errorf("Create server certificate response error")
+	}
+	certificateID := *output.ServerCertificateID
+	logger.Debug("Created server certificate [%s] expires at [%s]", certificateID, leaf.NotAfter)
+	delete(superseded, certificateID)
+
+	for i, listener := range listeners {
+		ids := []string{}
+		added := false
+		for _, id := range service.StringValueSlice(listener.ServerCertificateID) {
+			if replaced[i][id] || id == certificateID {
+				if !added {
+					ids = append(ids, certificateID)
+					added = true
+				}
+				continue
+			}
+			ids = append(ids, id)
+		}
+		if !added {
+			ids = append(ids, certificateID)
+		}
+		_, err = lbService.ModifyLoadBalancerListenerAttributes(&service.ModifyLoadBalancerListenerAttributesInput{
+			LoadBalancerListener: listener.LoadBalancerListenerID,
+			ServerCertificateID:  service.StringSlice(ids),
+		})
+		if err != nil {
+			return certificateID, err
+		}
+	}
+	err = ApplyLoadBalancer(lbService, jobService, loadBalancerID, timeout, waitInterval)
+	if err != nil {
+		return certificateID, err
+	}
+	if len(superseded) == 0 {
+		return certificateID, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return certificateID, ctx.Err()
+	case <-utils.DefaultClock.After(gracePeriod):
+	}
+	// the superseded certificates may be shared with the listeners of other loadBalancers
+	loadBalancerIDs, err := describeLoadBalancerIDs(lbService)
+	if err != nil {
+		return certificateID, err
+	}
+	for _, id := range loadBalancerIDs {
+		listeners, err = describeListeners(lbService, id, nil)
+		if err != nil {
+			return certificateID, err
+		}
+		for _, listener := range listeners {
+			for _, id := range service.StringValueSlice(listener.ServerCertificateID) {
+				delete(superseded, id)
+			}
+		}
+	}
+	if len(superseded) == 0 {
+		return certificateID, nil
+	}
+	certificateIDs := make([]string, 0, len(superseded))
+	for id := range superseded {
+		certificateIDs = append(certificateIDs, id)
+	}
+	logger.Debug("Deleting superseded server certificates %v", certificateIDs)
+	_, err = lbService.DeleteServerCertificates(&service.DeleteServerCertificatesInput{
+		ServerCertificates: service.StringSlice(certificateIDs),
+	})
+	if err != nil {
+		logger.Warn("Delete superseded server certificates %v error : [%s]", certificateIDs, err.Error())
+		return certificateID, err
+	}
+	return certificateID, nil
+}
+
+// supersededCertificates returns the certificates of the listener superseded by the leaf certificate, that is
+// the only certificate of the listener, or the certificates sharing a domain name with the leaf
+func supersededCertificates(ids []string, certificates map[string]*x509.Certificate, leaf *x509.Certificate) (map[string]bool, error) {
+	superseded := map[string]bool{}
+	if len(ids) == 1 {
+		superseded[ids[0]] = true
+		return superseded, nil
+	}
+	names := certificateNames(leaf)
+	for _, id := range ids {
+		certificate, ok := certificates[id]
+		if !ok {
+			continue
+		}
+		for name := range certificateNames(certificate) {
+			if names[name] {
+				superseded[id] = true
+				break
+			}
+		}
+	}
+	if len(ids) > 0 && len(superseded) == 0 {
+		return nil, fmt.Errorf("no certificate of %v shares a domain name with the new certificate", ids)
+	}
+	return superseded, nil
+}
+
+func certificateNames(certificate *x509.Certificate) map[string]bool {
+	names := map[string]bool{}
+	if certificate.Subject.CommonName != "" {
+		names[strings.ToLower(certificate.Subject.CommonName)] = true
+	}
+	for _, name := range certificate.DNSNames {
+		names[strings.ToLower(name)] = true
+	}
+	return names
+}
+
+// describeCertificateLeaves returns the leaf certificates of the server certificates by ID,
+// the server certificates without a parsable content are left out
+func describeCertificateLeaves(lbService *service.LoadBalancerService, certificateIDs []string) (map[string]*x509.Certificate, error) {
+	leaves := map[string]*x509.Certificate{}
+	if len(certificateIDs) == 0 {
+		return leaves, nil
+	}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := lbService.DescribeServerCertificates(&service.DescribeServerCertificatesInput{
+			ServerCertificates: service.StringSlice(certificateIDs),
+			Verbose:            service.Int(1),
+			Limit:              service.Int(limit),
+			Offset:             service.Int(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, certificate := range output.ServerCertificateSet {
+			block, _ := pem.Decode([]byte(service.StringValue(certificate.CertificateContent)))
+			if block == nil {
+				continue
+			}
+			leaf, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				continue
+			}
+			leaves[service.StringValue(certificate.ServerCertificateID)] = leaf
+		}
+		if len(output.ServerCertificateSet) < limit {
+			return leaves, nil
+		}
+	}
+}
+
+func describeLoadBalancerIDs(lbService *service.LoadBalancerService) ([]string, error) {
+	loadBalancerIDs := []string{}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := lbService.DescribeLoadBalancers(&service.DescribeLoadBalancersInput{
+			Limit:  service.Int(limit),
+			Offset: service.Int(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, lb := range output.LoadBalancerSet {
+			if service.StringValue(lb.LoadBalancerID) != "" {
+				loadBalancerIDs = append(loadBalancerIDs, *lb.LoadBalancerID)
+			}
+		}
+		if len(output.LoadBalancerSet) < limit {
+			return loadBalancerIDs, nil
+		}
+	}
+}
+
+func describeListeners(lbService *service.LoadBalancerService, loadBalancerID string, listenerIDs []string) ([]*service.LoadBalancerListener, error) {
+	listeners := []*service.LoadBalancerListener{}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		input := &service.DescribeLoadBalancerListenersInput{
+			LoadBalancer: service.String(loadBalancerID),
+			Verbose:      service.Int(1),
+			Limit:        service.Int(limit),
+			Offset:       service.Int(offset),
+		}
+		if len(listenerIDs) > 0 {
+			input.LoadBalancerListeners = service.StringSlice(listenerIDs)
+		}
+		output, err := lbService.DescribeLoadBalancerListeners(input)
+		if err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, output.LoadBalancerListenerSet...)
+		if len(output.LoadBalancerListenerSet) < limit {
+			return listeners, nil
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+func generateTestCertificate(t *testing.T, commonName string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certificate), string(privateKey)
+}
+
+// newRotationAPI serve lb-1 with the https listener lbl-1 using sc-old of www.example.com and sc-shared of
+// api.example.com, and lb-2 with the listener lbl-2 using lb2Certificate
+func newRotationAPI(t *testing.T, lb2Certificate string) (*fakeAPI, *service.LoadBalancerService, *service.JobService) {
+	api, qcService := newFakeAPI(t)
+	rotated := false
+	api.handle("DescribeLoadBalancerListeners", func(params url.Values) string {
+		if params.Get("loadbalancer") == "lb-2" {
+			return `{"action":"DescribeLoadBalancerListenersResponse","ret_code":0,"total_count":1,"loadbalancer_listener_set":[
+				{"loadbalancer_listener_id":"lbl-2","loadbalancer_id":"lb-2","listener_protocol":"https","server_certificate_id":["` + lb2Certificate + `"]}]}`
+		}
+		certificates := `"sc-old","sc-shared"`
+		if rotated {
+			certificates = `"sc-new","sc-shared"`
+		}
+		return `{"action":"DescribeLoadBalancerListenersResponse","ret_code":0,"total_count":1,"loadbalancer_listener_set":[
+			{"loadbalancer_listener_id":"lbl-1","loadbalancer_id":"lb-1","listener_protocol":"https","server_certificate_id":[` + certificates + `]}]}`
+	})
+	oldCertificate, _ := generateTestCertificate(t, "www.example.com")
+	sharedCertificate, _ := generateTestCertificate(t, "api.example.com")
+	api.respond("DescribeServerCertificates", fmt.Sprintf(`{"action":"DescribeServerCertificatesResponse","ret_code":0,"total_count":2,"server_certificate_set":[
+		{"server_certificate_id":"sc-old","certificate_content":%q},{"server_certificate_id":"sc-shared","certificate_content":%q}]}`,
+		oldCertificate, sharedCertificate))
+	api.respond("CreateServerCertificate", `{"action":"CreateServerCertificateResponse","ret_code":0,"server_certificate_id":"sc-new"}`)
+	api.handle("ModifyLoadBalancerListenerAttributes", func(params url.Values) string {
+		rotated = true
+		return `{"action":"ModifyLoadBalancerListenerAttributesResponse","ret_code":0}`
+	})
+	api.respond("UpdateLoadBalancers", `{"action":"UpdateLoadBalancersResponse","ret_code":0,"job_id":"j-1"}`)
+	api.respond("DescribeJobs", `{"action":"DescribeJobsResponse","ret_code":0,"total_count":1,"job_set":[{"job_id":"j-1","status":"successful"}]}`)
+	api.respond("DescribeLoadBalancers", `{"action":"DescribeLoadBalancersResponse","ret_code":0,"total_count":2,"loadbalancer_set":[
+		{"loadbalancer_id":"lb-1"},{"loadbalancer_id":"lb-2"}]}`)
+	lbService, _ := qcService.LoadBalancer("pek3a")
+	jobService, _ := qcService.Job("pek3a")
+	return api, lbService, jobService
+}
+
+func TestRotateListenerCertificateKeepsSharedCertificate(t *testing.T) {
+	clock := utils.NewFakeClock(time.Now())
+	defer utils.SetDefaultClock(clock)()
+	api, lbService, jobService := newRotationAPI(t, "sc-shared")
+	defer api.Close()
+	certificate, privateKey := generateTestCertificate(t, "www.example.com")
+
+	certificateID, err := RotateListenerCertificate(context.Background(), lbService, jobService, "lb-1", []string{"lbl-1"},
+		certificate, privateKey, time.Hour, time.Minute, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "sc-new", certificateID)
+	assert.True(t, clock.Slept() >= time.Hour)
+	modified := api.called("ModifyLoadBalancerListenerAttributes")
+	assert.Equal(t, 1, len(modified))
+	assert.Equal(t, "sc-new", modified[0].Get("server_certificate_id.1"))
+	assert.Equal(t, "sc-shared", modified[0].Get("server_certificate_id.2"))
+	deleted := api.called("DeleteServerCertificates")
+	assert.Equal(t, 1, len(deleted))
+	assert.Equal(t, "sc-old", deleted[0].Get("server_certificates.1"))
+	assert.Equal(t, "", deleted[0].Get("server_certificates.2"))
+}
+
+// gracePeriodClock never fires for the grace period of an hour
+type gracePeriodClock struct {
+	*utils.FakeClock
+}
+
+func (c gracePeriodClock) After(d time.Duration) <-chan time.Time {
+	if d >= time.Hour {
+		return nil
+	}
+	return c.FakeClock.After(d)
+}
+
+func TestRotateListenerCertificateCanceled(t *testing.T) {
+	defer utils.SetDefaultClock(gracePeriodClock{utils.NewFakeClock(time.Now())})()
+	api, lbService, jobService := newRotationAPI(t, "sc-other")
+	defer api.Close()
+	certificate, privateKey := generateTestCertificate(t, "www.example.com")
+	ctx, cancel := context.WithCancel(context.Background())
+	api.handle("UpdateLoadBalancers", func(params url.Values) string {
+		cancel()
+		return `{"action":"UpdateLoadBalancersResponse","ret_code":0,"job_id":"j-1"}`
+	})
+
+	certificateID, err := RotateListenerCertificate(ctx, lbService, jobService, "lb-1", []string{"lbl-1"},
+		certificate, privateKey, time.Hour, time.Minute, time.Second)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, "sc-new", certificateID)
+	assert.Equal(t, 0, len(api.called("DeleteServerCertificates")))
+}
+
+func TestRotateListenerCertificateRejects(t *testing.T) {
+	defer utils.SetDefaultClock(utils.NewFakeClock(time.Now()))()
+	api, lbService, jobService := newRotationAPI(t, "sc-other")
+	defer api.Close()
+
+	certificate, privateKey := generateTestCertificate(t, "shop.example.com")
+	_, err := RotateListenerCertificate(context.Background(), lbService, jobService, "lb-1", []string{"lbl-1"},
+		certificate, privateKey, time.Hour, time.Minute, time.Second)
+	assert.NotNil(t, err)
+
+	api.respond("DescribeLoadBalancerListeners", `{"action":"DescribeLoadBalancerListenersResponse","ret_code":0,"total_count":1,"loadbalancer_listener_set":[
+		{"loadbalancer_listener_id":"lbl-1","loadbalancer_id":"lb-1","listener_protocol":"http"}]}`)
+	certificate, privateKey = generateTestCertificate(t, "www.example.com")
+	_, err = RotateListenerCertificate(context.Background(), lbService, jobService, "lb-1", []string{"lbl-1"},
+		certificate, privateKey, time.Hour, time.Minute, time.Second)
+	assert.EqualError(t, err, "Listener [lbl-1] of LoadBalancer [lb-1] is not https or ssl")
+	assert.Equal(t, 0, len(api.called("CreateServerCertificate")))
+}
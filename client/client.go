@@ -1,12 +1,15 @@
 package client
 
 import (
+	"context"
 	"errors"
-	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/yunify/qingcloud-sdk-go/config"
 	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
 )
 
 const (
@@ -36,6 +39,11 @@ const (
 	//LoadBalancerStatusCeased ceased
 	LoadBalancerStatusCeased = "ceased"
 
+	//ListenerProtocolHTTPS https
+	ListenerProtocolHTTPS = "https"
+	//ListenerProtocolSSL ssl
+	ListenerProtocolSSL = "ssl"
+
 	//JobStatusUnknown unknown
 	JobStatusUnknown = "unknown"
 	//JobStatusSuccessful successful
@@ -47,6 +55,11 @@ const (
 	//JobStatusWorking working
 	JobStatusWorking = "working"
 
+	//EIPStatusAvailable available
+	EIPStatusAvailable = "available"
+	//EIPStatusAssociated associated
+	EIPStatusAssociated = "associated"
+
 	defaultOpTimeout    = 180 * time.Second
 	defaultWaitInterval = 10 * time.Second
 )
@@ -54,20 +67,46 @@ const (
 // QingCloudClient QingCloud IaaS Advanced Client
 type QingCloudClient interface {
 	RunInstance(arg *service.RunInstancesInput) (*service.Instance, error)
+	RunInstanceWithTags(arg *service.RunInstancesInput, tagIDs []string) (*service.Instance, error)
 	DescribeInstance(instanceID string) (*service.Instance, error)
 	StartInstance(instanceID string) error
 	StopInstance(instanceID string, force bool) error
 	RestartInstance(instanceID string) error
 	TerminateInstance(instanceID string) error
 	WaitInstanceStatus(instanceID string, status string) (*service.Instance, error)
+	WatchInstanceStatus(ctx context.Context, instanceIDs []string) <-chan *InstanceStatusEvent
+	Close() error
+	Shutdown(ctx context.Context) error
+}
+
+// ErrClientClosed is returned by the operations of a client after it is closed
+var ErrClientClosed = errors.New("QingCloud client closed")
+
+// ClientOptions the options of NewClientWithOptions
+type ClientOptions struct {
+	// RequestPolicies override the retries and rate limit of the requests of the client, keyed like
+	// RequestPolicies of the config, e.g. "Job" or "describe" to poll faster and "UpdateLoadBalancers"
+	// to retry less. They take precedence over the policies of the same keys in the config,
+	// which is not changed.
+	RequestPolicies map[string]*config.RequestPolicy
 }
 
 // NewClient return a new QingCloudClient
 func NewClient(config *config.Config, zone string) (QingCloudClient, error) {
+	return NewClientWithOptions(config, zone, nil)
+}
+
+// NewClientWithOptions return a new QingCloudClient with the options
+func NewClientWithOptions(config *config.Config, zone string, opts *ClientOptions) (QingCloudClient, error) {
 	qcService, err := service.Init(config)
 	if err != nil {
 		return nil, err
 	}
+	if opts != nil {
+		for key, policy := range opts.RequestPolicies {
+			qcService = qcService.WithRequestPolicy(key, policy)
+		}
+	}
 	instanceService, err := qcService.Instance(zone)
 	if err != nil {
 		return nil, err
@@ -76,13 +115,20 @@ func NewClient(config *config.Config, zone string) (QingCloudClient, error) {
 	if err != nil {
 		return nil, err
 	}
+	tagService, err := qcService.Tag(zone)
+	if err != nil {
+		return nil, err
+	}
 
 	c := &client{
 		InstanceService:  instanceService,
 		JobService:       jobService,
+		TagService:       tagService,
 		OperationTimeout: defaultOpTimeout,
 		WaitInterval:     defaultWaitInterval,
 		zone:             zone,
+		config:           qcService.Config,
+		done:             make(chan struct{}),
 	}
 	return c, nil
 }
@@ -90,28 +136,85 @@ func NewClient(config *config.Config, zone string) (QingCloudClient, error) {
 type client struct {
 	InstanceService  *service.InstanceService
 	JobService       *service.JobService
+	TagService       *service.TagService
 	OperationTimeout time.Duration
 	WaitInterval     time.Duration
 	zone             string
+	config           *config.Config
+
+	mutex    sync.Mutex
+	closed   bool
+	done     chan struct{}
+	inflight sync.WaitGroup
+}
+
+// Close stop the client, see Shutdown
+func (c *client) Close() error {
+	return c.Shutdown(context.Background())
 }
 
-// RunInstance
+// Shutdown stop the client, new operations are rejected and the waiting of running operations is canceled,
+// it waits the running operations and mirrored requests return until ctx is done, then closes the idle connections
+func (c *client) Shutdown(ctx context.Context) error {
+	c.mutex.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.done)
+	}
+	c.mutex.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		c.inflight.Wait()
+		close(drained)
+	}()
+
+	var err error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if mirrorErr := c.config.ShutdownMirrors(ctx); err == nil {
+		err = mirrorErr
+	}
+	c.config.CloseIdleConnections()
+	return err
+}
+
+func (c *client) begin() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return ErrClientClosed
+	}
+	c.inflight.Add(1)
+	return nil
+}
+
+// RunInstance run the instance with the default tags in the config
 func (c *client) RunInstance(input *service.RunInstancesInput) (*service.Instance, error) {
+	if err := c.begin(); err != nil {
+		return nil, err
+	}
+	defer c.inflight.Done()
+	return c.runInstanceWithTags(input, nil)
+}
 
+func (c *client) runInstance(input *service.RunInstancesInput) (*service.Instance, error) {
 	output, err := c.InstanceService.RunInstances(input)
 	if err != nil {
 		return nil, err
 	}
-	if len(output.Instances) == 0 {
+	if len(output.Instances) == 0 || output.Instances[0] == nil {
 		return nil, errors.New("Create instance response error")
 	}
-	jobID := output.JobID
-	jobErr := c.waitJob(*jobID)
+	jobErr := c.waitJob(output.JobID)
 	if jobErr != nil {
 		return nil, jobErr
 	}
 	instanceID := *output.Instances[0]
-	_, waitErr := c.WaitInstanceStatus(instanceID, InstanceStatusRunning)
+	_, waitErr := c.waitInstanceStatus(instanceID, InstanceStatusRunning)
 	if waitErr != nil {
 		return nil, waitErr
 	}
@@ -122,37 +225,86 @@ func (c *client) RunInstance(input *service.RunInstancesInput) (*service.Instanc
 	return ins, nil
 }
 
-// DescribeInstance
-func (c *client) DescribeInstance(instanceID string) (*service.Instance, error) {
-	input := &service.DescribeInstancesInput{Instances: []*string{&instanceID}}
-	output, err := c.InstanceService.DescribeInstances(input)
+// RunInstanceWithTags run the instance with tags and the default tags in the config passed on the create request,
+// tags which are not attached after the instance is running will be attached by AttachTags
+func (c *client) RunInstanceWithTags(input *service.RunInstancesInput, tagIDs []string) (*service.Instance, error) {
+	if err := c.begin(); err != nil {
+		return nil, err
+	}
+	defer c.inflight.Done()
+	return c.runInstanceWithTags(input, tagIDs)
+}
+
+func (c *client) runInstanceWithTags(input *service.RunInstancesInput, tagIDs []string) (*service.Instance, error) {
+	defaultTagIDs, err := DefaultTagIDs(c.TagService, ResourceTypeInstance)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs = mergeTags(defaultTagIDs, tagIDs)
+	if len(tagIDs) == 0 {
+		return c.runInstance(input)
+	}
+	// the tags are set on a copy to leave the input of the caller unchanged
+	withTags := service.RunInstancesInput{}
+	if input != nil {
+		withTags = *input
+	}
+	if withTags.Tags == nil {
+		withTags.Tags = service.String(strings.Join(tagIDs, ","))
+	}
+	ins, err := c.runInstance(&withTags)
 	if err != nil {
 		return nil, err
 	}
-	if len(output.InstanceSet) == 0 {
-		return nil, fmt.Errorf("Instance with id [%s] not exist", instanceID)
+	missing := MissingTags(ins.Tags, tagIDs)
+	if len(missing) == 0 {
+		return ins, nil
+	}
+	err = AttachTags(c.TagService, ResourceTypeInstance, []string{*ins.InstanceID}, missing)
+	if err != nil {
+		return ins, err
+	}
+	return describeInstance(c.InstanceService, *ins.InstanceID)
+}
+
+// DescribeInstance
+func (c *client) DescribeInstance(instanceID string) (*service.Instance, error) {
+	if err := c.begin(); err != nil {
+		return nil, err
 	}
-	return output.InstanceSet[0], nil
+	defer c.inflight.Done()
+	return describeInstance(c.InstanceService, instanceID)
 }
 
 // StartInstance
 func (c *client) StartInstance(instanceID string) error {
+	if err := c.begin(); err != nil {
+		return err
+	}
+	defer c.inflight.Done()
 	input := &service.StartInstancesInput{Instances: []*string{&instanceID}}
-	output, err := c.InstanceService.StartInstances(input)
+	var output *service.StartInstancesOutput
+	err := requeueInstanceOnConflict(c.InstanceService, instanceID, c.WaitInterval, c.done, func() (err error) {
+		output, err = c.InstanceService.StartInstances(input)
+		return
+	})
 	if err != nil {
 		return err
 	}
-	jobID := output.JobID
-	waitErr := c.waitJob(*jobID)
+	waitErr := c.waitJob(output.JobID)
 	if waitErr != nil {
 		return waitErr
 	}
-	_, err = c.WaitInstanceStatus(instanceID, InstanceStatusRunning)
+	_, err = c.waitInstanceStatus(instanceID, InstanceStatusRunning)
 	return err
 }
 
 // StopInstance
 func (c *client) StopInstance(instanceID string, force bool) error {
+	if err := c.begin(); err != nil {
+		return err
+	}
+	defer c.inflight.Done()
 	var forceParam int
 	if force {
 		forceParam = 1
@@ -160,60 +312,120 @@ func (c *client) StopInstance(instanceID string, force bool) error {
 		forceParam = 0
 	}
 	input := &service.StopInstancesInput{Instances: []*string{&instanceID}, Force: &forceParam}
-	output, err := c.InstanceService.StopInstances(input)
+	var output *service.StopInstancesOutput
+	err := requeueInstanceOnConflict(c.InstanceService, instanceID, c.WaitInterval, c.done, func() (err error) {
+		output, err = c.InstanceService.StopInstances(input)
+		return
+	})
 	if err != nil {
 		return err
 	}
-	jobID := output.JobID
-	waitErr := c.waitJob(*jobID)
+	waitErr := c.waitJob(output.JobID)
 	if waitErr != nil {
 		return waitErr
 	}
-	_, err = c.WaitInstanceStatus(instanceID, InstanceStatusStopped)
+	_, err = c.waitInstanceStatus(instanceID, InstanceStatusStopped)
 	return err
 }
 
 // RestartInstance
 func (c *client) RestartInstance(instanceID string) error {
+	if err := c.begin(); err != nil {
+		return err
+	}
+	defer c.inflight.Done()
 	input := &service.RestartInstancesInput{Instances: []*string{&instanceID}}
-	output, err := c.InstanceService.RestartInstances(input)
+	var output *service.RestartInstancesOutput
+	err := requeueInstanceOnConflict(c.InstanceService, instanceID, c.WaitInterval, c.done, func() (err error) {
+		output, err = c.InstanceService.RestartInstances(input)
+		return
+	})
 	if err != nil {
 		return err
 	}
-	jobID := output.JobID
-	waitErr := c.waitJob(*jobID)
+	waitErr := c.waitJob(output.JobID)
 	if waitErr != nil {
 		return waitErr
 	}
-	_, err = c.WaitInstanceStatus(instanceID, InstanceStatusRunning)
+	_, err = c.waitInstanceStatus(instanceID, InstanceStatusRunning)
 	return err
 }
 
 // TerminateInstance
 func (c *client) TerminateInstance(instanceID string) error {
+	if err := c.begin(); err != nil {
+		return err
+	}
+	defer c.inflight.Done()
 	input := &service.TerminateInstancesInput{Instances: []*string{&instanceID}}
-	output, err := c.InstanceService.TerminateInstances(input)
+	var output *service.TerminateInstancesOutput
+	err := requeueInstanceOnConflict(c.InstanceService, instanceID, c.WaitInterval, c.done, func() (err error) {
+		output, err = c.InstanceService.TerminateInstances(input)
+		return
+	})
 	if err != nil {
 		return err
 	}
-	jobID := output.JobID
-	waitErr := c.waitJob(*jobID)
+	waitErr := c.waitJob(output.JobID)
 	if waitErr != nil {
 		return waitErr
 	}
-	_, err = c.WaitInstanceStatus(instanceID, InstanceStatusTerminated)
+	_, err = c.waitInstanceStatus(instanceID, InstanceStatusTerminated)
 	return err
 }
 
-func (c *client) waitJob(jobID string) error {
-	return WaitJob(c.JobService, jobID, c.OperationTimeout, c.WaitInterval)
+func (c *client) waitJob(jobID *string) error {
+	if jobID == nil || *jobID == "" {
+		return errors.New("Job ID not returned")
+	}
+	return waitJobUntil(c.JobService, *jobID, c.OperationTimeout, c.WaitInterval, c.done)
 }
 
 // WaitInstanceStatus
 func (c *client) WaitInstanceStatus(instanceID string, status string) (*service.Instance, error) {
-	return WaitInstanceStatus(c.InstanceService, instanceID, status, c.OperationTimeout, c.WaitInterval)
+	if err := c.begin(); err != nil {
+		return nil, err
+	}
+	defer c.inflight.Done()
+	return c.waitInstanceStatus(instanceID, status)
+}
+
+func (c *client) waitInstanceStatus(instanceID string, status string) (*service.Instance, error) {
+	return waitInstanceStatusUntil(c.InstanceService, instanceID, status, c.OperationTimeout, c.WaitInterval, c.done)
+}
+
+// WatchInstanceStatus
+func (c *client) WatchInstanceStatus(ctx context.Context, instanceIDs []string) <-chan *InstanceStatusEvent {
+	if err := c.begin(); err != nil {
+		events := make(chan *InstanceStatusEvent, 1)
+		events <- &InstanceStatusEvent{Time: utils.DefaultClock.Now(), Err: err}
+		close(events)
+		return events
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+
+	events := make(chan *InstanceStatusEvent)
+	go func() {
+		defer c.inflight.Done()
+		defer cancel()
+		defer close(events)
+		for event := range WatchInstanceStatus(ctx, c.InstanceService, instanceIDs, c.WaitInterval) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return events
 }
 
 func (c *client) waitInstanceNetwork(instanceID string) (*service.Instance, error) {
-	return WaitInstanceNetwork(c.InstanceService, instanceID, c.OperationTimeout, c.WaitInterval)
+	return waitInstanceNetworkUntil(c.InstanceService, instanceID, c.OperationTimeout, c.WaitInterval, c.done)
 }
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+func TestRunInstanceWithTags(t *testing.T) {
+	defer utils.SetDefaultClock(utils.NewFakeClock(time.Now()))()
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.respond("RunInstances", `{"action":"RunInstancesResponse","ret_code":0,"instances":["i-1"],"job_id":"j-1"}`)
+	api.respond("DescribeJobs", `{"action":"DescribeJobsResponse","ret_code":0,"total_count":1,"job_set":[{"job_id":"j-1","status":"successful"}]}`)
+	api.respond("DescribeInstances", `{"action":"DescribeInstancesResponse","ret_code":0,"total_count":1,"instance_set":[{"instance_id":"i-1","status":"running","vxnets":[{"private_ip":"192.168.0.2"}]}]}`)
+	qcService.Config.DefaultTags = []string{"tag-default"}
+	c, err := NewClient(qcService.Config, "pek3a")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	input := &service.RunInstancesInput{ImageID: service.String("img-1"), LoginMode: service.String("keypair")}
+	ins, err := c.RunInstanceWithTags(input, []string{"tag-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "i-1", service.StringValue(ins.InstanceID))
+	assert.Nil(t, input.Tags)
+
+	calls := api.called("RunInstances")
+	if assert.Len(t, calls, 1) {
+		assert.Equal(t, "tag-default,tag-1", calls[0].Get("tags"))
+		assert.Equal(t, "img-1", calls[0].Get("image_id"))
+	}
+	// the tags not attached by RunInstances are attached after the instance is running
+	assert.Len(t, api.called("AttachTags"), 1)
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	configPolicy := &config.RequestPolicy{Retries: service.Int(1)}
+	jobPolicy := &config.RequestPolicy{Retries: service.Int(5), RetryInterval: 0.1}
+	lbPolicy := &config.RequestPolicy{Retries: service.Int(0)}
+	qcService.Config.RequestPolicies = map[string]*config.RequestPolicy{"Job": configPolicy}
+
+	c, err := NewClientWithOptions(qcService.Config, "pek3a", &ClientOptions{
+		RequestPolicies: map[string]*config.RequestPolicy{"Job": jobPolicy, "UpdateLoadBalancers": lbPolicy},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	conf := c.(*client).JobService.Config
+	assert.True(t, conf.RequestPolicy("Job", "DescribeJobs") == jobPolicy)
+	assert.True(t, conf.RequestPolicy("LoadBalancer", "UpdateLoadBalancers") == lbPolicy)
+	assert.True(t, c.(*client).InstanceService.Config.RequestPolicy("Job", "DescribeJobs") == jobPolicy)
+	// the config of the caller is unchanged
+	assert.Equal(t, map[string]*config.RequestPolicy{"Job": configPolicy}, qcService.Config.RequestPolicies)
+}
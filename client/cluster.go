@@ -0,0 +1,314 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+const (
+	//ClusterStatusPending pending
+	ClusterStatusPending = "pending"
+	//ClusterStatusActive active
+	ClusterStatusActive = "active"
+	//ClusterStatusStopped stopped
+	ClusterStatusStopped = "stopped"
+	//ClusterStatusSuspended suspended
+	ClusterStatusSuspended = "suspended"
+	//ClusterStatusDeleted deleted
+	ClusterStatusDeleted = "deleted"
+	//ClusterStatusCeased ceased
+	ClusterStatusCeased = "ceased"
+
+	//SnapshotStatusPending pending
+	SnapshotStatusPending = "pending"
+	//SnapshotStatusAvailable available
+	SnapshotStatusAvailable = "available"
+)
+
+func describeCluster(clusterService *service.ClusterService, clusterID string) (*service.Cluster, error) {
+	output, err := clusterService.DescribeClusters(&service.DescribeClustersInput{
+		Clusters: []*string{&clusterID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.ClusterSet) == 0 {
+		return nil, fmt.Errorf("Cluster with id [%s] not exist", clusterID)
+	}
+	return output.ClusterSet[0], nil
+}
+
+// WaitClusterStatus wait the cluster with this clusterID to expect status
+func WaitClusterStatus(clusterService *service.ClusterService, clusterID string, status string, timeout time.Duration, waitInterval time.Duration) (cluster *service.Cluster, err error) {
+	logger.Debug("Waiting for Cluster [%s] status [%s] ", clusterID, status)
+	errorTimes := 0
+	err = utils.WaitForSpecificOrError(func() (bool, error) {
+		c, err := describeCluster(clusterService, clusterID)
+		if err != nil {
+			logger.Error("DescribeCluster [%s] error : [%s]", clusterID, err.Error())
+			errorTimes++
+			if errorTimes > 3 {
+				return false, err
+			}
+			return false, nil
+		}
+		if c.Status != nil && *c.Status == status {
+			if c.TransitionStatus != nil && *c.TransitionStatus != "" {
+				//wait transition to finished
+				return false, nil
+			}
+			cluster = c
+			logger.Debug("Cluster [%s] status is [%s] ", clusterID, *c.Status)
+			return true, nil
+		}
+		return false, nil
+	}, timeout, waitInterval)
+	return
+}
+
+func describeSnapshot(snapshotService *service.SnapshotService, snapshotID string) (*service.Snapshot, error) {
+	output, err := snapshotService.DescribeSnapshots(&service.DescribeSnapshotsInput{
+		Snapshots: []*string{&snapshotID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.SnapshotSet) == 0 {
+		return nil, fmt.Errorf("Snapshot with id [%s] not exist", snapshotID)
+	}
+	return output.SnapshotSet[0], nil
+}
+
+// WaitSnapshotStatus wait the snapshot with this snapshotID to expect status
+func WaitSnapshotStatus(snapshotService *service.SnapshotService, snapshotID string, status string, timeout time.Duration, waitInterval time.Duration) (snapshot *service.Snapshot, err error) {
+	logger.Debug("Waiting for Snapshot [%s] status [%s] ", snapshotID, status)
+	errorTimes := 0
+	err = utils.WaitForSpecificOrError(func() (bool, error) {
+		s, err := describeSnapshot(snapshotService, snapshotID)
+		if err != nil {
+			logger.Error("DescribeSnapshot [%s] error : [%s]", snapshotID, err.Error())
+			errorTimes++
+			if errorTimes > 3 {
+				return false, err
+			}
+			return false, nil
+		}
+		if s.Status != nil && *s.Status == status {
+			if s.TransitionStatus != nil && *s.TransitionStatus != "" {
+				//wait transition to finished
+				return false, nil
+			}
+			snapshot = s
+			logger.Debug("Snapshot [%s] status is [%s] ", snapshotID, *s.Status)
+			return true, nil
+		}
+		return false, nil
+	}, timeout, waitInterval)
+	return
+}
+
+// BackupCluster create a backup of the cluster and wait it available, it returns the ID of the backup snapshot
+func BackupCluster(snapshotService *service.SnapshotService, clusterID string, name string, full bool, timeout time.Duration, waitInterval time.Duration) (string, error) {
+	isFull := 0
+	if full {
+		isFull = 1
+	}
+	input := &service.CreateSnapshotsInput{
+		Resources: []*string{service.String(clusterID)},
+		IsFull:    service.Int(isFull),
+	}
+	if name != "" {
+		input.SnapshotName = service.String(name)
+	}
+	output, err := snapshotService.CreateSnapshots(input)
+	if err != nil {
+		return "", err
+	}
+	if len(output.Snapshots) == 0 || output.Snapshots[0] == nil {
+		return "", fmt.Errorf("Backup cluster [%s] response error", clusterID)
+	}
+	snapshotID := *output.Snapshots[0]
+	err = attachDefaultTags(snapshotService.Config, snapshotService.Properties.Zone, ResourceTypeSnapshot, snapshotID)
+	if err != nil {
+		return "", err
+	}
+	_, err = WaitSnapshotStatus(snapshotService, snapshotID, SnapshotStatusAvailable, timeout, waitInterval)
+	if err != nil {
+		return "", err
+	}
+	return snapshotID, nil
+}
+
+// DescribeClusterBackups list all backup snapshots of the cluster
+func DescribeClusterBackups(snapshotService *service.SnapshotService, clusterID string) ([]*service.Snapshot, error) {
+	backups := []*service.Snapshot{}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := snapshotService.DescribeSnapshots(&service.DescribeSnapshotsInput{
+			ResourceID: service.String(clusterID),
+			Limit:      service.Int(limit),
+			Offset:     service.Int(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, output.SnapshotSet...)
+		if len(output.SnapshotSet) < limit {
+			return backups, nil
+		}
+	}
+}
+
+// RestoreClusterToNew create a new cluster from the backup snapshot with conf and wait it active,
+// it returns the ID of the new cluster
+func RestoreClusterToNew(clusterService *service.ClusterService, jobService *service.JobService, snapshotID string, conf string, timeout time.Duration, waitInterval time.Duration) (string, error) {
+	output, err := clusterService.CreateClusterFromSnapshot(&service.CreateClusterFromSnapshotInput{
+		SnapshotID: service.String(snapshotID),
+		Conf:       service.String(conf),
+	})
+	if err != nil {
+		return "", err
+	}
+	if output.ClusterID == nil {
+		return "", fmt.Errorf("Restore cluster from snapshot [%s] response error", snapshotID)
+	}
+	err = attachDefaultTags(clusterService.Config, clusterService.Properties.Zone, ResourceTypeCluster, *output.ClusterID)
+	if err != nil {
+		return "", err
+	}
+	if output.JobID != nil {
+		err = waitOutputJob(jobService, output.JobID, timeout, waitInterval)
+		if err != nil {
+			return "", err
+		}
+	}
+	_, err = WaitClusterStatus(clusterService, *output.ClusterID, ClusterStatusActive, timeout, waitInterval)
+	if err != nil {
+		return "", err
+	}
+	return *output.ClusterID, nil
+}
+
+// RestoreCluster restore the cluster in place from the backup snapshot and wait it active
+func RestoreCluster(clusterService *service.ClusterService, jobService *service.JobService, clusterID string, snapshotID string, timeout time.Duration, waitInterval time.Duration) error {
+	output, err := clusterService.RestoreClusterFromSnapshot(&service.RestoreClusterFromSnapshotInput{
+		Cluster:  service.String(clusterID),
+		Snapshot: service.String(snapshotID),
+	})
+	if err != nil {
+		return err
+	}
+	return waitClusterJob(clusterService, jobService, clusterID, output.JobID, timeout, waitInterval)
+}
+
+// SetClusterHealthCheck set the health check and auto-recovery of the cluster role
+func SetClusterHealthCheck(clusterService *service.ClusterService, clusterID string, role string, check *service.ClusterHealthCheck) error {
+	cluster, err := describeCluster(clusterService, clusterID)
+	if err != nil {
+		return err
+	}
+	if !cluster.HasRole(role) {
+		return fmt.Errorf("Cluster [%s] has no role [%s]", clusterID, role)
+	}
+	_, err = clusterService.SetClusterHealthCheck(clusterID, role, check)
+	return err
+}
+
+// CordonClusterNode stop the health check and auto-recovery of the cluster node, for maintenance
+func CordonClusterNode(clusterService *service.ClusterService, jobService *service.JobService, clusterID string, nodeID string, timeout time.Duration, waitInterval time.Duration) error {
+	output, err := clusterService.CordonClusterNodes(&service.CordonClusterNodesInput{
+		Cluster: service.String(clusterID),
+		Nodes:   []*string{service.String(nodeID)},
+	})
+	if err != nil {
+		return err
+	}
+	return waitClusterJob(clusterService, jobService, clusterID, output.JobID, timeout, waitInterval)
+}
+
+// UncordonClusterNode resume the health check and auto-recovery of the cluster node
+func UncordonClusterNode(clusterService *service.ClusterService, jobService *service.JobService, clusterID string, nodeID string, timeout time.Duration, waitInterval time.Duration) error {
+	output, err := clusterService.UncordonClusterNodes(&service.UncordonClusterNodesInput{
+		Cluster: service.String(clusterID),
+		Nodes:   []*string{service.String(nodeID)},
+	})
+	if err != nil {
+		return err
+	}
+	return waitClusterJob(clusterService, jobService, clusterID, output.JobID, timeout, waitInterval)
+}
+
+// RepairClusterNode repair the cluster node and wait the cluster active
+func RepairClusterNode(clusterService *service.ClusterService, jobService *service.JobService, clusterID string, nodeID string, timeout time.Duration, waitInterval time.Duration) error {
+	output, err := clusterService.RepairClusterNodes(&service.RepairClusterNodesInput{
+		Cluster: service.String(clusterID),
+		Nodes:   []*string{service.String(nodeID)},
+	})
+	if err != nil {
+		return err
+	}
+	return waitClusterJob(clusterService, jobService, clusterID, output.JobID, timeout, waitInterval)
+}
+
+func waitClusterJob(clusterService *service.ClusterService, jobService *service.JobService, clusterID string, jobID *string, timeout time.Duration, waitInterval time.Duration) error {
+	if jobID != nil {
+		err := WaitJob(jobService, *jobID, timeout, waitInterval)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := WaitClusterStatus(clusterService, clusterID, ClusterStatusActive, timeout, waitInterval)
+	return err
+}
+
+// DescribeClusterNodesOfRole list all nodes of the cluster with the role, or all nodes of the cluster if role is empty
+func DescribeClusterNodesOfRole(clusterService *service.ClusterService, clusterID string, role service.ClusterNodeRole) ([]*service.ClusterNode, error) {
+	nodes := []*service.ClusterNode{}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		input := &service.DescribeClusterNodesInput{
+			Cluster: service.String(clusterID),
+			Limit:   service.Int(limit),
+			Offset:  service.Int(offset),
+		}
+		if role != "" {
+			input.Role = service.String(string(role))
+		}
+		output, err := clusterService.DescribeClusterNodes(input)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, output.NodeSet...)
+		if len(output.NodeSet) < limit {
+			return nodes, nil
+		}
+	}
+}
+
+// WaitClusterRoleReady wait all nodes of the cluster with the role ready, see service.ClusterNode.IsReady
+func WaitClusterRoleReady(clusterService *service.ClusterService, clusterID string, role service.ClusterNodeRole, timeout time.Duration, waitInterval time.Duration) (nodes []*service.ClusterNode, err error) {
+	logger.Debug("Waiting for Cluster [%s] role [%s] ready", clusterID, role)
+	errorTimes := 0
+	err = utils.WaitForSpecificOrError(func() (bool, error) {
+		n, err := DescribeClusterNodesOfRole(clusterService, clusterID, role)
+		if err != nil {
+			logger.Error("DescribeClusterNodes [%s] error : [%s]", clusterID, err.Error())
+			errorTimes++
+			if errorTimes > 3 {
+				return false, err
+			}
+			return false, nil
+		}
+		if !service.ClusterNodesReady(n) {
+			return false, nil
+		}
+		logger.Debug("Cluster [%s] role [%s] is ready", clusterID, role)
+		nodes = n
+		return true, nil
+	}, timeout, waitInterval)
+	return
+}
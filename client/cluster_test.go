@@ -0,0 +1,33 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeClusterBackupsWithoutTotalCount(t *testing.T) {
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.handle("DescribeSnapshots", func(params url.Values) string {
+		assert.Equal(t, "cl-1", params.Get("resource_id"))
+		offset, _ := strconv.Atoi(params.Get("offset"))
+		items := []string{}
+		for i := offset; i < 150 && i < offset+100; i++ {
+			items = append(items, fmt.Sprintf(`{"snapshot_id":"ss-%d"}`, i))
+		}
+		return fmt.Sprintf(`{"action":"DescribeSnapshotsResponse","ret_code":0,"snapshot_set":[%s]}`, strings.Join(items, ","))
+	})
+	snapshotService, err := qcService.Snapshot("pek3a")
+	assert.Nil(t, err)
+
+	backups, err := DescribeClusterBackups(snapshotService, "cl-1")
+	assert.Nil(t, err)
+	assert.Equal(t, 150, len(backups))
+	assert.Equal(t, "ss-149", *backups[149].SnapshotID)
+	assert.Equal(t, 2, len(api.called("DescribeSnapshots")))
+}
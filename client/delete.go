@@ -0,0 +1,473 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+// DeleteStep a step of the plan to delete a resource with its dependencies
+type DeleteStep struct {
+	// Action the API to call, e.g. DetachVolumes
+	Action string
+	// ResourceIDs the resources the action applies to
+	ResourceIDs []string
+	// Target the instance, vxnet or router the resources are detached from, if any
+	Target string
+
+	run func() (*string, error)
+}
+
+func (s *DeleteStep) String() string {
+	if s.Target != "" {
+		return fmt.Sprintf("%s %s from %s", s.Action, strings.Join(s.ResourceIDs, ","), s.Target)
+	}
+	return fmt.Sprintf("%s %s", s.Action, strings.Join(s.ResourceIDs, ","))
+}
+
+// DeleteOptions the options of DeleteWithDependencies
+type DeleteOptions struct {
+	// DryRun only returns the plan without deleting anything
+	DryRun bool
+	// DeleteDependencies also deletes the volumes and releases the EIPs detached from the instance,
+	// the shared vxnets, routers and security groups are always kept
+	DeleteDependencies bool
+	Timeout            time.Duration
+	WaitInterval       time.Duration
+}
+
+// DeleteWithDependencies delete the resource with this resourceID in the correct order,
+// dissociating the EIPs, detaching the volumes and leaving the vxnets before it is deleted,
+// each step waits its job finished. It returns the planned steps, which are all executed unless DryRun.
+func DeleteWithDependencies(s *DependencyServices, jobService *service.JobService, resourceID string, opts *DeleteOptions) ([]*DeleteStep, error) {
+	if opts == nil {
+		opts = &DeleteOptions{}
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultOpTimeout
+	}
+	if opts.WaitInterval == 0 {
+		opts.WaitInterval = defaultWaitInterval
+	}
+	steps, err := s.planDelete(resourceID, opts.DeleteDependencies)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DryRun {
+		return steps, nil
+	}
+	for _, step := range steps {
+		logger.Debug("Deleting [%s] step: %s", resourceID, step)
+		jobID, err := step.run()
+		if err != nil {
+			return steps, fmt.Errorf("%s error : %s", step, err.Error())
+		}
+		if jobID != nil {
+			if err := WaitJob(jobService, *jobID, opts.Timeout, opts.WaitInterval); err != nil {
+				return steps, fmt.Errorf("%s error : %s", step, err.Error())
+			}
+		}
+	}
+	return steps, nil
+}
+
+func (s *DependencyServices) planDelete(resourceID string, deleteDependencies bool) ([]*DeleteStep, error) {
+	switch ResourceTypeOf(resourceID) {
+	case ResourceTypeInstance:
+		return s.planDeleteInstance(resourceID, deleteDependencies)
+	case ResourceTypeVolume:
+		return s.planDeleteVolume(resourceID)
+	case ResourceTypeEIP:
+		return s.planDeleteEIP(resourceID)
+	case ResourceTypeNIC:
+		return s.planDeleteNIC(resourceID)
+	case ResourceTypeVxNet:
+		return s.planDeleteVxNet(resourceID)
+	case ResourceTypeRouter:
+		return s.planDeleteRouter(resourceID)
+	case ResourceTypeSecurityGroup:
+		return []*DeleteStep{s.deleteSecurityGroupsStep(resourceID)}, nil
+	}
+	return nil, fmt.Errorf("Delete resource [%s] is not supported", resourceID)
+}
+
+func (s *DependencyServices) planDeleteInstance(instanceID string, deleteDependencies bool) ([]*DeleteStep, error) {
+	graph, err := BuildDependencyGraph(s, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	var eips, volumes, vxnets []string
+	for _, node := range graph.Dependencies(instanceID) {
+		switch node.Type {
+		case ResourceTypeEIP:
+			eips = append(eips, node.ID)
+		case ResourceTypeVolume:
+			volumes = append(volumes, node.ID)
+		case ResourceTypeNIC:
+			for _, vxnet := range graph.Dependencies(node.ID) {
+				vxnets = append(vxnets, vxnet.ID)
+			}
+		case ResourceTypeVxNet:
+			vxnets = append(vxnets, node.ID)
+		}
+	}
+
+	eips, err = s.eipsBoundTo(eips, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []*DeleteStep{}
+	if len(eips) > 0 {
+		steps = append(steps, s.dissociateEIPsStep(eips, instanceID))
+	}
+	if len(volumes) > 0 {
+		steps = append(steps, s.detachVolumesStep(volumes, instanceID))
+	}
+	for _, vxnetID := range vxnets {
+		if vxnetID == "vxnet-0" {
+			// the basic network can not be left
+			continue
+		}
+		steps = append(steps, s.leaveVxNetStep([]string{instanceID}, vxnetID))
+	}
+	steps = append(steps, &DeleteStep{
+		Action:      "TerminateInstances",
+		ResourceIDs: []string{instanceID},
+		run: func() (*string, error) {
+			output, err := s.InstanceService.TerminateInstances(&service.TerminateInstancesInput{
+				Instances: []*string{service.String(instanceID)},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return output.JobID, nil
+		},
+	})
+	if !deleteDependencies {
+		return steps, nil
+	}
+	if len(volumes) > 0 {
+		steps = append(steps, s.deleteVolumesStep(volumes))
+	}
+	if len(eips) > 0 {
+		steps = append(steps, s.releaseEIPsStep(eips))
+	}
+	return steps, nil
+}
+
+func (s *DependencyServices) planDeleteVolume(volumeID string) ([]*DeleteStep, error) {
+	output, err := s.VolumeService.DescribeVolumes(&service.DescribeVolumesInput{
+		Volumes: []*string{service.String(volumeID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.VolumeSet) == 0 {
+		return nil, fmt.Errorf("Volume with id [%s] not exist", volumeID)
+	}
+	steps := []*DeleteStep{}
+	if instance := output.VolumeSet[0].Instance; instance != nil && service.StringValue(instance.InstanceID) != "" {
+		steps = append(steps, s.detachVolumesStep([]string{volumeID}, *instance.InstanceID))
+	}
+	return append(steps, s.deleteVolumesStep([]string{volumeID})), nil
+}
+
+func (s *DependencyServices) planDeleteEIP(eipID string) ([]*DeleteStep, error) {
+	output, err := s.EIPService.DescribeEIPs(&service.DescribeEIPsInput{
+		EIPs: []*string{service.String(eipID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.EIPSet) == 0 {
+		return nil, fmt.Errorf("EIP with id [%s] not exist", eipID)
+	}
+	steps := []*DeleteStep{}
+	if resourceID, bound := eipBinding(output.EIPSet[0]); bound {
+		if ResourceTypeOf(resourceID) != ResourceTypeInstance {
+			return nil, fmt.Errorf("EIP [%s] is associated with [%s], only EIPs of instances can be dissociated", eipID, resourceID)
+		}
+		steps = append(steps, s.dissociateEIPsStep([]string{eipID}, resourceID))
+	}
+	return append(steps, s.releaseEIPsStep([]string{eipID})), nil
+}
+
+// eipsBoundTo returns the EIPs of eipIDs which are associated with the resource
+func (s *DependencyServices) eipsBoundTo(eipIDs []string, resourceID string) ([]string, error) {
+	if len(eipIDs) == 0 {
+		return nil, nil
+	}
+	output, err := s.EIPService.DescribeEIPs(&service.DescribeEIPsInput{
+		EIPs:  service.StringSlice(eipIDs),
+		Limit: service.Int(len(eipIDs)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	bound := []string{}
+	for _, eip := range output.EIPSet {
+		if id, ok := eipBinding(eip); ok && id == resourceID {
+			bound = append(bound, service.StringValue(eip.EIPID))
+		}
+	}
+	return bound, nil
+}
+
+// eipBinding returns the ID of the resource the EIP is associated with, if any
+func eipBinding(eip *service.EIP) (string, bool) {
+	if eip == nil || eip.Resource == nil || service.StringValue(eip.Resource.ResourceID) == "" {
+		return "", false
+	}
+	switch service.StringValue(eip.Status) {
+	case "", EIPStatusAssociated:
+		return *eip.Resource.ResourceID, true
+	}
+	return "", false
+}
+
+func (s *DependencyServices) planDeleteNIC(nicID string) ([]*DeleteStep, error) {
+	output, err := s.NICService.DescribeNics(&service.DescribeNicsInput{
+		Nics: []*string{service.String(nicID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.NICSet) == 0 {
+		return nil, fmt.Errorf("NIC with id [%s] not exist", nicID)
+	}
+	steps := []*DeleteStep{}
+	if instanceID := service.StringValue(output.NICSet[0].InstanceID); instanceID != "" {
+		steps = append(steps, &DeleteStep{
+			Action:      "DetachNics",
+			ResourceIDs: []string{nicID},
+			Target:      instanceID,
+			run: func() (*string, error) {
+				output, err := s.NICService.DetachNics(&service.DetachNicsInput{
+					Nics: []*string{service.String(nicID)},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return output.JobID, nil
+			},
+		})
+	}
+	return append(steps, s.deleteNicsStep([]string{nicID})), nil
+}
+
+func (s *DependencyServices) planDeleteVxNet(vxnetID string) ([]*DeleteStep, error) {
+	graph, err := BuildDependencyGraph(s, vxnetID)
+	if err != nil {
+		return nil, err
+	}
+	instanceIDs := []string{}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := s.VxNetService.DescribeVxNetInstances(&service.DescribeVxNetInstancesInput{
+			VxNet:  service.String(vxnetID),
+			Limit:  service.Int(limit),
+			Offset: service.Int(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, instance := range output.InstanceSet {
+			if service.StringValue(instance.InstanceID) != "" {
+				instanceIDs = append(instanceIDs, *instance.InstanceID)
+			}
+		}
+		if len(output.InstanceSet) < limit {
+			break
+		}
+	}
+	steps := []*DeleteStep{}
+	if len(instanceIDs) > 0 {
+		steps = append(steps, s.leaveVxNetStep(instanceIDs, vxnetID))
+	}
+	for _, router := range graph.Dependencies(vxnetID) {
+		steps = append(steps, s.leaveRouterStep([]string{vxnetID}, router.ID))
+	}
+	return append(steps, &DeleteStep{
+		Action:      "DeleteVxNets",
+		ResourceIDs: []string{vxnetID},
+		run: func() (*string, error) {
+			_, err := s.VxNetService.DeleteVxNets(&service.DeleteVxNetsInput{
+				VxNets: []*string{service.String(vxnetID)},
+			})
+			return nil, err
+		},
+	}), nil
+}
+
+func (s *DependencyServices) planDeleteRouter(routerID string) ([]*DeleteStep, error) {
+	vxnetIDs := []string{}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := s.RouterService.DescribeRouterVxNets(&service.DescribeRouterVxNetsInput{
+			Router: service.String(routerID),
+			Limit:  service.Int(limit),
+			Offset: service.Int(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, vxnet := range output.RouterVxNetSet {
+			if service.StringValue(vxnet.VxNetID) != "" {
+				vxnetIDs = append(vxnetIDs, *vxnet.VxNetID)
+			}
+		}
+		if len(output.RouterVxNetSet) < limit {
+			break
+		}
+	}
+	steps := []*DeleteStep{}
+	if len(vxnetIDs) > 0 {
+		steps = append(steps, s.leaveRouterStep(vxnetIDs, routerID))
+	}
+	return append(steps, &DeleteStep{
+		Action:      "DeleteRouters",
+		ResourceIDs: []string{routerID},
+		run: func() (*string, error) {
+			output, err := s.RouterService.DeleteRouters(&service.DeleteRoutersInput{
+				Routers: []*string{service.String(routerID)},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return output.JobID, nil
+		},
+	}), nil
+}
+
+func (s *DependencyServices) dissociateEIPsStep(eipIDs []string, target string) *DeleteStep {
+	return &DeleteStep{
+		Action:      "DissociateEIPs",
+		ResourceIDs: eipIDs,
+		Target:      target,
+		run: func() (*string, error) {
+			output, err := s.EIPService.DissociateEIPs(&service.DissociateEIPsInput{
+				EIPs: service.StringSlice(eipIDs),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return output.JobID, nil
+		},
+	}
+}
+
+func (s *DependencyServices) releaseEIPsStep(eipIDs []string) *DeleteStep {
+	return &DeleteStep{
+		Action:      "ReleaseEIPs",
+		ResourceIDs: eipIDs,
+		run: func() (*string, error) {
+			output, err := s.EIPService.ReleaseEIPs(&service.ReleaseEIPsInput{
+				EIPs: service.StringSlice(eipIDs),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return output.JobID, nil
+		},
+	}
+}
+
+func (s *DependencyServices) detachVolumesStep(volumeIDs []string, instanceID string) *DeleteStep {
+	return &DeleteStep{
+		Action:      "DetachVolumes",
+		ResourceIDs: volumeIDs,
+		Target:      instanceID,
+		run: func() (*string, error) {
+			output, err := s.VolumeService.DetachVolumes(&service.DetachVolumesInput{
+				Instance: service.String(instanceID),
+				Volumes:  service.StringSlice(volumeIDs),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return output.JobID, nil
+		},
+	}
+}
+
+func (s *DependencyServices) deleteVolumesStep(volumeIDs []string) *DeleteStep {
+	return &DeleteStep{
+		Action:      "DeleteVolumes",
+		ResourceIDs: volumeIDs,
+		run: func() (*string, error) {
+			output, err := s.VolumeService.DeleteVolumes(&service.DeleteVolumesInput{
+				Volumes: service.StringSlice(volumeIDs),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return output.JobID, nil
+		},
+	}
+}
+
+func (s *DependencyServices) deleteNicsStep(nicIDs []string) *DeleteStep {
+	return &DeleteStep{
+		Action:      "DeleteNics",
+		ResourceIDs: nicIDs,
+		run: func() (*string, error) {
+			_, err := s.NICService.DeleteNics(&service.DeleteNicsInput{
+				Nics: service.StringSlice(nicIDs),
+			})
+			return nil, err
+		},
+	}
+}
+
+func (s *DependencyServices) leaveVxNetStep(instanceIDs []string, vxnetID string) *DeleteStep {
+	return &DeleteStep{
+		Action:      "LeaveVxNet",
+		ResourceIDs: instanceIDs,
+		Target:      vxnetID,
+		run: func() (*string, error) {
+			output, err := s.VxNetService.LeaveVxNet(&service.LeaveVxNetInput{
+				Instances: service.StringSlice(instanceIDs),
+				VxNet:     service.String(vxnetID),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return output.JobID, nil
+		},
+	}
+}
+
+func (s *DependencyServices) leaveRouterStep(vxnetIDs []string, routerID string) *DeleteStep {
+	return &DeleteStep{
+		Action:      "LeaveRouter",
+		ResourceIDs: vxnetIDs,
+		Target:      routerID,
+		run: func() (*string, error) {
+			output, err := s.RouterService.LeaveRouter(&service.LeaveRouterInput{
+				Router: service.String(routerID),
+				VxNets: service.StringSlice(vxnetIDs),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return output.JobID, nil
+		},
+	}
+}
+
+func (s *DependencyServices) deleteSecurityGroupsStep(securityGroupID string) *DeleteStep {
+	return &DeleteStep{
+		Action:      "DeleteSecurityGroups",
+		ResourceIDs: []string{securityGroupID},
+		run: func() (*string, error) {
+			_, err := s.SecurityGroupService.DeleteSecurityGroups(&service.DeleteSecurityGroupsInput{
+				SecurityGroups: []*string{service.String(securityGroupID)},
+			})
+			return nil, err
+		},
+	}
+}
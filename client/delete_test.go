@@ -0,0 +1,125 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func stepStrings(steps []*DeleteStep) []string {
+	lines := make([]string, 0, len(steps))
+	for _, step := range steps {
+		lines = append(lines, step.String())
+	}
+	return lines
+}
+
+// pagedSet serve total items of the set, the ids are made of the prefix and the index
+func pagedSet(action, set, key, prefix string, total int) func(params url.Values) string {
+	return func(params url.Values) string {
+		offset, _ := strconv.Atoi(params.Get("offset"))
+		limit, _ := strconv.Atoi(params.Get("limit"))
+		items := []string{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			items = append(items, fmt.Sprintf(`{"%s":"%s%d"}`, key, prefix, i))
+		}
+		return fmt.Sprintf(`{"action":"%sResponse","ret_code":0,"total_count":%d,"%s":[%s]}`,
+			action, total, set, strings.Join(items, ","))
+	}
+}
+
+func TestDeleteVxNetPagesInstances(t *testing.T) {
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.respond("DescribeVxnets", `{"action":"DescribeVxnetsResponse","ret_code":0,"total_count":1,"vxnet_set":[
+		{"vxnet_id":"vxnet-1","vxnet_name":"web"}]}`)
+	api.handle("DescribeVxnetInstances", pagedSet("DescribeVxnetInstances", "instance_set", "instance_id", "i-", 150))
+	s, err := NewDependencyServices(qcService, "pek3a")
+	assert.Nil(t, err)
+
+	steps, err := DeleteWithDependencies(s, nil, "vxnet-1", &DeleteOptions{DryRun: true})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(steps))
+	assert.Equal(t, "LeaveVxNet", steps[0].Action)
+	assert.Equal(t, 150, len(steps[0].ResourceIDs))
+	assert.Equal(t, "i-149", steps[0].ResourceIDs[149])
+	calls := api.called("DescribeVxnetInstances")
+	assert.Equal(t, 2, len(calls))
+	assert.Equal(t, "100", calls[1].Get("offset"))
+}
+
+func TestDeleteRouterPagesVxNets(t *testing.T) {
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.handle("DescribeRouterVxnets", pagedSet("DescribeRouterVxnets", "router_vxnet_set", "vxnet_id", "vxnet-", 100))
+	s, err := NewDependencyServices(qcService, "pek3a")
+	assert.Nil(t, err)
+
+	steps, err := DeleteWithDependencies(s, nil, "rtr-1", &DeleteOptions{DryRun: true})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(steps))
+	assert.Equal(t, 100, len(steps[0].ResourceIDs))
+	// the full first page is followed by an empty one
+	assert.Equal(t, 2, len(api.called("DescribeRouterVxnets")))
+}
+
+func TestDeleteUnassociatedEIP(t *testing.T) {
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.respond("DescribeEips", `{"action":"DescribeEipsResponse","ret_code":0,"total_count":1,"eip_set":[
+		{"eip_id":"eip-1","status":"available","resource":{"resource_id":"","resource_type":""}}]}`)
+	s, err := NewDependencyServices(qcService, "pek3a")
+	assert.Nil(t, err)
+
+	steps, err := DeleteWithDependencies(s, nil, "eip-1", &DeleteOptions{DryRun: true})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ReleaseEIPs eip-1"}, stepStrings(steps))
+}
+
+func TestDeleteEIPOfRouter(t *testing.T) {
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.respond("DescribeEips", `{"action":"DescribeEipsResponse","ret_code":0,"total_count":1,"eip_set":[
+		{"eip_id":"eip-1","status":"associated","resource":{"resource_id":"rtr-1","resource_type":"router"}}]}`)
+	s, err := NewDependencyServices(qcService, "pek3a")
+	assert.Nil(t, err)
+
+	_, err = DeleteWithDependencies(s, nil, "eip-1", &DeleteOptions{DryRun: true})
+	assert.NotNil(t, err)
+}
+
+func TestDeleteInstanceSkipsEIPOfOtherResource(t *testing.T) {
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.respond("DescribeInstances", `{"action":"DescribeInstancesResponse","ret_code":0,"total_count":1,"instance_set":[
+		{"instance_id":"i-1","status":"running","eip":{"eip_id":"eip-1"}}]}`)
+	// the EIP was dissociated from the instance and associated to another one since
+	api.respond("DescribeEips", `{"action":"DescribeEipsResponse","ret_code":0,"total_count":1,"eip_set":[
+		{"eip_id":"eip-1","status":"associated","resource":{"resource_id":"i-2","resource_type":"instance"}}]}`)
+	s, err := NewDependencyServices(qcService, "pek3a")
+	assert.Nil(t, err)
+
+	steps, err := DeleteWithDependencies(s, nil, "i-1", &DeleteOptions{DryRun: true, DeleteDependencies: true})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"TerminateInstances i-1"}, stepStrings(steps))
+}
+
+func TestDeleteInstanceWithEIP(t *testing.T) {
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.respond("DescribeInstances", `{"action":"DescribeInstancesResponse","ret_code":0,"total_count":1,"instance_set":[
+		{"instance_id":"i-1","status":"running","eip":{"eip_id":"eip-1"}}]}`)
+	api.respond("DescribeEips", `{"action":"DescribeEipsResponse","ret_code":0,"total_count":1,"eip_set":[
+		{"eip_id":"eip-1","status":"associated","resource":{"resource_id":"i-1","resource_type":"instance"}}]}`)
+	s, err := NewDependencyServices(qcService, "pek3a")
+	assert.Nil(t, err)
+
+	steps, err := DeleteWithDependencies(s, nil, "i-1", &DeleteOptions{DryRun: true, DeleteDependencies: true})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"DissociateEIPs eip-1 from i-1", "TerminateInstances i-1", "ReleaseEIPs eip-1"}, stepStrings(steps))
+	assert.Equal(t, 0, len(api.called("DissociateEips")))
+}
@@ -0,0 +1,249 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+const (
+	//VxNetTypeUnmanaged unmanaged vxnet
+	VxNetTypeUnmanaged = 0
+	//VxNetTypeManaged managed vxnet
+	VxNetTypeManaged = 1
+)
+
+// VxNetSpec describe the vxnet expected by EnsureVxNet
+type VxNetSpec struct {
+	// Name identify the vxnet, it should be unique among the vxnets
+	Name        string
+	VxNetType   int
+	Description string
+	TagIDs      []string
+}
+
+// RouterSpec describe the router expected by EnsureRouter
+type RouterSpec struct {
+	// Name identify the router, it should be unique among the routers
+	Name            string
+	RouterType      int
+	VpcNetwork      string
+	SecurityGroupID string
+	Description     string
+	TagIDs          []string
+}
+
+// EnsureVxNet find the vxnet by the name of spec, create it if not exist,
+// and reconcile its description and tags, it returns the ID of the vxnet either way
+func EnsureVxNet(vxnetService *service.VxNetService, tagService *service.TagService, spec *VxNetSpec) (string, error) {
+	vxnet, err := findVxNet(vxnetService, spec.Name)
+	if err != nil {
+		return "", err
+	}
+	if vxnet == nil {
+		return createVxNet(vxnetService, tagService, spec)
+	}
+	vxnetID := *vxnet.VxNetID
+	if service.IntValue(vxnet.VxNetType) != spec.VxNetType {
+		return "", fmt.Errorf("VxNet [%s] type is [%d], but [%d] expected", vxnetID, service.IntValue(vxnet.VxNetType), spec.VxNetType)
+	}
+	if spec.Description != "" && service.StringValue(vxnet.Description) != spec.Description {
+		logger.Info("Updating description of VxNet [%s]", vxnetID)
+		_, err = vxnetService.ModifyVxNetAttributes(&service.ModifyVxNetAttributesInput{
+			VxNet:       service.String(vxnetID),
+			Description: service.String(spec.Description),
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	err = AttachTags(tagService, ResourceTypeVxNet, []string{vxnetID}, MissingTags(vxnet.Tags, spec.TagIDs))
+	if err != nil {
+		return "", err
+	}
+	return vxnetID, nil
+}
+
+// EnsureRouter find the router by the name of spec, create it and wait it active if not exist,
+// and reconcile its description, security group and tags, it returns the ID of the router either way
+func EnsureRouter(routerService *service.RouterService, jobService *service.JobService, tagService *service.TagService, spec *RouterSpec, timeout time.Duration, waitInterval time.Duration) (string, error) {
+	router, err := findRouter(routerService, spec.Name)
+	if err != nil {
+		return "", err
+	}
+	if router == nil {
+		return createRouter(routerService, tagService, spec, timeout, waitInterval)
+	}
+	routerID := *router.RouterID
+	if spec.VpcNetwork != "" && service.StringValue(router.VpcNetwork) != spec.VpcNetwork {
+		return "", fmt.Errorf("Router [%s] vpc network is [%s], but [%s] expected", routerID, service.StringValue(router.VpcNetwork), spec.VpcNetwork)
+	}
+	input := &service.ModifyRouterAttributesInput{Router: service.String(routerID)}
+	modified := false
+	if spec.Description != "" && service.StringValue(router.Description) != spec.Description {
+		input.Description = service.String(spec.Description)
+		modified = true
+	}
+	securityGroupChanged := spec.SecurityGroupID != "" && service.StringValue(router.SecurityGroupID) != spec.SecurityGroupID
+	if securityGroupChanged {
+		input.SecurityGroup = service.String(spec.SecurityGroupID)
+		modified = true
+	}
+	if modified {
+		logger.Info("Updating attributes of Router [%s]", routerID)
+		_, err = routerService.ModifyRouterAttributes(input)
+		if err != nil {
+			return "", err
+		}
+	}
+	if securityGroupChanged {
+		err = ApplyRouter(routerService, jobService, routerID, timeout, waitInterval)
+		if err != nil {
+			return "", err
+		}
+	}
+	err = AttachTags(tagService, ResourceTypeRouter, []string{routerID}, MissingTags(router.Tags, spec.TagIDs))
+	if err != nil {
+		return "", err
+	}
+	return routerID, nil
+}
+
+func createVxNet(vxnetService *service.VxNetService, tagService *service.TagService, spec *VxNetSpec) (string, error) {
+	logger.Info("Creating VxNet [%s]", spec.Name)
+	output, err := vxnetService.CreateVxNets(&service.CreateVxNetsInput{
+		VxNetName: service.String(spec.Name),
+		VxNetType: service.Int(spec.VxNetType),
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(output.VxNets) == 0 || output.VxNets[0] == nil {
+		return "", fmt.Errorf("Create VxNet [%s] response error", spec.Name)
+	}
+	vxnetID := *output.VxNets[0]
+	if spec.Description != "" {
+		_, err = vxnetService.ModifyVxNetAttributes(&service.ModifyVxNetAttributesInput{
+			VxNet:       service.String(vxnetID),
+			Description: service.String(spec.Description),
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	err = AttachTags(tagService, ResourceTypeVxNet, []string{vxnetID}, spec.TagIDs)
+	if err != nil {
+		return "", err
+	}
+	err = attachDefaultTags(vxnetService.Config, vxnetService.Properties.Zone, ResourceTypeVxNet, vxnetID)
+	if err != nil {
+		return "", err
+	}
+	return vxnetID, nil
+}
+
+func createRouter(routerService *service.RouterService, tagService *service.TagService, spec *RouterSpec, timeout time.Duration, waitInterval time.Duration) (string, error) {
+	logger.Info("Creating Router [%s]", spec.Name)
+	input := &service.CreateRoutersInput{
+		RouterName: service.String(spec.Name),
+		RouterType: service.Int(spec.RouterType),
+	}
+	if spec.VpcNetwork != "" {
+		input.VpcNetwork = service.String(spec.VpcNetwork)
+	}
+	if spec.SecurityGroupID != "" {
+		input.SecurityGroup = service.String(spec.SecurityGroupID)
+	}
+	output, err := routerService.CreateRouters(input)
+	if err != nil {
+		return "", err
+	}
+	if len(output.Routers) == 0 || output.Routers[0] == nil {
+		return "", fmt.Errorf("Create Router [%s] response error", spec.Name)
+	}
+	routerID := *output.Routers[0]
+	_, err = WaitRouterStatus(routerService, routerID, RouterStatusActive, timeout, waitInterval)
+	if err != nil {
+		return "", err
+	}
+	if spec.Description != "" {
+		_, err = routerService.ModifyRouterAttributes(&service.ModifyRouterAttributesInput{
+			Router:      service.String(routerID),
+			Description: service.String(spec.Description),
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	err = AttachTags(tagService, ResourceTypeRouter, []string{routerID}, spec.TagIDs)
+	if err != nil {
+		return "", err
+	}
+	err = attachDefaultTags(routerService.Config, routerService.Properties.Zone, ResourceTypeRouter, routerID)
+	if err != nil {
+		return "", err
+	}
+	return routerID, nil
+}
+
+// findVxNet find the vxnet by name only, so the vxnet created by a previous call which failed
+// to attach the tags is found too, the missing tags are attached by the caller
+func findVxNet(vxnetService *service.VxNetService, name string) (*service.VxNet, error) {
+	var found *service.VxNet
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := vxnetService.DescribeVxNets(&service.DescribeVxNetsInput{
+			SearchWord: service.String(name),
+			Verbose:    service.Int(1),
+			Limit:      service.Int(limit),
+			Offset:     service.Int(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, vxnet := range output.VxNetSet {
+			if service.StringValue(vxnet.VxNetName) != name || vxnet.VxNetID == nil {
+				continue
+			}
+			if found != nil {
+				return nil, fmt.Errorf("VxNet with name [%s] not unique, found [%s] and [%s]", name, *found.VxNetID, *vxnet.VxNetID)
+			}
+			found = vxnet
+		}
+		if len(output.VxNetSet) < limit {
+			return found, nil
+		}
+	}
+}
+
+// findRouter find the router by name only, like findVxNet
+func findRouter(routerService *service.RouterService, name string) (*service.Router, error) {
+	var found *service.Router
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := routerService.DescribeRouters(&service.DescribeRoutersInput{
+			SearchWord: service.String(name),
+			Status:     service.StringSlice([]string{RouterStatusPending, RouterStatusActive, RouterStatusPoweroffed}),
+			Verbose:    service.Int(1),
+			Limit:      service.Int(limit),
+			Offset:     service.Int(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, router := range output.RouterSet {
+			if service.StringValue(router.RouterName) != name || router.RouterID == nil {
+				continue
+			}
+			if found != nil {
+				return nil, fmt.Errorf("Router with name [%s] not unique, found [%s] and [%s]", name, *found.RouterID, *router.RouterID)
+			}
+			found = router
+		}
+		if len(output.RouterSet) < limit {
+			return found, nil
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnsureVxNetAfterFailedTagging(t *testing.T) {
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	// the vxnet created by the previous call, which failed to attach the tag
+	api.respond("DescribeVxnets", `{"action":"DescribeVxnetsResponse","ret_code":0,"total_count":1,"vxnet_set":[
+		{"vxnet_id":"vxnet-1","vxnet_name":"web","vxnet_type":1,"tags":[]}]}`)
+	api.respond("AttachTags", `{"action":"AttachTagsResponse","ret_code":0}`)
+	vxnetService, _ := qcService.VxNet("pek3a")
+	tagService, _ := qcService.Tag("pek3a")
+
+	vxnetID, err := EnsureVxNet(vxnetService, tagService, &VxNetSpec{Name: "web", VxNetType: VxNetTypeManaged, TagIDs: []string{"tag-1"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "vxnet-1", vxnetID)
+	assert.Equal(t, 0, len(api.called("CreateVxnets")))
+	assert.Equal(t, "", api.called("DescribeVxnets")[0].Get("tags.1"))
+	attached := api.called("AttachTags")
+	assert.Equal(t, 1, len(attached))
+	assert.Equal(t, "vxnet-1", attached[0].Get("resource_tag_pairs.1.resource_id"))
+	assert.Equal(t, "tag-1", attached[0].Get("resource_tag_pairs.1.tag_id"))
+}
+
+func TestEnsureRouterAfterFailedTagging(t *testing.T) {
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.respond("DescribeRouters", `{"action":"DescribeRoutersResponse","ret_code":0,"total_count":1,"router_set":[
+		{"router_id":"rtr-1","router_name":"gateway","status":"active","tags":[{"tag_id":"tag-1"}]}]}`)
+	api.respond("AttachTags", `{"action":"AttachTagsResponse","ret_code":0}`)
+	routerService, _ := qcService.Router("pek3a")
+	jobService, _ := qcService.Job("pek3a")
+	tagService, _ := qcService.Tag("pek3a")
+
+	routerID, err := EnsureRouter(routerService, jobService, tagService, &RouterSpec{Name: "gateway", TagIDs: []string{"tag-1", "tag-2"}}, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "rtr-1", routerID)
+	assert.Equal(t, 0, len(api.called("CreateRouters")))
+	assert.Equal(t, "", api.called("DescribeRouters")[0].Get("tags.1"))
+	attached := api.called("AttachTags")
+	assert.Equal(t, 1, len(attached))
+	assert.Equal(t, "tag-2", attached[0].Get("resource_tag_pairs.1.tag_id"))
+	assert.Equal(t, "", attached[0].Get("resource_tag_pairs.2.tag_id"))
+}
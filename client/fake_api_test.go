@@ -0,0 +1,77 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+// fakeAPI serves the responses of the actions and records the calls, the responses of
+// the actions without handler are successful with no content
+type fakeAPI struct {
+	mutex     sync.Mutex
+	server    *httptest.Server
+	responses map[string]func(params url.Values) string
+	calls     []url.Values
+}
+
+func newFakeAPI(t *testing.T) (*fakeAPI, *service.QingCloudService) {
+	api := &fakeAPI{responses: map[string]func(params url.Values) string{}}
+	api.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		action := r.Form.Get("action")
+		api.mutex.Lock()
+		api.calls = append(api.calls, r.Form)
+		handler := api.responses[action]
+		api.mutex.Unlock()
+		response := `{"ret_code":0}`
+		if handler != nil {
+			response = handler(r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	conf, err := config.NewWithEndpoint("AccessKeyID", "SecretAccessKey", api.server.URL+"/iaas")
+	assert.Nil(t, err)
+	conf.Zone = "pek3a"
+	qcService, err := service.Init(conf)
+	assert.Nil(t, err)
+	return api, qcService
+}
+
+func (a *fakeAPI) Close() {
+	a.server.Close()
+}
+
+// respond serve the response to the calls to the action
+func (a *fakeAPI) respond(action string, response string) {
+	a.handle(action, func(params url.Values) string {
+		return response
+	})
+}
+
+// handle serve the response of the handler to the calls to the action
+func (a *fakeAPI) handle(action string, handler func(params url.Values) string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.responses[action] = handler
+}
+
+// called return the params of the calls to the action
+func (a *fakeAPI) called(action string) []url.Values {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	calls := []url.Values{}
+	for _, params := range a.calls {
+		if params.Get("action") == action {
+			calls = append(calls, params)
+		}
+	}
+	return calls
+}
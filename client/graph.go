@@ -0,0 +1,289 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+// ResourceNode a resource in the dependency graph
+type ResourceNode struct {
+	ID     string
+	Type   string
+	Name   string
+	Status string
+}
+
+// DependencyEdge the resource From depends on the resource To
+type DependencyEdge struct {
+	From string
+	To   string
+}
+
+// DependencyGraph the resources reachable from the Root resource and their dependencies
+type DependencyGraph struct {
+	Root  string
+	Nodes map[string]*ResourceNode
+	Edges []*DependencyEdge
+}
+
+// Dependencies returns the resources the resource with this ID depends on directly
+func (g *DependencyGraph) Dependencies(id string) []*ResourceNode {
+	nodes := []*ResourceNode{}
+	for _, edge := range g.Edges {
+		if edge.From == id {
+			nodes = append(nodes, g.Nodes[edge.To])
+		}
+	}
+	return nodes
+}
+
+// Dependents returns the resources which depend on the resource with this ID directly
+func (g *DependencyGraph) Dependents(id string) []*ResourceNode {
+	nodes := []*ResourceNode{}
+	for _, edge := range g.Edges {
+		if edge.To == id {
+			nodes = append(nodes, g.Nodes[edge.From])
+		}
+	}
+	return nodes
+}
+
+func (g *DependencyGraph) addNode(node *ResourceNode) bool {
+	if _, ok := g.Nodes[node.ID]; ok {
+		return false
+	}
+	g.Nodes[node.ID] = node
+	return true
+}
+
+func (g *DependencyGraph) addEdge(from, to string) {
+	for _, edge := range g.Edges {
+		if edge.From == from && edge.To == to {
+			return
+		}
+	}
+	g.Edges = append(g.Edges, &DependencyEdge{From: from, To: to})
+}
+
+// DependencyServices the services used to walk the relationships of resources
+type DependencyServices struct {
+	InstanceService      *service.InstanceService
+	VolumeService        *service.VolumeService
+	EIPService           *service.EIPService
+	NICService           *service.NicService
+	VxNetService         *service.VxNetService
+	RouterService        *service.RouterService
+	SecurityGroupService *service.SecurityGroupService
+}
+
+// NewDependencyServices return the DependencyServices of the zone
+func NewDependencyServices(qcService *service.QingCloudService, zone string) (*DependencyServices, error) {
+	s := &DependencyServices{}
+	var err error
+	if s.InstanceService, err = qcService.Instance(zone); err != nil {
+		return nil, err
+	}
+	if s.VolumeService, err = qcService.Volume(zone); err != nil {
+		return nil, err
+	}
+	if s.EIPService, err = qcService.EIP(zone); err != nil {
+		return nil, err
+	}
+	if s.NICService, err = qcService.Nic(zone); err != nil {
+		return nil, err
+	}
+	if s.VxNetService, err = qcService.VxNet(zone); err != nil {
+		return nil, err
+	}
+	if s.RouterService, err = qcService.Router(zone); err != nil {
+		return nil, err
+	}
+	if s.SecurityGroupService, err = qcService.SecurityGroup(zone); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ResourceTypeOf returns the resource type of the resourceID by its prefix, it returns "" if unknown
+func ResourceTypeOf(resourceID string) string {
+	switch {
+	case strings.HasPrefix(resourceID, "i-"):
+		return ResourceTypeInstance
+	case strings.HasPrefix(resourceID, "vol-"):
+		return ResourceTypeVolume
+	case strings.HasPrefix(resourceID, "eip-"):
+		return ResourceTypeEIP
+	case strings.HasPrefix(resourceID, "vxnet-"):
+		return ResourceTypeVxNet
+	case strings.HasPrefix(resourceID, "rtr-"):
+		return ResourceTypeRouter
+	case strings.HasPrefix(resourceID, "sg-"):
+		return ResourceTypeSecurityGroup
+	case strings.HasPrefix(resourceID, "lb-"):
+		return ResourceTypeLoadBalancer
+	case strings.HasPrefix(resourceID, "kp-"):
+		return ResourceTypeKeyPair
+	case strings.Count(resourceID, ":") == 5:
+		return ResourceTypeNIC
+	}
+	return ""
+}
+
+// BuildDependencyGraph walks the relationships of the resource with this resourceID,
+// instance -> volumes, NICs, EIP, security group, keypairs; NIC -> vxnet; vxnet -> router;
+// router -> EIP, security group, and returns the dependency graph.
+func BuildDependencyGraph(s *DependencyServices, resourceID string) (*DependencyGraph, error) {
+	g := &DependencyGraph{
+		Root:  resourceID,
+		Nodes: map[string]*ResourceNode{},
+	}
+	resourceType := ResourceTypeOf(resourceID)
+	if resourceType == "" {
+		return nil, fmt.Errorf("Unknown resource type of [%s]", resourceID)
+	}
+	pending := []*ResourceNode{{ID: resourceID, Type: resourceType}}
+	for len(pending) > 0 {
+		node := pending[0]
+		pending = pending[1:]
+		dependencies, err := s.describeDependencies(node)
+		if err != nil {
+			return nil, err
+		}
+		g.Nodes[node.ID] = node
+		for _, dependency := range dependencies {
+			if g.addNode(dependency) {
+				pending = append(pending, dependency)
+			}
+			g.addEdge(node.ID, dependency.ID)
+		}
+	}
+	return g, nil
+}
+
+// describeDependencies fills the node and returns its direct dependencies
+func (s *DependencyServices) describeDependencies(node *ResourceNode) ([]*ResourceNode, error) {
+	dependencies := []*ResourceNode{}
+	add := func(id *string, resourceType string) {
+		if service.StringValue(id) != "" {
+			dependencies = append(dependencies, &ResourceNode{ID: *id, Type: resourceType})
+		}
+	}
+
+	switch node.Type {
+	case ResourceTypeInstance:
+		output, err := s.InstanceService.DescribeInstances(&service.DescribeInstancesInput{
+			Instances: []*string{service.String(node.ID)},
+			Verbose:   service.Int(1),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(output.InstanceSet) == 0 {
+			return nil, fmt.Errorf("Instance with id [%s] not exist", node.ID)
+		}
+		instance := output.InstanceSet[0]
+		node.Name, node.Status = service.StringValue(instance.InstanceName), service.StringValue(instance.Status)
+		for _, volumeID := range instance.VolumeIDs {
+			add(volumeID, ResourceTypeVolume)
+		}
+		for _, vxnet := range instance.VxNets {
+			if service.StringValue(vxnet.NICID) != "" {
+				add(vxnet.NICID, ResourceTypeNIC)
+			} else {
+				add(vxnet.VxNetID, ResourceTypeVxNet)
+			}
+		}
+		if instance.EIP != nil {
+			add(instance.EIP.EIPID, ResourceTypeEIP)
+		}
+		if instance.SecurityGroup != nil {
+			add(instance.SecurityGroup.SecurityGroupID, ResourceTypeSecurityGroup)
+		}
+		for _, keyPairID := range instance.KeyPairIDs {
+			add(keyPairID, ResourceTypeKeyPair)
+		}
+	case ResourceTypeNIC:
+		output, err := s.NICService.DescribeNics(&service.DescribeNicsInput{
+			Nics: []*string{service.String(node.ID)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(output.NICSet) == 0 {
+			return nil, fmt.Errorf("NIC with id [%s] not exist", node.ID)
+		}
+		nic := output.NICSet[0]
+		node.Name, node.Status = service.StringValue(nic.NICName), service.StringValue(nic.Status)
+		add(nic.VxNetID, ResourceTypeVxNet)
+	case ResourceTypeVxNet:
+		output, err := s.VxNetService.DescribeVxNets(&service.DescribeVxNetsInput{
+			VxNets:  []*string{service.String(node.ID)},
+			Verbose: service.Int(1),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(output.VxNetSet) == 0 {
+			return nil, fmt.Errorf("VxNet with id [%s] not exist", node.ID)
+		}
+		vxnet := output.VxNetSet[0]
+		node.Name = service.StringValue(vxnet.VxNetName)
+		if vxnet.Router != nil && service.StringValue(vxnet.Router.RouterID) != "" {
+			add(vxnet.Router.RouterID, ResourceTypeRouter)
+		} else {
+			add(vxnet.VpcRouterID, ResourceTypeRouter)
+		}
+	case ResourceTypeRouter:
+		output, err := s.RouterService.DescribeRouters(&service.DescribeRoutersInput{
+			Routers: []*string{service.String(node.ID)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(output.RouterSet) == 0 {
+			return nil, fmt.Errorf("Router with id [%s] not exist", node.ID)
+		}
+		router := output.RouterSet[0]
+		node.Name, node.Status = service.StringValue(router.RouterName), service.StringValue(router.Status)
+		if router.EIP != nil {
+			add(router.EIP.EIPID, ResourceTypeEIP)
+		}
+		add(router.SecurityGroupID, ResourceTypeSecurityGroup)
+	case ResourceTypeVolume:
+		output, err := s.VolumeService.DescribeVolumes(&service.DescribeVolumesInput{
+			Volumes: []*string{service.String(node.ID)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(output.VolumeSet) == 0 {
+			return nil, fmt.Errorf("Volume with id [%s] not exist", node.ID)
+		}
+		node.Name, node.Status = service.StringValue(output.VolumeSet[0].VolumeName), service.StringValue(output.VolumeSet[0].Status)
+	case ResourceTypeEIP:
+		output, err := s.EIPService.DescribeEIPs(&service.DescribeEIPsInput{
+			EIPs: []*string{service.String(node.ID)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(output.EIPSet) == 0 {
+			return nil, fmt.Errorf("EIP with id [%s] not exist", node.ID)
+		}
+		node.Name, node.Status = service.StringValue(output.EIPSet[0].EIPName), service.StringValue(output.EIPSet[0].Status)
+	case ResourceTypeSecurityGroup:
+		output, err := s.SecurityGroupService.DescribeSecurityGroups(&service.DescribeSecurityGroupsInput{
+			SecurityGroups: []*string{service.String(node.ID)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(output.SecurityGroupSet) == 0 {
+			return nil, fmt.Errorf("SecurityGroup with id [%s] not exist", node.ID)
+		}
+		node.Name = service.StringValue(output.SecurityGroupSet[0].SecurityGroupName)
+	}
+	return dependencies, nil
+}
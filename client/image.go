@@ -0,0 +1,142 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+const (
+	//ImageStatusAvailable available
+	ImageStatusAvailable = "available"
+	//ImageStatusDeprecated deprecated
+	ImageStatusDeprecated = "deprecated"
+)
+
+// DeprecateImage mark the image deprecated, or available again if not deprecated
+func DeprecateImage(imageService *service.ImageService, imageID string, deprecated bool) error {
+	flag := 0
+	if deprecated {
+		flag = 1
+	}
+	_, err := imageService.ModifyImageAttributes(&service.ModifyImageAttributesInput{
+		Image:      service.String(imageID),
+		Deprecated: service.Int(flag),
+	})
+	return err
+}
+
+// PruneImagesOptions select the images to prune
+type PruneImagesOptions struct {
+	// Pattern matches the names of the images, the images are grouped by the
+	// first submatch if it has one, otherwise all matched images are in one group
+	Pattern *regexp.Regexp
+	// Keep is the number of the newest images to keep in each group
+	Keep int
+	// DryRun only returns the images to prune without deleting them
+	DryRun bool
+}
+
+// PruneImages delete the images of the user older than the newest opts.Keep ones of each name group,
+// the images still used by instances which are not terminated are skipped.
+// It returns the pruned images
+func PruneImages(imageService *service.ImageService, instanceService *service.InstanceService, opts *PruneImagesOptions) ([]*service.Image, error) {
+	if opts == nil || opts.Pattern == nil {
+		return nil, fmt.Errorf("Prune images requires the name pattern")
+	}
+	if opts.Keep < 0 {
+		return nil, fmt.Errorf("Prune images keep should not be negative, got %d", opts.Keep)
+	}
+	images, err := describeSelfImages(imageService)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[string][]*service.Image{}
+	for _, image := range images {
+		match := opts.Pattern.FindStringSubmatch(service.StringValue(image.ImageName))
+		if match == nil {
+			continue
+		}
+		key := ""
+		if len(match) > 1 {
+			key = match[1]
+		}
+		groups[key] = append(groups[key], image)
+	}
+
+	pruned := []*service.Image{}
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			return service.TimeValue(group[i].CreateTime).After(service.TimeValue(group[j].CreateTime))
+		})
+		if len(group) <= opts.Keep {
+			continue
+		}
+		for _, image := range group[opts.Keep:] {
+			inUse, err := isImageInUse(instanceService, *image.ImageID)
+			if err != nil {
+				return pruned, err
+			}
+			if inUse {
+				logger.Debug("Image [%s] is in use, skip pruning it", *image.ImageID)
+				continue
+			}
+			pruned = append(pruned, image)
+		}
+	}
+	if opts.DryRun || len(pruned) == 0 {
+		return pruned, nil
+	}
+
+	imageIDs := make([]*string, 0, len(pruned))
+	for _, image := range pruned {
+		imageIDs = append(imageIDs, image.ImageID)
+	}
+	_, err = imageService.DeleteImages(&service.DeleteImagesInput{Images: imageIDs})
+	if err != nil {
+		return nil, err
+	}
+	return pruned, nil
+}
+
+func describeSelfImages(imageService *service.ImageService) ([]*service.Image, error) {
+	images := []*service.Image{}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := imageService.DescribeImages(&service.DescribeImagesInput{
+			Provider: service.String("self"),
+			Status:   service.StringSlice([]string{ImageStatusAvailable, ImageStatusDeprecated}),
+			Limit:    service.Int(limit),
+			Offset:   service.Int(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, image := range output.ImageSet {
+			if image.ImageID != nil {
+				images = append(images, image)
+			}
+		}
+		if len(output.ImageSet) < limit {
+			return images, nil
+		}
+	}
+}
+
+func isImageInUse(instanceService *service.InstanceService, imageID string) (bool, error) {
+	output, err := instanceService.DescribeInstances(&service.DescribeInstancesInput{
+		ImageID: []*string{service.String(imageID)},
+		Status: service.StringSlice([]string{
+			InstanceStatusPending, InstanceStatusRunning, InstanceStatusStopped, InstanceStatusSuspended,
+		}),
+		Limit: service.Int(1),
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(output.InstanceSet) > 0 || service.IntValue(output.TotalCount) > 0, nil
+}
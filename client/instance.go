@@ -0,0 +1,258 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	qcErrors "github.com/yunify/qingcloud-sdk-go/request/errors"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+// OnlineResizeUnsupportedRetCodes are the ret codes of the online resize requests rejected since
+// the instance can not hot-add the vCPU or memory, which are retried by resizing the instance stopped.
+// It is empty by default since the API has no dedicated ret code for it, and a general one like
+// PermissionDenied must not stop a running instance, set it to the ret codes of the platform if any.
+var OnlineResizeUnsupportedRetCodes = []int{}
+
+func isOnlineResizeUnsupported(err error) bool {
+	e, ok := qcErrors.AsQingCloudError(err)
+	if !ok {
+		return false
+	}
+	for _, code := range OnlineResizeUnsupportedRetCodes {
+		if code == e.RetCode {
+			return true
+		}
+	}
+	return false
+}
+
+// InstanceHostSpec is the hostname of an instance run by RunInstancesWithHostSpecs and its DNS overrides
+type InstanceHostSpec struct {
+	Hostname string
+	// DNS overrides the resolver of the instance, nil keeps the DNS of the input
+	DNS *service.InstanceDNS
+}
+
+// RunInstancesWithHostnames run one instance for each hostname from the input, since RunInstances
+// set the same hostname to all instances it creates, and wait them running.
+// The hostnames can be generated by service.SequentialHostnames
+func RunInstancesWithHostnames(instanceService *service.InstanceService, jobService *service.JobService, input *service.RunInstancesInput, hostnames []string, timeout time.Duration, waitInterval time.Duration) ([]*service.Instance, error) {
+	hosts := make([]*InstanceHostSpec, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		hosts = append(hosts, &InstanceHostSpec{Hostname: hostname})
+	}
+	return RunInstancesWithHostSpecs(instanceService, jobService, input, hosts, timeout, waitInterval)
+}
+
+// RunInstancesWithHostSpecs run one instance for each host from the input with its hostname and DNS overrides,
+// and wait them running. The hosts are all validated before any instance is run
+func RunInstancesWithHostSpecs(instanceService *service.InstanceService, jobService *service.JobService, input *service.RunInstancesInput, hosts []*InstanceHostSpec, timeout time.Duration, waitInterval time.Duration) ([]*service.Instance, error) {
+	platform := service.StringValue(input.Platform)
+	for _, host := range hosts {
+		if err := service.ValidateHostname(host.Hostname, platform); err != nil {
+			return nil, err
+		}
+		if host.DNS != nil {
+			if err := host.DNS.Validate(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	instances := []*service.Instance{}
+	for _, host := range hosts {
+		hostname := host.Hostname
+		instanceInput := *input
+		instanceInput.Count = service.Int(1)
+		instanceInput.Hostname = service.String(hostname)
+		if instanceInput.InstanceName == nil {
+			instanceInput.InstanceName = service.String(hostname)
+		}
+		if host.DNS != nil {
+			if err := host.DNS.ApplyTo(&instanceInput); err != nil {
+				return instances, err
+			}
+		}
+		output, err := instanceService.RunInstances(&instanceInput)
+		if err != nil {
+			return instances, err
+		}
+		if len(output.Instances) == 0 || output.Instances[0] == nil {
+			return instances, fmt.Errorf("Run instance with hostname [%s] response error", hostname)
+		}
+		err = attachDefaultTags(instanceService.Config, instanceService.Properties.Zone, ResourceTypeInstance, *output.Instances[0])
+		if err != nil {
+			return instances, err
+		}
+		err = waitOutputJob(jobService, output.JobID, timeout, waitInterval)
+		if err != nil {
+			return instances, err
+		}
+		instance, err := WaitInstanceStatus(instanceService, *output.Instances[0], InstanceStatusRunning, timeout, waitInterval)
+		if err != nil {
+			return instances, err
+		}
+		instances = append(instances, instance)
+	}
+	return instances, nil
+}
+
+// ResizeInstanceAndWait resize the instance to cpu cores and memory (MB) and wait it finished,
+// a running instance is resized online if it can hot-add the vCPU and memory, see service.Instance.CanResizeOnline,
+// otherwise or if the online resize is rejected with one of OnlineResizeUnsupportedRetCodes, it is stopped
+// before resizing and started again afterwards, also if the resize fails after it is stopped
+func ResizeInstanceAndWait(instanceService *service.InstanceService, jobService *service.JobService, instanceID string, cpu int, memory int, timeout time.Duration, waitInterval time.Duration) (err error) {
+	instance, err := describeInstance(instanceService, instanceID)
+	if err != nil {
+		return err
+	}
+	if instance.CanResizeOnline(cpu, memory) {
+		err = ResizeInstanceOnline(instanceService, jobService, instanceID, cpu, memory, timeout, waitInterval)
+		if err == nil || !isOnlineResizeUnsupported(err) {
+			return err
+		}
+		logger.Warn("Resize Instance [%s] online error : [%s], resizing it stopped", instanceID, err.Error())
+		instance, err = describeInstance(instanceService, instanceID)
+		if err != nil {
+			return err
+		}
+	}
+	running := service.StringValue(instance.Status) == InstanceStatusRunning
+	// the stopped instance is started again if the resize fails
+	restart := false
+	if running {
+		var stopOutput *service.StopInstancesOutput
+		err = requeueInstanceOnConflict(instanceService, instanceID, waitInterval, nil, func() (err error) {
+			stopOutput, err = instanceService.StopInstances(&service.StopInstancesInput{
+				Instances: []*string{service.String(instanceID)},
+			})
+			return
+		})
+		if err != nil {
+			return err
+		}
+		restart = true
+		defer func() {
+			if err == nil || !restart {
+				return
+			}
+			logger.Warn("Resize Instance [%s] error : [%s], starting it again", instanceID, err.Error())
+			if startErr := startInstanceAndWait(instanceService, jobService, instanceID, timeout, waitInterval); startErr != nil {
+				err = fmt.Errorf("%s, and start instance [%s] again error : %s", err.Error(), instanceID, startErr.Error())
+			}
+		}()
+		if err = waitOutputJob(jobService, stopOutput.JobID, timeout, waitInterval); err != nil {
+			return err
+		}
+		if _, err = WaitInstanceStatus(instanceService, instanceID, InstanceStatusStopped, timeout, waitInterval); err != nil {
+			return err
+		}
+	}
+	var resizeOutput *service.ResizeInstancesOutput
+	err = requeueInstanceOnConflict(instanceService, instanceID, waitInterval, nil, func() (err error) {
+		resizeOutput, err = instanceService.ResizeInstances(&service.ResizeInstancesInput{
+			Instances: []*string{service.String(instanceID)},
+			CPU:       service.Int(cpu),
+			Memory:    service.Int(memory),
+		})
+		return
+	})
+	if err != nil {
+		return err
+	}
+	if err = waitOutputJob(jobService, resizeOutput.JobID, timeout, waitInterval); err != nil {
+		return err
+	}
+	if !running {
+		return nil
+	}
+	restart = false
+	return startInstanceAndWait(instanceService, jobService, instanceID, timeout, waitInterval)
+}
+
+func startInstanceAndWait(instanceService *service.InstanceService, jobService *service.JobService, instanceID string, timeout time.Duration, waitInterval time.Duration) error {
+	var output *service.StartInstancesOutput
+	err := requeueInstanceOnConflict(instanceService, instanceID, waitInterval, nil, func() (err error) {
+		output, err = instanceService.StartInstances(&service.StartInstancesInput{
+			Instances: []*string{service.String(instanceID)},
+		})
+		return
+	})
+	if err != nil {
+		return err
+	}
+	if err = waitOutputJob(jobService, output.JobID, timeout, waitInterval); err != nil {
+		return err
+	}
+	_, err = WaitInstanceStatus(instanceService, instanceID, InstanceStatusRunning, timeout, waitInterval)
+	return err
+}
+
+// ResizeInstanceOnline hot-add the vCPU and memory (MB) of the running instance and wait it finished,
+// the instance should be created with VCPUsMax and MemoryMax enough, see service.Instance.CanResizeOnline
+func ResizeInstanceOnline(instanceService *service.InstanceService, jobService *service.JobService, instanceID string, cpu int, memory int, timeout time.Duration, waitInterval time.Duration) error {
+	var output *service.ResizeInstancesOutput
+	err := requeueInstanceOnConflict(instanceService, instanceID, waitInterval, nil, func() (err error) {
+		output, err = instanceService.ResizeInstances(service.OnlineResizeInstanceInput(instanceID, cpu, memory))
+		return
+	})
+	if err != nil {
+		return err
+	}
+	if err = waitOutputJob(jobService, output.JobID, timeout, waitInterval); err != nil {
+		return err
+	}
+	_, err = WaitInstanceStatus(instanceService, instanceID, InstanceStatusRunning, timeout, waitInterval)
+	return err
+}
+
+// AutoResizeInstance evaluate the utilization of the instance in the last window against the policy,
+// the proposed size is applied by ResizeInstanceAndWait only if apply, so the callers can review
+// the recommendation before resizing
+func AutoResizeInstance(monitorService *service.MonitorService, instanceService *service.InstanceService, jobService *service.JobService, instanceID string, window time.Duration, step string, policy *service.ResizePolicy, apply bool, timeout time.Duration, waitInterval time.Duration) (*service.ResizeRecommendation, error) {
+	instance, err := describeInstance(instanceService, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	end := utils.DefaultClock.Now()
+	usage, err := monitorService.GetInstanceUsage(instanceID, end.Add(-window), end, step)
+	if err != nil {
+		return nil, err
+	}
+	recommendation, err := service.EvaluateInstanceResize(instance, usage, policy)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("Instance [%s] resize recommendation: %s, %s", instanceID, recommendation.Action, recommendation.Reason)
+	if !apply || recommendation.Action == service.ResizeActionNone {
+		return recommendation, nil
+	}
+	err = ResizeInstanceAndWait(instanceService, jobService, instanceID, recommendation.Proposed.CPU, recommendation.Proposed.Memory, timeout, waitInterval)
+	return recommendation, err
+}
+
+// SetRunInstancesUserData set the user data of the instances to run from reader, the plain user data
+// is inlined into the input, and the exec or tar user data is uploaded as an attachment instead
+func SetRunInstancesUserData(userDataService *service.UserDataService, input *service.RunInstancesInput, userdataType string, reader io.Reader) error {
+	var value string
+	var err error
+	switch userdataType {
+	case service.UserDataTypePlain:
+		value, err = service.EncodeUserData(reader, service.MaxUserDataAttachmentSize)
+	case service.UserDataTypeExec, service.UserDataTypeTar:
+		value, err = userDataService.UploadUserDataAttachmentFrom("", reader)
+	default:
+		return fmt.Errorf("User data type [%s] not supported", userdataType)
+	}
+	if err != nil {
+		return err
+	}
+	input.NeedUserdata = service.Int(1)
+	input.UserdataType = service.String(userdataType)
+	input.UserdataValue = service.String(value)
+	return nil
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+// newResizeAPI serve the running instance i-1 which can be resized online up to 4 cores and 8G memory,
+// the online resize responds onlineResponse
+func newResizeAPI(t *testing.T, onlineResponse string) (*fakeAPI, *service.InstanceService, *service.JobService) {
+	api, qcService := newFakeAPI(t)
+	status := InstanceStatusRunning
+	api.handle("DescribeInstances", func(params url.Values) string {
+		return fmt.Sprintf(`{"action":"DescribeInstancesResponse","ret_code":0,"total_count":1,"instance_set":[
+			{"instance_id":"i-1","status":"%s","vcpus_current":1,"vcpus_max":4,"memory_current":1024,"memory_max":8192}]}`, status)
+	})
+	api.handle("ResizeInstances", func(params url.Values) string {
+		if params.Get("vcpus_current") != "" {
+			return onlineResponse
+		}
+		return `{"action":"ResizeInstancesResponse","ret_code":0,"job_id":"j-1"}`
+	})
+	api.handle("StopInstances", func(params url.Values) string {
+		status = InstanceStatusStopped
+		return `{"action":"StopInstancesResponse","ret_code":0,"job_id":"j-1"}`
+	})
+	api.handle("StartInstances", func(params url.Values) string {
+		status = InstanceStatusRunning
+		return `{"action":"StartInstancesResponse","ret_code":0,"job_id":"j-1"}`
+	})
+	api.respond("DescribeJobs", `{"action":"DescribeJobsResponse","ret_code":0,"total_count":1,"job_set":[{"job_id":"j-1","status":"successful"}]}`)
+	instanceService, _ := qcService.Instance("pek3a")
+	jobService, _ := qcService.Job("pek3a")
+	return api, instanceService, jobService
+}
+
+func TestResizeInstanceAndWaitUnsupportedOnline(t *testing.T) {
+	defer utils.SetDefaultClock(utils.NewFakeClock(time.Now()))()
+	defer func(codes []int) { OnlineResizeUnsupportedRetCodes = codes }(OnlineResizeUnsupportedRetCodes)
+	OnlineResizeUnsupportedRetCodes = []int{5100}
+	api, instanceService, jobService := newResizeAPI(t, `{"action":"ResizeInstancesResponse","ret_code":5100,"message":"OnlineResizeUnsupported"}`)
+	defer api.Close()
+
+	err := ResizeInstanceAndWait(instanceService, jobService, "i-1", 2, 2048, time.Minute, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(api.called("ResizeInstances")))
+	assert.Equal(t, 1, len(api.called("StopInstances")))
+	assert.Equal(t, 1, len(api.called("StartInstances")))
+}
+
+func TestResizeInstanceAndWaitOnlineError(t *testing.T) {
+	defer utils.SetDefaultClock(utils.NewFakeClock(time.Now()))()
+	api, instanceService, jobService := newResizeAPI(t, `{"action":"ResizeInstancesResponse","ret_code":2500,"message":"QuotaExceeded"}`)
+	defer api.Close()
+
+	err := ResizeInstanceAndWait(instanceService, jobService, "i-1", 2, 2048, time.Minute, time.Second)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, len(api.called("ResizeInstances")))
+	assert.Equal(t, 0, len(api.called("StopInstances")))
+}
+
+func TestResizeInstanceAndWaitPermissionDenied(t *testing.T) {
+	defer utils.SetDefaultClock(utils.NewFakeClock(time.Now()))()
+	api, instanceService, jobService := newResizeAPI(t, `{"action":"ResizeInstancesResponse","ret_code":1400,"message":"PermissionDenied"}`)
+	defer api.Close()
+
+	err := ResizeInstanceAndWait(instanceService, jobService, "i-1", 2, 2048, time.Minute, time.Second)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, len(api.called("ResizeInstances")))
+	assert.Equal(t, 0, len(api.called("StopInstances")))
+}
+
+func TestResizeInstanceAndWaitRestartsOnError(t *testing.T) {
+	defer utils.SetDefaultClock(utils.NewFakeClock(time.Now()))()
+	api, instanceService, jobService := newResizeAPI(t, "")
+	defer api.Close()
+	api.respond("ResizeInstances", `{"action":"ResizeInstancesResponse","ret_code":2500,"message":"QuotaExceeded"}`)
+
+	// 16 cores can not be hot-added, so the instance is stopped before resizing
+	err := ResizeInstanceAndWait(instanceService, jobService, "i-1", 16, 2048, time.Minute, time.Second)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, len(api.called("StopInstances")))
+	assert.Equal(t, 1, len(api.called("ResizeInstances")))
+	assert.Equal(t, 1, len(api.called("StartInstances")))
+}
+
+func TestRunInstancesWithHostSpecs(t *testing.T) {
+	defer utils.SetDefaultClock(utils.NewFakeClock(time.Now()))()
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.handle("RunInstances", func(params url.Values) string {
+		return fmt.Sprintf(`{"action":"RunInstancesResponse","ret_code":0,"instances":["i-%s"],"job_id":"j-1"}`, params.Get("hostname"))
+	})
+	api.respond("DescribeJobs", `{"action":"DescribeJobsResponse","ret_code":0,"total_count":1,"job_set":[{"job_id":"j-1","status":"successful"}]}`)
+	api.handle("DescribeInstances", func(params url.Values) string {
+		return fmt.Sprintf(`{"action":"DescribeInstancesResponse","ret_code":0,"total_count":1,"instance_set":[
+			{"instance_id":"%s","status":"running"}]}`, params.Get("instances.1"))
+	})
+	instanceService, _ := qcService.Instance("pek3a")
+	jobService, _ := qcService.Job("pek3a")
+
+	input := &service.RunInstancesInput{ImageID: service.String("bionic1x64"), LoginMode: service.String("keypair"), DNSServers: service.StringSlice([]string{"10.0.0.1"})}
+	_, err := RunInstancesWithHostSpecs(instanceService, jobService, input, []*InstanceHostSpec{
+		{Hostname: "web-1"},
+		{Hostname: "web-2", DNS: &service.InstanceDNS{Servers: []string{"10.0.0.2"}, SearchDomains: []string{"pek3a.internal"}}},
+	}, time.Minute, time.Second)
+	assert.Nil(t, err)
+	calls := api.called("RunInstances")
+	assert.Equal(t, 2, len(calls))
+	assert.Equal(t, "10.0.0.1", calls[0].Get("dns_servers.1"))
+	assert.Equal(t, "", calls[0].Get("dns_search_domains.1"))
+	assert.Equal(t, "web-2", calls[1].Get("hostname"))
+	assert.Equal(t, "10.0.0.2", calls[1].Get("dns_servers.1"))
+	assert.Equal(t, "pek3a.internal", calls[1].Get("dns_search_domains.1"))
+	// the input is not changed by the overrides
+	assert.Equal(t, []string{"10.0.0.1"}, service.StringValueSlice(input.DNSServers))
+
+	_, err = RunInstancesWithHostSpecs(instanceService, jobService, input, []*InstanceHostSpec{
+		{Hostname: "web-3", DNS: &service.InstanceDNS{Servers: []string{"dns.example.com"}}},
+	}, time.Minute, time.Second)
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, len(api.called("RunInstances")))
+}
@@ -0,0 +1,216 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+const (
+	//BackendSyncNamePrefix the name prefix of the backends added by SyncListenerBackends, only these backends are removed by it
+	BackendSyncNamePrefix = "synced-"
+)
+
+// BackendSelector select the instances to be the backends of a listener
+type BackendSelector struct {
+	// TagIDs the instances must have all these tags
+	TagIDs []string
+	// Port the port of the backends
+	Port int
+	// Weight the weight of the added backends, 0 uses the default weight
+	Weight int
+	// AllowEmpty allow removing the last backends of the listener when no instance matches,
+	// otherwise the sync is refused to keep the listener serving, e.g. after a wrong tag
+	AllowEmpty bool
+}
+
+// BackendSyncResult the changes made by SyncListenerBackends
+type BackendSyncResult struct {
+	// AddedInstanceIDs the instances added as backends
+	AddedInstanceIDs []string
+	// RemovedBackendIDs the backends removed
+	RemovedBackendIDs []string
+	// Applied whether the loadBalancer was applied with the changes, the changes made before
+	// a failure are applied too, so they are in effect if it is set even if the sync failed
+	Applied bool
+}
+
+// Changed return whether the backends of the listener were changed
+func (r *BackendSyncResult) Changed() bool {
+	return len(r.AddedInstanceIDs) > 0 || len(r.RemovedBackendIDs) > 0
+}
+
+// SyncListenerBackends keep the backends of the listener in sync with the instances matching the selector,
+// the instances matching but not backends yet are added, and the backends added by it which no longer match,
+// e.g. terminated or untagged ones, are removed. Backends added by hand, of policies, on other ports,
+// or of other resources than instances are left as they are. Removing all the backends of the listener
+// is refused unless AllowEmpty of the selector is set.
+// The loadBalancer is applied only if the backends changed, so it is cheap to call periodically
+func SyncListenerBackends(lbService *service.LoadBalancerService, instanceService *service.InstanceService, jobService *service.JobService, loadBalancerID string, listenerID string, selector *BackendSelector, timeout time.Duration, waitInterval time.Duration) (*BackendSyncResult, error) {
+	if len(selector.TagIDs) == 0 {
+		return nil, fmt.Errorf("TagIDs of the backend selector are required to sync backends")
+	}
+	if selector.Port <= 0 {
+		return nil, fmt.Errorf("Port of the backend selector is required to sync backends")
+	}
+	instances, err := describeInstancesWithTags(instanceService, selector.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+	backends, err := describeListenerBackends(lbService, listenerID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BackendSyncResult{}
+	added, removed := diffBackends(backends, instances, selector.Port)
+	if len(removed) > 0 && len(backends)-len(removed)+len(added) == 0 && !selector.AllowEmpty {
+		return result, fmt.Errorf("Sync of listener [%s] would remove all its backends %v, set AllowEmpty to allow it", listenerID, removed)
+	}
+	// the backends are added before the stale ones are removed, so a failed removal leaves the
+	// listener with more backends rather than none, and the backends added are applied anyway
+	if len(added) > 0 {
+		newBackends := make([]*service.LoadBalancerBackend, 0, len(added))
+		addedInstanceIDs := make([]string, 0, len(added))
+		for _, instance := range added {
+			backend := &service.LoadBalancerBackend{
+				ResourceID:              instance.InstanceID,
+				LoadBalancerBackendName: service.String(syncedBackendName(instance)),
+				Port:                    service.Int(selector.Port),
+			}
+			if selector.Weight > 0 {
+				backend.Weight = service.Int(selector.Weight)
+			}
+			newBackends = append(newBackends, backend)
+			addedInstanceIDs = append(addedInstanceIDs, service.StringValue(instance.InstanceID))
+		}
+		logger.Debug("Adding instances %v as backends of listener [%s]", addedInstanceIDs, listenerID)
+		_, err = lbService.AddLoadBalancerBackends(&service.AddLoadBalancerBackendsInput{
+			LoadBalancerListener: service.String(listenerID),
+			Backends:             newBackends,
+		})
+		if err != nil {
+			return result, err
+		}
+		result.AddedInstanceIDs = addedInstanceIDs
+	}
+	if len(removed) > 0 {
+		logger.Debug("Removing backends %v from listener [%s]", removed, listenerID)
+		_, err = lbService.DeleteLoadBalancerBackends(&service.DeleteLoadBalancerBackendsInput{
+			LoadBalancerBackends: service.StringSlice(removed),
+		})
+		if err != nil {
+			if !result.Changed() {
+				return result, err
+			}
+			if applyErr := result.apply(lbService, jobService, loadBalancerID, timeout, waitInterval); applyErr != nil {
+				return result, fmt.Errorf("%s, and apply the added backends of listener [%s] error : %s", err.Error(), listenerID, applyErr.Error())
+			}
+			return result, err
+		}
+		result.RemovedBackendIDs = removed
+	}
+	if !result.Changed() {
+		return result, nil
+	}
+	return result, result.apply(lbService, jobService, loadBalancerID, timeout, waitInterval)
+}
+
+// apply the loadBalancer with the changed backends and record whether it succeeded
+func (r *BackendSyncResult) apply(lbService *service.LoadBalancerService, jobService *service.JobService, loadBalancerID string, timeout time.Duration, waitInterval time.Duration) error {
+	err := ApplyLoadBalancer(lbService, jobService, loadBalancerID, timeout, waitInterval)
+	r.Applied = err == nil
+	return err
+}
+
+// syncedBackendName return the name of the backend of the instance added by SyncListenerBackends
+func syncedBackendName(instance *service.Instance) string {
+	name := service.StringValue(instance.InstanceName)
+	if name == "" {
+		name = service.StringValue(instance.InstanceID)
+	}
+	return BackendSyncNamePrefix + name
+}
+
+// diffBackends return the instances to add as backends on the port, and the IDs of the backends
+// added by SyncListenerBackends on the port to remove since their instances are not selected
+func diffBackends(backends []*service.LoadBalancerBackend, instances []*service.Instance, port int) ([]*service.Instance, []string) {
+	selected := make(map[string]bool, len(instances))
+	for _, instance := range instances {
+		selected[service.StringValue(instance.InstanceID)] = true
+	}
+	existing := map[string]bool{}
+	removed := []string{}
+	for _, backend := range backends {
+		if service.StringValue(backend.LoadBalancerPolicyID) != "" {
+			continue
+		}
+		resourceID := service.StringValue(backend.ResourceID)
+		if !strings.HasPrefix(resourceID, "i-") || service.IntValue(backend.Port) != port {
+			continue
+		}
+		if selected[resourceID] {
+			existing[resourceID] = true
+			continue
+		}
+		if !strings.HasPrefix(service.StringValue(backend.LoadBalancerBackendName), BackendSyncNamePrefix) {
+			continue
+		}
+		removed = append(removed, service.StringValue(backend.LoadBalancerBackendID))
+	}
+	added := []*service.Instance{}
+	for _, instance := range instances {
+		if !existing[service.StringValue(instance.InstanceID)] {
+			added = append(added, instance)
+		}
+	}
+	return added, removed
+}
+
+// describeInstancesWithTags list the instances which are not terminated and have all these tags
+func describeInstancesWithTags(instanceService *service.InstanceService, tagIDs []string) ([]*service.Instance, error) {
+	instances := []*service.Instance{}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := instanceService.DescribeInstances(&service.DescribeInstancesInput{
+			Tags:    service.StringSlice(tagIDs),
+			Status:  service.StringSlice([]string{InstanceStatusPending, InstanceStatusRunning, InstanceStatusStopped, InstanceStatusSuspended}),
+			Verbose: service.Int(1),
+			Limit:   service.Int(limit),
+			Offset:  service.Int(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, instance := range output.InstanceSet {
+			if len(MissingTags(instance.Tags, tagIDs)) == 0 {
+				instances = append(instances, instance)
+			}
+		}
+		if len(output.InstanceSet) < limit {
+			return instances, nil
+		}
+	}
+}
+
+func describeListenerBackends(lbService *service.LoadBalancerService, listenerID string) ([]*service.LoadBalancerBackend, error) {
+	backends := []*service.LoadBalancerBackend{}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := lbService.DescribeLoadBalancerBackends(&service.DescribeLoadBalancerBackendsInput{
+			LoadBalancerListener: service.String(listenerID),
+			Limit:                service.Int(limit),
+			Offset:               service.Int(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		backends = append(backends, output.LoadBalancerBackendSet...)
+		if len(output.LoadBalancerBackendSet) < limit {
+			return backends, nil
+		}
+	}
+}
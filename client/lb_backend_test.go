@@ -0,0 +1,96 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+func TestDiffBackends(t *testing.T) {
+	backend := func(id string, resourceID string, name string, port int) *service.LoadBalancerBackend {
+		return &service.LoadBalancerBackend{
+			LoadBalancerBackendID:   service.String(id),
+			ResourceID:              service.String(resourceID),
+			LoadBalancerBackendName: service.String(name),
+			Port:                    service.Int(port),
+		}
+	}
+	policyBackend := backend("lbb-policy", "i-6", "synced-i-6", 80)
+	policyBackend.LoadBalancerPolicyID = service.String("lbp-1")
+	backends := []*service.LoadBalancerBackend{
+		backend("lbb-1", "i-1", "synced-web-1", 80),
+		backend("lbb-ip", "ip-1", "synced-ip", 80),
+		backend("lbb-hand", "i-3", "manual", 80),
+		backend("lbb-4", "i-4", "synced-web-4", 80),
+		backend("lbb-port", "i-5", "synced-web-5", 8080),
+		policyBackend,
+	}
+	instances := []*service.Instance{
+		{InstanceID: service.String("i-1")},
+		{InstanceID: service.String("i-2")},
+	}
+
+	added, removed := diffBackends(backends, instances, 80)
+	assert.Equal(t, 1, len(added))
+	assert.Equal(t, "i-2", service.StringValue(added[0].InstanceID))
+	assert.Equal(t, []string{"lbb-4"}, removed)
+
+	added, removed = diffBackends(backends, nil, 80)
+	assert.Equal(t, 0, len(added))
+	assert.Equal(t, []string{"lbb-1", "lbb-4"}, removed)
+}
+
+func TestSyncListenerBackendsRefuseEmpty(t *testing.T) {
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.respond("DescribeInstances", `{"action":"DescribeInstancesResponse","ret_code":0,"total_count":0,"instance_set":[]}`)
+	api.respond("DescribeLoadBalancerBackends", `{"action":"DescribeLoadBalancerBackendsResponse","ret_code":0,"loadbalancer_backend_set":[
+		{"loadbalancer_backend_id":"lbb-1","resource_id":"i-1","loadbalancer_backend_name":"synced-web-1","port":80}]}`)
+	api.respond("UpdateLoadBalancers", `{"action":"UpdateLoadBalancersResponse","ret_code":0,"job_id":"j-1"}`)
+	api.respond("DescribeJobs", `{"action":"DescribeJobsResponse","ret_code":0,"job_set":[{"job_id":"j-1","status":"successful"}]}`)
+	lbService, _ := qcService.LoadBalancer("pek3a")
+	instanceService, _ := qcService.Instance("pek3a")
+	jobService, _ := qcService.Job("pek3a")
+
+	selector := &BackendSelector{TagIDs: []string{"tag-1"}, Port: 80}
+	_, err := SyncListenerBackends(lbService, instanceService, jobService, "lb-1", "lbl-1", selector, time.Second, time.Millisecond)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, len(api.called("DeleteLoadBalancerBackends")))
+
+	selector.AllowEmpty = true
+	result, err := SyncListenerBackends(lbService, instanceService, jobService, "lb-1", "lbl-1", selector, time.Second, time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"lbb-1"}, result.RemovedBackendIDs)
+	assert.True(t, result.Applied)
+	assert.Equal(t, 1, len(api.called("DeleteLoadBalancerBackends")))
+}
+
+func TestSyncListenerBackendsDeleteFails(t *testing.T) {
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.respond("DescribeInstances", `{"action":"DescribeInstancesResponse","ret_code":0,"total_count":1,"instance_set":[
+		{"instance_id":"i-2","instance_name":"web-2","tags":[{"tag_id":"tag-1"}]}]}`)
+	api.respond("DescribeLoadBalancerBackends", `{"action":"DescribeLoadBalancerBackendsResponse","ret_code":0,"loadbalancer_backend_set":[
+		{"loadbalancer_backend_id":"lbb-1","resource_id":"i-1","loadbalancer_backend_name":"synced-web-1","port":80}]}`)
+	api.respond("DeleteLoadBalancerBackends", `{"action":"DeleteLoadBalancerBackendsResponse","ret_code":5000,"message":"InternalError"}`)
+	api.respond("UpdateLoadBalancers", `{"action":"UpdateLoadBalancersResponse","ret_code":0,"job_id":"j-1"}`)
+	api.respond("DescribeJobs", `{"action":"DescribeJobsResponse","ret_code":0,"job_set":[{"job_id":"j-1","status":"successful"}]}`)
+	lbService, _ := qcService.LoadBalancer("pek3a")
+	instanceService, _ := qcService.Instance("pek3a")
+	jobService, _ := qcService.Job("pek3a")
+
+	selector := &BackendSelector{TagIDs: []string{"tag-1"}, Port: 80}
+	result, err := SyncListenerBackends(lbService, instanceService, jobService, "lb-1", "lbl-1", selector, time.Second, time.Millisecond)
+	assert.NotNil(t, err)
+	// the backend is added before the stale one is removed, and applied although the removal failed
+	assert.Equal(t, []string{"i-2"}, result.AddedInstanceIDs)
+	assert.Nil(t, result.RemovedBackendIDs)
+	assert.True(t, result.Applied)
+	actions := []string{}
+	for _, params := range api.calls {
+		actions = append(actions, params.Get("action"))
+	}
+	assert.Equal(t, []string{"AddLoadBalancerBackends", "DeleteLoadBalancerBackends", "UpdateLoadBalancers"}, actions[2:5])
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+// ApplyLoadBalancer update the loadBalancer to make the modified listeners and backends take effect
+func ApplyLoadBalancer(lbService *service.LoadBalancerService, jobService *service.JobService, loadBalancerID string, timeout time.Duration, waitInterval time.Duration) error {
+	output, err := lbService.UpdateLoadBalancers(&service.UpdateLoadBalancersInput{
+		LoadBalancers: []*string{service.String(loadBalancerID)},
+	})
+	if err != nil {
+		return err
+	}
+	return waitOutputJob(jobService, output.JobID, timeout, waitInterval)
+}
+
+// FindListener find the listener of the loadBalancer by port and protocol, it returns nil if not found
+func FindListener(lbService *service.LoadBalancerService, loadBalancerID string, port int, protocol string) (*service.LoadBalancerListener, error) {
+	listeners, err := describeListeners(lbService, loadBalancerID, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, listener := range listeners {
+		if listener == nil {
+			continue
+		}
+		if service.IntValue(listener.ListenerPort) == port &&
+			strings.EqualFold(service.StringValue(listener.ListenerProtocol), protocol) {
+			return listener, nil
+		}
+	}
+	return nil, nil
+}
+
+// EnsureListener make sure the loadBalancer has a listener matching the spec by port and protocol,
+// it updates the drifted attributes in place, creates the listener if missing and applies the loadBalancer.
+// It returns the ID of the listener.
+func EnsureListener(lbService *service.LoadBalancerService, jobService *service.JobService, loadBalancerID string, spec *service.LoadBalancerListener, timeout time.Duration, waitInterval time.Duration) (string, error) {
+	if spec == nil || spec.ListenerPort == nil || spec.ListenerProtocol == nil {
+		return "", fmt.Errorf("Listener spec with ListenerPort and ListenerProtocol is required to ensure listener")
+	}
+	listener, err := FindListener(lbService, loadBalancerID, *spec.ListenerPort, *spec.ListenerProtocol)
+	if err != nil {
+		return "", err
+	}
+
+	var listenerID string
+	if listener == nil {
+		logger.Debug("Creating listener [%d/%s] of LoadBalancer [%s]", *spec.ListenerPort, *spec.ListenerProtocol, loadBalancerID)
+		output, err := lbService.AddLoadBalancerListeners(&service.AddLoadBalancerListenersInput{
+			LoadBalancer: service.String(loadBalancerID),
+			Listeners:    []*service.LoadBalancerListener{spec},
+		})
+		if err != nil {
+			return "", err
+		}
+		if len(output.LoadBalancerListeners) == 0 || service.StringValue(output.LoadBalancerListeners[0]) == "" {
+			return "", fmt.Errorf("Add listener to LoadBalancer [%s] response error", loadBalancerID)
+		}
+		listenerID = *output.LoadBalancerListeners[0]
+	} else {
+		listenerID = service.StringValue(listener.LoadBalancerListenerID)
+		if listenerID == "" {
+			return "", fmt.Errorf("Listener [%d/%s] of LoadBalancer [%s] has no ID", *spec.ListenerPort, *spec.ListenerProtocol, loadBalancerID)
+		}
+		input, drifted := listenerDrift(listener, spec)
+		if !drifted {
+			return listenerID, nil
+		}
+		logger.Debug("Updating drifted listener [%s] of LoadBalancer [%s]", listenerID, loadBalancerID)
+		if _, err := lbService.ModifyLoadBalancerListenerAttributes(input); err != nil {
+			return "", err
+		}
+	}
+
+	err = ApplyLoadBalancer(lbService, jobService, loadBalancerID, timeout, waitInterval)
+	if err != nil {
+		return "", err
+	}
+	return listenerID, nil
+}
+
+// listenerDrift returns the input to modify the attributes set in spec but differ from the listener,
+// attributes which can not be modified in place are ignored
+func listenerDrift(listener, spec *service.LoadBalancerListener) (*service.ModifyLoadBalancerListenerAttributesInput, bool) {
+	input := &service.ModifyLoadBalancerListenerAttributesInput{
+		LoadBalancerListener: listener.LoadBalancerListenerID,
+	}
+	drifted := false
+	diffString := func(current, expected *string) *string {
+		if expected != nil && service.StringValue(current) != *expected {
+			drifted = true
+			return expected
+		}
+		return nil
+	}
+	diffInt := func(current, expected *int) *int {
+		if expected != nil && service.IntValue(current) != *expected {
+			drifted = true
+			return expected
+		}
+		return nil
+	}
+
+	input.LoadBalancerListenerName = diffString(listener.LoadBalancerListenerName, spec.LoadBalancerListenerName)
+	input.BalanceMode = diffString(listener.BalanceMode, spec.BalanceMode)
+	input.HealthyCheckMethod = diffString(listener.HealthyCheckMethod, spec.HealthyCheckMethod)
+	input.HealthyCheckOption = diffString(listener.HealthyCheckOption, spec.HealthyCheckOption)
+	input.SessionSticky = diffString(listener.SessionSticky, spec.SessionSticky)
+	input.Forwardfor = diffInt(listener.Forwardfor, spec.Forwardfor)
+	input.ListenerOption = diffInt(listener.ListenerOption, spec.ListenerOption)
+	input.Timeout = diffInt(listener.Timeout, spec.Timeout)
+	input.Scene = diffInt(listener.Scene, spec.Scene)
+	if len(spec.ServerCertificateID) > 0 &&
+		!reflect.DeepEqual(service.StringValueSlice(listener.ServerCertificateID), service.StringValueSlice(spec.ServerCertificateID)) {
+		input.ServerCertificateID = spec.ServerCertificateID
+		drifted = true
+	}
+	return input, drifted
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+func TestEnsureListenerIncompleteResponses(t *testing.T) {
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	lbService, err := qcService.LoadBalancer("pek3a")
+	assert.Nil(t, err)
+	jobService, err := qcService.Job("pek3a")
+	assert.Nil(t, err)
+	spec := &service.LoadBalancerListener{
+		ListenerPort:     service.Int(80),
+		ListenerProtocol: service.String("http"),
+	}
+
+	_, err = EnsureListener(lbService, jobService, "lb-1", nil, time.Minute, time.Second)
+	assert.NotNil(t, err)
+
+	api.respond("DescribeLoadBalancerListeners", `{"ret_code":0,"total_count":1,"loadbalancer_listener_set":[null]}`)
+	api.respond("AddLoadBalancerListeners", `{"ret_code":0,"loadbalancer_listeners":[null]}`)
+	_, err = EnsureListener(lbService, jobService, "lb-1", spec, time.Minute, time.Second)
+	assert.EqualError(t, err, "Add listener to LoadBalancer [lb-1] response error")
+
+	api.respond("DescribeLoadBalancerListeners", `{"ret_code":0,"total_count":1,"loadbalancer_listener_set":[
+		{"listener_port":80,"listener_protocol":"http"}]}`)
+	_, err = EnsureListener(lbService, jobService, "lb-1", spec, time.Minute, time.Second)
+	assert.EqualError(t, err, "Listener [80/http] of LoadBalancer [lb-1] has no ID")
+
+	api.respond("DescribeLoadBalancerListeners", `{"ret_code":0,"total_count":1,"loadbalancer_listener_set":[
+		{"loadbalancer_listener_id":"lbl-1","listener_port":80,"listener_protocol":"http"}]}`)
+	spec.BalanceMode = service.String("roundrobin")
+	_, err = EnsureListener(lbService, jobService, "lb-1", spec, time.Minute, time.Second)
+	assert.EqualError(t, err, "Job ID not returned")
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+// DHCPLease the private IP leased to a NIC in a vxnet
+type DHCPLease struct {
+	VxNetID    string
+	MAC        string
+	IP         string
+	InstanceID string
+	NICName    string
+	Status     string
+}
+
+// DescribeVxNetLeases list the private IPs leased in the vxnet with this vxnetID
+func DescribeVxNetLeases(nicService *service.NicService, vxnetID string) ([]*DHCPLease, error) {
+	nics, err := describeNics(nicService, []string{vxnetID})
+	if err != nil {
+		return nil, err
+	}
+	leases := make([]*DHCPLease, 0, len(nics))
+	for _, nic := range nics {
+		if service.StringValue(nic.PrivateIP) != "" {
+			leases = append(leases, newDHCPLease(nic))
+		}
+	}
+	return leases, nil
+}
+
+// FindInstanceByAddress find the instance with the MAC address or private IP in these vxnets,
+// or in all vxnets if vxnetIDs is empty. It returns the lease of the matched NIC with the instance
+func FindInstanceByAddress(nicService *service.NicService, instanceService *service.InstanceService, address string, vxnetIDs []string) (*service.Instance, *DHCPLease, error) {
+	nics, err := describeNics(nicService, vxnetIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, nic := range nics {
+		if !nic.MatchAddress(address) {
+			continue
+		}
+		lease := newDHCPLease(nic)
+		if lease.InstanceID == "" {
+			return nil, lease, fmt.Errorf("NIC [%s] with address [%s] is not attached to any instance", lease.MAC, address)
+		}
+		instance, err := describeInstance(instanceService, lease.InstanceID)
+		if err != nil {
+			return nil, lease, err
+		}
+		return instance, lease, nil
+	}
+	return nil, nil, fmt.Errorf("Instance with address [%s] not exist", address)
+}
+
+// AssignNicIPs assign the secondary private IPs to the NIC with this nicID and wait it finished,
+// count IPs are allocated by the vxnet if ips is empty. It returns the NIC with its secondary IPs
+func AssignNicIPs(nicService *service.NicService, jobService *service.JobService, nicID string, ips []string, count int, timeout time.Duration, waitInterval time.Duration) (*service.NIC, error) {
+	if err := service.ValidateNicIPs(ips); err != nil {
+		return nil, err
+	}
+	input := &service.AssignNicIPsInput{Nic: service.String(nicID)}
+	if len(ips) > 0 {
+		input.PrivateIPs = service.StringSlice(ips)
+	} else {
+		input.Count = service.Int(count)
+	}
+	output, err := nicService.AssignNicIPs(input)
+	if err != nil {
+		return nil, err
+	}
+	if output.JobID != nil {
+		if err = WaitJob(jobService, *output.JobID, timeout, waitInterval); err != nil {
+			return nil, err
+		}
+	}
+	nic, err := describeNic(nicService, nicID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range service.StringValueSlice(output.PrivateIPs) {
+		if !nic.HasIP(ip) {
+			return nic, fmt.Errorf("IP [%s] is not assigned to NIC [%s]", ip, nicID)
+		}
+	}
+	return nic, nil
+}
+
+// UnassignNicIPs unassign the secondary private IPs from the NIC with this nicID and wait it finished
+func UnassignNicIPs(nicService *service.NicService, jobService *service.JobService, nicID string, ips []string, timeout time.Duration, waitInterval time.Duration) error {
+	if err := service.ValidateNicIPs(ips); err != nil {
+		return err
+	}
+	output, err := nicService.UnassignNicIPs(&service.UnassignNicIPsInput{
+		Nic:        service.String(nicID),
+		PrivateIPs: service.StringSlice(ips),
+	})
+	if err != nil {
+		return err
+	}
+	if output.JobID != nil {
+		return WaitJob(jobService, *output.JobID, timeout, waitInterval)
+	}
+	return nil
+}
+
+// MoveNicIP move the secondary private IP from the NIC with fromNicID to the NIC with toNicID,
+// e.g. to fail over a keepalived virtual IP. The IP is only unassigned if it is still on the old NIC
+func MoveNicIP(nicService *service.NicService, jobService *service.JobService, ip string, fromNicID string, toNicID string, timeout time.Duration, waitInterval time.Duration) (*service.NIC, error) {
+	from, err := describeNic(nicService, fromNicID)
+	if err != nil {
+		return nil, err
+	}
+	if from.HasIP(ip) {
+		logger.Debug("Unassigning IP [%s] from NIC [%s]", ip, fromNicID)
+		if err = UnassignNicIPs(nicService, jobService, fromNicID, []string{ip}, timeout, waitInterval); err != nil {
+			return nil, err
+		}
+	}
+	logger.Debug("Assigning IP [%s] to NIC [%s]", ip, toNicID)
+	return AssignNicIPs(nicService, jobService, toNicID, []string{ip}, 0, timeout, waitInterval)
+}
+
+func describeNic(nicService *service.NicService, nicID string) (*service.NIC, error) {
+	output, err := nicService.DescribeNics(&service.DescribeNicsInput{
+		Nics: []*string{service.String(nicID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.NICSet) == 0 {
+		return nil, fmt.Errorf("NIC with id [%s] not exist", nicID)
+	}
+	return output.NICSet[0], nil
+}
+
+func newDHCPLease(nic *service.NIC) *DHCPLease {
+	return &DHCPLease{
+		VxNetID:    service.StringValue(nic.VxNetID),
+		MAC:        nic.MAC(),
+		IP:         service.StringValue(nic.PrivateIP),
+		InstanceID: service.StringValue(nic.InstanceID),
+		NICName:    service.StringValue(nic.NICName),
+		Status:     service.StringValue(nic.Status),
+	}
+}
+
+func describeNics(nicService *service.NicService, vxnetIDs []string) ([]*service.NIC, error) {
+	nics := []*service.NIC{}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		input := &service.DescribeNicsInput{
+			Limit:  service.Int(limit),
+			Offset: service.Int(offset),
+		}
+		if len(vxnetIDs) > 0 {
+			input.VxNets = service.StringSlice(vxnetIDs)
+		}
+		output, err := nicService.DescribeNics(input)
+		if err != nil {
+			return nil, err
+		}
+		nics = append(nics, output.NICSet...)
+		if len(output.NICSet) < limit {
+			return nics, nil
+		}
+	}
+}
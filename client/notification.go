@@ -0,0 +1,117 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+const (
+	//NotificationSendStatusSuccessful successful
+	NotificationSendStatusSuccessful = "successful"
+	//NotificationSendStatusFailed failed
+	NotificationSendStatusFailed = "failed"
+)
+
+// CheckNotificationList check all items of the notification list can receive the notifications,
+// it returns the errors of the items which are not verified or have invalid webhooks, indexed by the item ID
+func CheckNotificationList(notificationService *service.NotificationService, notificationListID string) (map[string]error, error) {
+	list, err := describeNotificationList(notificationService, notificationListID)
+	if err != nil {
+		return nil, err
+	}
+	problems := map[string]error{}
+	for _, item := range list.Items {
+		if err := item.Check(); err != nil {
+			problems[service.StringValue(item.NotificationItemID)] = err
+		}
+	}
+	return problems, nil
+}
+
+func describeNotificationList(notificationService *service.NotificationService, notificationListID string) (*service.NotificationList, error) {
+	output, err := notificationService.DescribeNotificationLists(&service.DescribeNotificationListsInput{
+		NotificationLists: []*string{service.String(notificationListID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.NotificationListSet) == 0 {
+		return nil, fmt.Errorf("Notification list with id [%s] not exist", notificationListID)
+	}
+	list := output.NotificationListSet[0]
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("Notification list [%s] has no items", notificationListID)
+	}
+	return list, nil
+}
+
+// notificationItemKey identifies the item of a send history, which has no item ID
+func notificationItemKey(itemType *string, content *string) string {
+	return service.StringValue(itemType) + ":" + service.StringValue(content)
+}
+
+// WaitNotificationSent wait the notifications of the resource sent to every item of the notification list since the time,
+// e.g. after SendAlarmNotification, until each item has a successful or failed send history.
+// It returns the send history and fails if any notification failed
+func WaitNotificationSent(notificationService *service.NotificationService, notificationListID string, resourceID string, since time.Time, timeout time.Duration, waitInterval time.Duration) (history []*service.NotificationSendHistory, err error) {
+	list, err := describeNotificationList(notificationService, notificationListID)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("Waiting for notifications of [%s] sent to the %d items of [%s]", resourceID, len(list.Items), notificationListID)
+	errorTimes := 0
+	err = utils.WaitForSpecificOrError(func() (bool, error) {
+		sent, err := describeNotificationsSendHistory(notificationService, notificationListID, resourceID, since)
+		if err != nil {
+			logger.Error("DescribeNotificationsSendHistory [%s] error : [%s]", notificationListID, err.Error())
+			errorTimes++
+			if errorTimes > 3 {
+				return false, err
+			}
+			return false, nil
+		}
+		errorTimes = 0
+		history = sent
+		final := map[string]bool{}
+		for _, h := range history {
+			switch service.StringValue(h.Status) {
+			case NotificationSendStatusFailed:
+				return false, fmt.Errorf("Notification to [%s] failed : %s", service.StringValue(h.NotificationItemContent), service.StringValue(h.ErrorMessage))
+			case NotificationSendStatusSuccessful:
+				final[notificationItemKey(h.NotificationItemType, h.NotificationItemContent)] = true
+			}
+		}
+		for _, item := range list.Items {
+			if !final[notificationItemKey(item.NotificationItemType, item.Content)] {
+				return false, nil
+			}
+		}
+		return true, nil
+	}, timeout, waitInterval)
+	return
+}
+
+func describeNotificationsSendHistory(notificationService *service.NotificationService, notificationListID string, resourceID string, since time.Time) ([]*service.NotificationSendHistory, error) {
+	history := []*service.NotificationSendHistory{}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := notificationService.DescribeNotificationsSendHistory(&service.DescribeNotificationsSendHistoryInput{
+			NotificationListID: service.String(notificationListID),
+			ResourceID:         service.String(resourceID),
+			StartTime:          service.Time(since),
+			Limit:              service.Int(limit),
+			Offset:             service.Int(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, output.NotificationSendHistorySet...)
+		if len(output.NotificationSendHistorySet) < limit {
+			return history, nil
+		}
+	}
+}
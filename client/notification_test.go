@@ -0,0 +1,56 @@
+package client
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+func TestWaitNotificationSent(t *testing.T) {
+	defer utils.SetDefaultClock(utils.NewFakeClock(time.Now()))()
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.respond("DescribeNotificationLists", `{"action":"DescribeNotificationListsResponse","ret_code":0,"total_count":1,"notification_list_set":[
+		{"notification_list_id":"nl-1","items":[
+			{"notification_item_id":"ni-1","notification_item_type":"email","content":"ops@example.com"},
+			{"notification_item_id":"ni-2","notification_item_type":"webhook","content":"https://hooks.example.com/alarm"}]}]}`)
+	histories := []string{
+		`{"action":"DescribeNotificationsSendHistoryResponse","ret_code":0,"total_count":1,"notification_send_history_set":[
+			{"notification_item_type":"email","notification_item_content":"ops@example.com","status":"successful"}]}`,
+		`{"action":"DescribeNotificationsSendHistoryResponse","ret_code":0,"total_count":2,"notification_send_history_set":[
+			{"notification_item_type":"email","notification_item_content":"ops@example.com","status":"successful"},
+			{"notification_item_type":"webhook","notification_item_content":"https://hooks.example.com/alarm","status":"successful"}]}`,
+	}
+	api.handle("DescribeNotificationsSendHistory", func(params url.Values) string {
+		history := histories[0]
+		if len(histories) > 1 {
+			histories = histories[1:]
+		}
+		return history
+	})
+	notificationService, _ := qcService.Notification("pek3a")
+
+	history, err := WaitNotificationSent(notificationService, "nl-1", "i-1", time.Now(), time.Minute, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(history))
+	// the webhook was not sent after the first describe
+	assert.Equal(t, 2, len(api.called("DescribeNotificationsSendHistory")))
+}
+
+func TestWaitNotificationSentFailed(t *testing.T) {
+	defer utils.SetDefaultClock(utils.NewFakeClock(time.Now()))()
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.respond("DescribeNotificationLists", `{"action":"DescribeNotificationListsResponse","ret_code":0,"total_count":1,"notification_list_set":[
+		{"notification_list_id":"nl-1","items":[{"notification_item_id":"ni-1","notification_item_type":"email","content":"ops@example.com"}]}]}`)
+	api.respond("DescribeNotificationsSendHistory", `{"action":"DescribeNotificationsSendHistoryResponse","ret_code":0,"total_count":1,"notification_send_history_set":[
+		{"notification_item_type":"email","notification_item_content":"ops@example.com","status":"failed","error_message":"mailbox full"}]}`)
+	notificationService, _ := qcService.Notification("pek3a")
+
+	_, err := WaitNotificationSent(notificationService, "nl-1", "i-1", time.Now(), time.Minute, time.Second)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "mailbox full")
+}
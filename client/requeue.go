@@ -0,0 +1,58 @@
+package client
+
+import (
+	"errors"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	qcErrors "github.com/yunify/qingcloud-sdk-go/request/errors"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+// requeueOnConflict call the operation, while it fails with a transient conflict it waits the resource leave
+// its transition state by wait and calls it again, until the TransientConflictTimeout of conf is exhausted
+func requeueOnConflict(conf *config.Config, call func() error, wait func(timeout time.Duration) error) error {
+	timeout := time.Duration(conf.TransientConflictTimeout) * time.Second
+	deadline := utils.DefaultClock.Now().Add(timeout)
+	for {
+		err := call()
+		if !errors.Is(err, qcErrors.ErrTransientConflict) {
+			return err
+		}
+		remaining := deadline.Sub(utils.DefaultClock.Now())
+		if remaining <= 0 {
+			return err
+		}
+		logger.Info("Requeue operation on transient conflict : [%s]", err.Error())
+		if waitErr := wait(remaining); waitErr != nil {
+			logger.Warn("Wait resource leave transition error : [%s]", waitErr.Error())
+			return err
+		}
+	}
+}
+
+// WaitInstanceTransition wait the instance with this instanceID leave its transition state
+func WaitInstanceTransition(instanceService *service.InstanceService, instanceID string, timeout time.Duration, waitInterval time.Duration) error {
+	return waitInstanceTransitionUntil(instanceService, instanceID, timeout, waitInterval, nil)
+}
+
+func waitInstanceTransitionUntil(instanceService *service.InstanceService, instanceID string, timeout time.Duration, waitInterval time.Duration, done <-chan struct{}) error {
+	logger.Debug("Waiting for Instance [%s] leave transition", instanceID)
+	return utils.WaitForSpecificOrErrorUntil(func() (bool, error) {
+		i, err := describeInstance(instanceService, instanceID)
+		if err != nil {
+			return false, err
+		}
+		return service.StringValue(i.TransitionStatus) == "", nil
+	}, timeout, waitInterval, done)
+}
+
+// requeueInstanceOnConflict call the operation on the instance, requeue it after the instance
+// leaves its transition state if it fails with a transient conflict, see requeueOnConflict
+func requeueInstanceOnConflict(instanceService *service.InstanceService, instanceID string, waitInterval time.Duration, done <-chan struct{}, call func() error) error {
+	return requeueOnConflict(instanceService.Config, call, func(timeout time.Duration) error {
+		return waitInstanceTransitionUntil(instanceService, instanceID, timeout, waitInterval, done)
+	})
+}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+const (
+	//RouterStatusPending pending
+	RouterStatusPending = "pending"
+	//RouterStatusActive active
+	RouterStatusActive = "active"
+	//RouterStatusPoweroffed poweroffed
+	RouterStatusPoweroffed = "poweroffed"
+	//RouterStatusSuspended suspended
+	RouterStatusSuspended = "suspended"
+	//RouterStatusDeleted deleted
+	RouterStatusDeleted = "deleted"
+	//RouterStatusCeased ceased
+	RouterStatusCeased = "ceased"
+
+	//RouterVxNetUnmanaged join the vxnet without dhcp server
+	RouterVxNetUnmanaged = 0
+	//RouterVxNetManaged join the vxnet with dhcp server
+	RouterVxNetManaged = 1
+
+	//RouterVxNetDHCPEnable enable the dhcp server of a joined vxnet
+	RouterVxNetDHCPEnable = 1
+	//RouterVxNetDHCPDisable disable the dhcp server of a joined vxnet
+	RouterVxNetDHCPDisable = 2
+)
+
+// RouterVxNetSpec describe how a vxnet join a router
+type RouterVxNetSpec struct {
+	VxNetID    string
+	IPNetwork  string
+	ManagerIP  string
+	DYNIPStart string
+	DYNIPEnd   string
+	// Managed vxnet has the dhcp server of router enabled
+	Managed bool
+}
+
+// JoinRouter join the vxnet to the router with spec and apply the router
+func JoinRouter(routerService *service.RouterService, jobService *service.JobService, routerID string, spec *RouterVxNetSpec, timeout time.Duration, waitInterval time.Duration) error {
+	if spec == nil || spec.VxNetID == "" {
+		return fmt.Errorf("VxNet spec with VxNetID is required to join router [%s]", routerID)
+	}
+	features := RouterVxNetUnmanaged
+	if spec.Managed {
+		features = RouterVxNetManaged
+	}
+	input := &service.JoinRouterInput{
+		Router:    service.String(routerID),
+		VxNet:     service.String(spec.VxNetID),
+		IPNetwork: service.String(spec.IPNetwork),
+		Features:  service.Int(features),
+	}
+	if spec.ManagerIP != "" {
+		input.ManagerIP = service.String(spec.ManagerIP)
+	}
+	if spec.DYNIPStart != "" {
+		input.DYNIPStart = service.String(spec.DYNIPStart)
+	}
+	if spec.DYNIPEnd != "" {
+		input.DYNIPEnd = service.String(spec.DYNIPEnd)
+	}
+	output, err := routerService.JoinRouter(input)
+	if err != nil {
+		return err
+	}
+	err = waitOutputJob(jobService, output.JobID, timeout, waitInterval)
+	if err != nil {
+		return err
+	}
+	return ApplyRouter(routerService, jobService, routerID, timeout, waitInterval)
+}
+
+// SetRouterVxNetDHCP enable or disable the dhcp server of the vxnet joined the router and apply the router
+func SetRouterVxNetDHCP(routerService *service.RouterService, jobService *service.JobService, routerID string, vxnetID string, enabled bool, timeout time.Duration, waitInterval time.Duration) error {
+	features := RouterVxNetDHCPDisable
+	if enabled {
+		features = RouterVxNetDHCPEnable
+	}
+	_, err := routerService.ModifyRouterAttributes(&service.ModifyRouterAttributesInput{
+		Router:   service.String(routerID),
+		VxNet:    service.String(vxnetID),
+		Features: service.Int(features),
+	})
+	if err != nil {
+		return err
+	}
+	return ApplyRouter(routerService, jobService, routerID, timeout, waitInterval)
+}
+
+// BindRouterSecurityGroup bind the security group to the router and apply the router
+func BindRouterSecurityGroup(routerService *service.RouterService, jobService *service.JobService, routerID string, securityGroupID string, timeout time.Duration, waitInterval time.Duration) error {
+	_, err := routerService.ModifyRouterAttributes(&service.ModifyRouterAttributesInput{
+		Router:        service.String(routerID),
+		SecurityGroup: service.String(securityGroupID),
+	})
+	if err != nil {
+		return err
+	}
+	return ApplyRouter(routerService, jobService, routerID, timeout, waitInterval)
+}
+
+// ApplyRouter update the router to make the modified attributes and statics take effect
+func ApplyRouter(routerService *service.RouterService, jobService *service.JobService, routerID string, timeout time.Duration, waitInterval time.Duration) error {
+	output, err := routerService.UpdateRouters(&service.UpdateRoutersInput{
+		Routers: []*string{service.String(routerID)},
+	})
+	if err != nil {
+		return err
+	}
+	return waitOutputJob(jobService, output.JobID, timeout, waitInterval)
+}
+
+func describeRouter(routerService *service.RouterService, routerID string) (*service.Router, error) {
+	output, err := routerService.DescribeRouters(&service.DescribeRoutersInput{
+		Routers: []*string{&routerID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.RouterSet) == 0 {
+		return nil, fmt.Errorf("Router with id [%s] not exist", routerID)
+	}
+	return output.RouterSet[0], nil
+}
+
+// WaitRouterStatus wait the router with this routerID to expect status
+func WaitRouterStatus(routerService *service.RouterService, routerID string, status string, timeout time.Duration, waitInterval time.Duration) (router *service.Router, err error) {
+	logger.Debug("Waiting for Router [%s] status [%s] ", routerID, status)
+	errorTimes := 0
+	err = utils.WaitForSpecificOrError(func() (bool, error) {
+		r, err := describeRouter(routerService, routerID)
+		if err != nil {
+			logger.Error("DescribeRouter [%s] error : [%s]", routerID, err.Error())
+			errorTimes++
+			if errorTimes > 3 {
+				return false, err
+			}
+			return false, nil
+		}
+		if r.Status != nil && *r.Status == status {
+			if r.TransitionStatus != nil && *r.TransitionStatus != "" {
+				//wait transition to finished
+				return false, nil
+			}
+			router = r
+			logger.Debug("Router [%s] status is [%s] ", routerID, *r.Status)
+			return true, nil
+		}
+		return false, nil
+	}, timeout, waitInterval)
+	return
+}
+
+// CheckRouterSaturation check whether the p-th percentile throughput of the router in the last window
+// reaches the ratio of the bandwidth of its EIP, it returns the stats of the window either way
+func CheckRouterSaturation(routerService *service.RouterService, eipService *service.EIPService, monitorService *service.MonitorService, routerID string, window time.Duration, p float64, ratio float64) (bool, *service.RouterStats, error) {
+	router, err := describeRouter(routerService, routerID)
+	if err != nil {
+		return false, nil, err
+	}
+	if router.EIP == nil || service.StringValue(router.EIP.EIPID) == "" {
+		return false, nil, fmt.Errorf("Router [%s] has no eip", routerID)
+	}
+	eipID := *router.EIP.EIPID
+	output, err := eipService.DescribeEIPs(&service.DescribeEIPsInput{
+		EIPs: []*string{service.String(eipID)},
+	})
+	if err != nil {
+		return false, nil, err
+	}
+	if len(output.EIPSet) == 0 {
+		return false, nil, fmt.Errorf("EIP with id [%s] not exist", eipID)
+	}
+	bandwidth := float64(service.IntValue(output.EIPSet[0].Bandwidth)) * 1000 * 1000
+
+	end := utils.DefaultClock.Now()
+	stats, err := monitorService.GetRouterStats(routerID, end.Add(-window), end, "5m")
+	if err != nil {
+		return false, nil, err
+	}
+	saturated := stats.Saturated(bandwidth, p, ratio)
+	if saturated {
+		in, out := stats.BandwidthUsage(bandwidth, p)
+		logger.Warn("Router [%s] bandwidth saturated, usage in [%.2f] out [%.2f] of [%d]Mbps", routerID, in, out, service.IntValue(output.EIPSet[0].Bandwidth))
+	}
+	return saturated, stats, nil
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouterHelpersWithoutJobID(t *testing.T) {
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	routerService, err := qcService.Router("pek3a")
+	assert.Nil(t, err)
+	jobService, err := qcService.Job("pek3a")
+	assert.Nil(t, err)
+
+	err = JoinRouter(routerService, jobService, "rtr-1", nil, time.Minute, time.Second)
+	assert.EqualError(t, err, "VxNet spec with VxNetID is required to join router [rtr-1]")
+	assert.Equal(t, 0, len(api.called("JoinRouter")))
+
+	spec := &RouterVxNetSpec{VxNetID: "vxnet-1", IPNetwork: "192.168.1.0/24", Managed: true}
+	err = JoinRouter(routerService, jobService, "rtr-1", spec, time.Minute, time.Second)
+	assert.EqualError(t, err, "Job ID not returned")
+	assert.Equal(t, "1", api.called("JoinRouter")[0].Get("features"))
+	assert.Equal(t, 0, len(api.called("UpdateRouters")))
+
+	err = BindRouterSecurityGroup(routerService, jobService, "rtr-1", "sg-1", time.Minute, time.Second)
+	assert.EqualError(t, err, "Job ID not returned")
+	assert.Equal(t, 1, len(api.called("UpdateRouters")))
+	assert.Equal(t, 0, len(api.called("DescribeJobs")))
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+// ApplySecurityGroup apply the rules of the security group to its resources and wait the job finish
+func ApplySecurityGroup(sgService *service.SecurityGroupService, jobService *service.JobService, securityGroupID string, timeout time.Duration, waitInterval time.Duration) error {
+	output, err := sgService.ApplySecurityGroup(&service.ApplySecurityGroupInput{
+		SecurityGroup: service.String(securityGroupID),
+	})
+	if err != nil {
+		return err
+	}
+	return waitOutputJob(jobService, output.JobID, timeout, waitInterval)
+}
+
+// SnapshotSecurityGroup snapshot the current rules of the security group, it returns the ID of the snapshot
+func SnapshotSecurityGroup(sgService *service.SecurityGroupService, securityGroupID string, name string) (string, error) {
+	input := &service.CreateSecurityGroupSnapshotInput{
+		SecurityGroup: service.String(securityGroupID),
+	}
+	if name != "" {
+		input.Name = service.String(name)
+	}
+	output, err := sgService.CreateSecurityGroupSnapshot(input)
+	if err != nil {
+		return "", err
+	}
+	if service.StringValue(output.SecurityGroupSnapshotID) == "" {
+		return "", fmt.Errorf("Snapshot security group [%s] response error", securityGroupID)
+	}
+	return *output.SecurityGroupSnapshotID, nil
+}
+
+// DescribeSecurityGroupSnapshots list all snapshots of the security group, the latest first
+func DescribeSecurityGroupSnapshots(sgService *service.SecurityGroupService, securityGroupID string) ([]*service.SecurityGroupSnapshot, error) {
+	snapshots := []*service.SecurityGroupSnapshot{}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := sgService.DescribeSecurityGroupSnapshots(&service.DescribeSecurityGroupSnapshotsInput{
+			SecurityGroup: service.String(securityGroupID),
+			Limit:         service.Int(limit),
+			Offset:        service.Int(offset),
+			Reverse:       service.Int(1),
+		})
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, output.SecurityGroupSnapshotSet...)
+		if len(output.SecurityGroupSnapshotSet) < limit {
+			return snapshots, nil
+		}
+	}
+}
+
+// RollbackSecurityGroup roll back the rules of the security group to the snapshot and apply them
+func RollbackSecurityGroup(sgService *service.SecurityGroupService, jobService *service.JobService, securityGroupID string, snapshotID string, timeout time.Duration, waitInterval time.Duration) error {
+	_, err := sgService.RollbackSecurityGroup(&service.RollbackSecurityGroupInput{
+		SecurityGroup:         service.String(securityGroupID),
+		SecurityGroupSnapshot: service.String(snapshotID),
+	})
+	if err != nil {
+		return err
+	}
+	return ApplySecurityGroup(sgService, jobService, securityGroupID, timeout, waitInterval)
+}
+
+// ChangeSecurityGroupRules snapshot the rules of the security group, run change and apply the security group.
+// If change or the apply fail, the rules are rolled back to the snapshot. It returns the ID of the snapshot
+// so the change can also be undone later by RollbackSecurityGroup
+func ChangeSecurityGroupRules(sgService *service.SecurityGroupService, jobService *service.JobService, securityGroupID string, change func() error, timeout time.Duration, waitInterval time.Duration) (string, error) {
+	snapshotID, err := SnapshotSecurityGroup(sgService, securityGroupID, "before-change-"+utils.DefaultClock.Now().UTC().Format("20060102150405"))
+	if err != nil {
+		return "", err
+	}
+	err = change()
+	if err == nil {
+		err = ApplySecurityGroup(sgService, jobService, securityGroupID, timeout, waitInterval)
+		if err == nil {
+			return snapshotID, nil
+		}
+	}
+
+	logger.Warn("Change security group [%s] error : [%s], rolling back to snapshot [%s]", securityGroupID, err.Error(), snapshotID)
+	rollbackErr := RollbackSecurityGroup(sgService, jobService, securityGroupID, snapshotID, timeout, waitInterval)
+	if rollbackErr != nil {
+		return snapshotID, fmt.Errorf("Change security group [%s] error : [%s], rollback to snapshot [%s] error : [%s]",
+			securityGroupID, err.Error(), snapshotID, rollbackErr.Error())
+	}
+	return snapshotID, err
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+const (
+	//SnapshotExportStatusPending pending
+	SnapshotExportStatusPending = "pending"
+	//SnapshotExportStatusWorking working
+	SnapshotExportStatusWorking = "working"
+	//SnapshotExportStatusSuccessful successful
+	SnapshotExportStatusSuccessful = "successful"
+	//SnapshotExportStatusFailed failed
+	SnapshotExportStatusFailed = "failed"
+)
+
+func describeSnapshotExport(snapshotService *service.SnapshotService, snapshotExportID string) (*service.SnapshotExport, error) {
+	output, err := snapshotService.DescribeSnapshotExports(&service.DescribeSnapshotExportsInput{
+		SnapshotExports: []*string{&snapshotExportID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.SnapshotExportSet) == 0 {
+		return nil, fmt.Errorf("Snapshot export with id [%s] not exist", snapshotExportID)
+	}
+	return output.SnapshotExportSet[0], nil
+}
+
+// WaitSnapshotExport wait the snapshot export with this snapshotExportID successful,
+// progress is called with the export every time its progress changes, if not nil
+func WaitSnapshotExport(snapshotService *service.SnapshotService, snapshotExportID string, progress func(*service.SnapshotExport), timeout time.Duration, waitInterval time.Duration) (export *service.SnapshotExport, err error) {
+	logger.Debug("Waiting for Snapshot export [%s] successful", snapshotExportID)
+	errorTimes := 0
+	lastProgress := -1
+	err = utils.WaitForSpecificOrError(func() (bool, error) {
+		e, err := describeSnapshotExport(snapshotService, snapshotExportID)
+		if err != nil {
+			logger.Error("DescribeSnapshotExport [%s] error : [%s]", snapshotExportID, err.Error())
+			errorTimes++
+			if errorTimes > 3 {
+				return false, err
+			}
+			return false, nil
+		}
+		if progress != nil && service.IntValue(e.Progress) != lastProgress {
+			lastProgress = service.IntValue(e.Progress)
+			progress(e)
+		}
+		switch service.StringValue(e.Status) {
+		case SnapshotExportStatusSuccessful:
+			logger.Debug("Snapshot export [%s] status is [%s] ", snapshotExportID, *e.Status)
+			export = e
+			return true, nil
+		case SnapshotExportStatusFailed:
+			return false, fmt.Errorf("Snapshot export [%s] of snapshot [%s] failed", snapshotExportID, service.StringValue(e.SnapshotID))
+		}
+		return false, nil
+	}, timeout, waitInterval)
+	return
+}
+
+// ExportSnapshot export the snapshot to the object storage bucket and wait it successful,
+// see WaitSnapshotExport for progress
+func ExportSnapshot(snapshotService *service.SnapshotService, input *service.ExportSnapshotsInput, progress func(*service.SnapshotExport), timeout time.Duration, waitInterval time.Duration) (*service.SnapshotExport, error) {
+	output, err := snapshotService.ExportSnapshots(input)
+	if err != nil {
+		return nil, err
+	}
+	if output.SnapshotExportID == nil {
+		return nil, fmt.Errorf("Export snapshot [%s] response error", service.StringValue(input.Snapshot))
+	}
+	return WaitSnapshotExport(snapshotService, *output.SnapshotExportID, progress, timeout, waitInterval)
+}
+
+// ExportSnapshotIncremental export the snapshot to the object storage bucket, only the changes since
+// its nearest ancestor successfully exported to the bucket are exported, or the whole snapshot if none
+func ExportSnapshotIncremental(snapshotService *service.SnapshotService, snapshotID string, bucket string, objectKey string, progress func(*service.SnapshotExport), timeout time.Duration, waitInterval time.Duration) (*service.SnapshotExport, error) {
+	baseSnapshotID, err := lastExportedAncestor(snapshotService, snapshotID, bucket)
+	if err != nil {
+		return nil, err
+	}
+	input := &service.ExportSnapshotsInput{
+		Snapshot: service.String(snapshotID),
+		Bucket:   service.String(bucket),
+	}
+	if objectKey != "" {
+		input.ObjectKey = service.String(objectKey)
+	}
+	if baseSnapshotID != "" {
+		logger.Info("Exporting Snapshot [%s] incremental since [%s]", snapshotID, baseSnapshotID)
+		input.BaseSnapshot = service.String(baseSnapshotID)
+	}
+	return ExportSnapshot(snapshotService, input, progress, timeout, waitInterval)
+}
+
+// lastExportedAncestor returns the nearest ancestor of the snapshot with a successful export to the bucket
+func lastExportedAncestor(snapshotService *service.SnapshotService, snapshotID string, bucket string) (string, error) {
+	ancestors := []string{}
+	for id := snapshotID; ; {
+		snapshot, err := describeSnapshot(snapshotService, id)
+		if err != nil {
+			return "", err
+		}
+		id = service.StringValue(snapshot.ParentID)
+		if id == "" || id == service.StringValue(snapshot.SnapshotID) {
+			break
+		}
+		ancestors = append(ancestors, id)
+	}
+	if len(ancestors) == 0 {
+		return "", nil
+	}
+
+	exported := map[string]bool{}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := snapshotService.DescribeSnapshotExports(&service.DescribeSnapshotExportsInput{
+			Snapshots: service.StringSlice(ancestors),
+			Status:    service.StringSlice([]string{SnapshotExportStatusSuccessful}),
+			Limit:     service.Int(limit),
+			Offset:    service.Int(offset),
+		})
+		if err != nil {
+			return "", err
+		}
+		for _, export := range output.SnapshotExportSet {
+			if service.StringValue(export.Bucket) == bucket {
+				exported[service.StringValue(export.SnapshotID)] = true
+			}
+		}
+		if len(output.SnapshotExportSet) < limit {
+			break
+		}
+	}
+	for _, id := range ancestors {
+		if exported[id] {
+			return id, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+// SnapshotGroupServices the services to backup and restore instances with their volumes
+type SnapshotGroupServices struct {
+	InstanceService *service.InstanceService
+	VolumeService   *service.VolumeService
+	SnapshotService *service.SnapshotService
+	JobService      *service.JobService
+}
+
+// InstanceSnapshotGroup the snapshots of the system disk and the data volumes of an instance
+// which are taken together by one CreateSnapshots request, so they are crash-consistent
+type InstanceSnapshotGroup struct {
+	Name       string
+	InstanceID string
+	// InstanceSnapshotID the snapshot of the system disk
+	InstanceSnapshotID string
+	// VolumeSnapshotIDs the snapshots of the data volumes, indexed by the volume ID
+	VolumeSnapshotIDs map[string]string
+}
+
+// SnapshotIDs return the IDs of all snapshots of the group
+func (g *InstanceSnapshotGroup) SnapshotIDs() []string {
+	snapshotIDs := []string{g.InstanceSnapshotID}
+	for _, snapshotID := range g.VolumeSnapshotIDs {
+		snapshotIDs = append(snapshotIDs, snapshotID)
+	}
+	return snapshotIDs
+}
+
+// CreateInstanceSnapshotGroup snapshot the instance with all its attached volumes at once
+// and wait the snapshots available
+func CreateInstanceSnapshotGroup(s *SnapshotGroupServices, instanceID string, name string, full bool, timeout time.Duration, waitInterval time.Duration) (*InstanceSnapshotGroup, error) {
+	instance, err := describeInstance(s.InstanceService, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	resources := []*string{service.String(instanceID)}
+	for _, volumeID := range instance.VolumeIDs {
+		if service.StringValue(volumeID) != "" {
+			resources = append(resources, volumeID)
+		}
+	}
+	isFull := 0
+	if full {
+		isFull = 1
+	}
+	input := &service.CreateSnapshotsInput{
+		Resources: resources,
+		IsFull:    service.Int(isFull),
+	}
+	if name != "" {
+		input.SnapshotName = service.String(name)
+	}
+	output, err := s.SnapshotService.CreateSnapshots(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(output.Snapshots) != len(resources) {
+		return nil, fmt.Errorf("Snapshot instance [%s] response error, %d snapshots for %d resources", instanceID, len(output.Snapshots), len(resources))
+	}
+	err = attachDefaultTags(s.SnapshotService.Config, s.SnapshotService.Properties.Zone, ResourceTypeSnapshot, service.StringValueSlice(output.Snapshots)...)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &InstanceSnapshotGroup{
+		Name:              name,
+		InstanceID:        instanceID,
+		VolumeSnapshotIDs: map[string]string{},
+	}
+	for _, snapshotID := range output.Snapshots {
+		if snapshotID == nil {
+			return nil, fmt.Errorf("Snapshot instance [%s] response error", instanceID)
+		}
+		snapshot, err := WaitSnapshotStatus(s.SnapshotService, *snapshotID, SnapshotStatusAvailable, timeout, waitInterval)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot.Resource == nil || service.StringValue(snapshot.Resource.ResourceID) == "" {
+			return nil, fmt.Errorf("Snapshot [%s] has no resource", *snapshotID)
+		}
+		resourceID := *snapshot.Resource.ResourceID
+		if resourceID == instanceID {
+			group.InstanceSnapshotID = *snapshotID
+		} else {
+			group.VolumeSnapshotIDs[resourceID] = *snapshotID
+		}
+	}
+	if group.InstanceSnapshotID == "" {
+		return nil, fmt.Errorf("Snapshot of the system disk of instance [%s] not found", instanceID)
+	}
+	return group, nil
+}
+
+// RestoredInstance the instance restored from an InstanceSnapshotGroup
+type RestoredInstance struct {
+	InstanceID string
+	// ImageID the image captured from the system disk snapshot
+	ImageID string
+	// VolumeIDs the restored volumes, indexed by the ID of the volumes they are restored from
+	VolumeIDs map[string]string
+}
+
+// RestoreInstanceSnapshotGroup capture an image from the system disk snapshot of the group, run a new instance
+// of it with the input, then create the volumes from the data volume snapshots and attach them to the new instance.
+// The resources created before a failure are returned with the error and are not cleaned up
+func RestoreInstanceSnapshotGroup(s *SnapshotGroupServices, group *InstanceSnapshotGroup, input *service.RunInstancesInput, timeout time.Duration, waitInterval time.Duration) (*RestoredInstance, error) {
+	restored := &RestoredInstance{VolumeIDs: map[string]string{}}
+	imageName := fmt.Sprintf("%s-%s", group.InstanceID, group.InstanceSnapshotID)
+	captureOutput, err := s.SnapshotService.CaptureInstanceFromSnapshot(&service.CaptureInstanceFromSnapshotInput{
+		Snapshot:  service.String(group.InstanceSnapshotID),
+		ImageName: service.String(imageName),
+	})
+	if err != nil {
+		return restored, err
+	}
+	if captureOutput.ImageID == nil {
+		return restored, fmt.Errorf("Capture instance from snapshot [%s] response error", group.InstanceSnapshotID)
+	}
+	restored.ImageID = *captureOutput.ImageID
+	if err = waitOutputJob(s.JobService, captureOutput.JobID, timeout, waitInterval); err != nil {
+		return restored, err
+	}
+
+	instanceInput := *input
+	instanceInput.ImageID = captureOutput.ImageID
+	instanceInput.Count = service.Int(1)
+	runOutput, err := s.InstanceService.RunInstances(&instanceInput)
+	if err != nil {
+		return restored, err
+	}
+	if len(runOutput.Instances) == 0 || runOutput.Instances[0] == nil {
+		return restored, fmt.Errorf("Run instance from image [%s] response error", restored.ImageID)
+	}
+	restored.InstanceID = *runOutput.Instances[0]
+	err = attachDefaultTags(s.InstanceService.Config, s.InstanceService.Properties.Zone, ResourceTypeInstance, restored.InstanceID)
+	if err != nil {
+		return restored, err
+	}
+	if err = waitOutputJob(s.JobService, runOutput.JobID, timeout, waitInterval); err != nil {
+		return restored, err
+	}
+	if _, err = WaitInstanceStatus(s.InstanceService, restored.InstanceID, InstanceStatusRunning, timeout, waitInterval); err != nil {
+		return restored, err
+	}
+
+	volumeIDs := []*string{}
+	for volumeID, snapshotID := range group.VolumeSnapshotIDs {
+		output, err := s.SnapshotService.CreateVolumeFromSnapshot(&service.CreateVolumeFromSnapshotInput{
+			Snapshot: service.String(snapshotID),
+		})
+		if err != nil {
+			return restored, err
+		}
+		if output.VolumeID == nil {
+			return restored, fmt.Errorf("Create volume from snapshot [%s] response error", snapshotID)
+		}
+		restored.VolumeIDs[volumeID] = *output.VolumeID
+		err = attachDefaultTags(s.VolumeService.Config, s.VolumeService.Properties.Zone, ResourceTypeVolume, *output.VolumeID)
+		if err != nil {
+			return restored, err
+		}
+		if err = waitOutputJob(s.JobService, output.JobID, timeout, waitInterval); err != nil {
+			return restored, err
+		}
+		volumeIDs = append(volumeIDs, output.VolumeID)
+	}
+	if len(volumeIDs) == 0 {
+		return restored, nil
+	}
+	logger.Debug("Attaching restored volumes %v to instance [%s]", service.StringValueSlice(volumeIDs), restored.InstanceID)
+	attachOutput, err := s.VolumeService.AttachVolumes(&service.AttachVolumesInput{
+		Instance: service.String(restored.InstanceID),
+		Volumes:  volumeIDs,
+	})
+	if err != nil {
+		return restored, err
+	}
+	return restored, waitOutputJob(s.JobService, attachOutput.JobID, timeout, waitInterval)
+}
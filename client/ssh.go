@@ -0,0 +1,146 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+const (
+	defaultSSHPort        = 22
+	defaultSSHDialTimeout = 5 * time.Second
+	defaultSSHMaxInterval = 30 * time.Second
+)
+
+// SSHReadyOptions configure how WaitUntilInstanceSSHReady probe the instance, zero values use the defaults
+type SSHReadyOptions struct {
+	// Port is the ssh port, default 22
+	Port int
+	// UsePrivateIP probes the private ip even if the instance has an eip
+	UsePrivateIP bool
+	// Timeout is the timeout of the whole waiting, default 180s
+	Timeout time.Duration
+	// WaitInterval is the first interval between probes, it doubles up to MaxInterval, default 10s
+	WaitInterval time.Duration
+	// MaxInterval is the max interval between probes, default 30s
+	MaxInterval time.Duration
+	// DialTimeout is the timeout of each probe, default 5s
+	DialTimeout time.Duration
+}
+
+// InstanceSSHInfo is the connection details of an instance ready for ssh
+type InstanceSSHInfo struct {
+	InstanceID string
+	Host       string
+	Port       int
+	// IsEIP is true if Host is the eip of the instance
+	IsEIP bool
+	// Banner is the ssh version banner the server sent
+	Banner   string
+	Instance *service.Instance
+}
+
+// Address returns the host:port to connect
+func (i *InstanceSSHInfo) Address() string {
+	return net.JoinHostPort(i.Host, strconv.Itoa(i.Port))
+}
+
+// WaitUntilInstanceSSHReady wait the instance running, resolve its eip or private ip,
+// and probe the ssh port with backoff until the ssh server answers
+func WaitUntilInstanceSSHReady(instanceService *service.InstanceService, instanceID string, opts *SSHReadyOptions) (*InstanceSSHInfo, error) {
+	o := SSHReadyOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Port == 0 {
+		o.Port = defaultSSHPort
+	}
+	if o.Timeout == 0 {
+		o.Timeout = defaultOpTimeout
+	}
+	if o.WaitInterval == 0 {
+		o.WaitInterval = defaultWaitInterval
+	}
+	if o.MaxInterval == 0 {
+		o.MaxInterval = defaultSSHMaxInterval
+	}
+	if o.DialTimeout == 0 {
+		o.DialTimeout = defaultSSHDialTimeout
+	}
+	clock := utils.DefaultClock
+	deadline := clock.Now().Add(o.Timeout)
+
+	instance, err := WaitInstanceStatus(instanceService, instanceID, InstanceStatusRunning, o.Timeout, o.WaitInterval)
+	if err != nil {
+		return nil, err
+	}
+	info := &InstanceSSHInfo{InstanceID: instanceID, Port: o.Port}
+	info.Host, info.IsEIP = instanceSSHHost(instance, o.UsePrivateIP)
+	if info.Host == "" {
+		instance, err = WaitInstanceNetwork(instanceService, instanceID, deadline.Sub(clock.Now()), o.WaitInterval)
+		if err != nil {
+			return nil, err
+		}
+		info.Host, info.IsEIP = instanceSSHHost(instance, o.UsePrivateIP)
+	}
+	info.Instance = instance
+
+	logger.Debug("Waiting for ssh of Instance [%s] at [%s]", instanceID, info.Address())
+	interval := o.WaitInterval
+	for {
+		banner, err := probeSSH(info.Address(), o.DialTimeout)
+		if err == nil {
+			info.Banner = banner
+			logger.Debug("Instance [%s] ssh is ready at [%s]", instanceID, info.Address())
+			return info, nil
+		}
+		logger.Debug("Probe ssh of Instance [%s] at [%s] error : [%s]", instanceID, info.Address(), err.Error())
+		if clock.Now().Add(interval).After(deadline) {
+			return nil, utils.NewTimeoutError(o.Timeout)
+		}
+		clock.Sleep(interval)
+		interval *= 2
+		if interval > o.MaxInterval {
+			interval = o.MaxInterval
+		}
+	}
+}
+
+func instanceSSHHost(instance *service.Instance, usePrivateIP bool) (string, bool) {
+	if !usePrivateIP && instance.EIP != nil && service.StringValue(instance.EIP.EIPAddr) != "" {
+		return *instance.EIP.EIPAddr, true
+	}
+	if len(instance.VxNets) > 0 && service.StringValue(instance.VxNets[0].PrivateIP) != "" {
+		return *instance.VxNets[0].PrivateIP, false
+	}
+	return "", false
+}
+
+// probeSSH connect the address and read the ssh version banner
+func probeSSH(address string, timeout time.Duration) (string, error) {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	reader := bufio.NewReader(conn)
+	// the server may send other lines before the version banner
+	for i := 0; i < 10; i++ {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, "SSH-") {
+			return line, nil
+		}
+	}
+	return "", fmt.Errorf("ssh banner not received from [%s]", address)
+}
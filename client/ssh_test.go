@@ -0,0 +1,33 @@
+package client
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+func TestWaitUntilInstanceSSHReadyTimeout(t *testing.T) {
+	clock := utils.NewFakeClock(time.Now())
+	defer utils.SetDefaultClock(clock)()
+	api, qcService := newFakeAPI(t)
+	defer api.Close()
+	api.respond("DescribeInstances", `{"action":"DescribeInstancesResponse","ret_code":0,"total_count":1,"instance_set":[
+		{"instance_id":"i-1","status":"running","vxnets":[{"vxnet_id":"vxnet-1","private_ip":"127.0.0.1"}]}]}`)
+	instanceService, _ := qcService.Instance("pek3a")
+	// a port nobody listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	start := time.Now()
+	_, err = WaitUntilInstanceSSHReady(instanceService, "i-1", &SSHReadyOptions{Port: port, Timeout: time.Minute})
+	assert.NotNil(t, err)
+	_, ok := err.(*utils.TimeoutError)
+	assert.True(t, ok)
+	assert.True(t, clock.Slept() >= 30*time.Second)
+	assert.True(t, time.Since(start) < 30*time.Second)
+}
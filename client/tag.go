@@ -0,0 +1,175 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+const (
+	//ResourceTypeInstance instance
+	ResourceTypeInstance = "instance"
+	//ResourceTypeVolume volume
+	ResourceTypeVolume = "volume"
+	//ResourceTypeEIP eip
+	ResourceTypeEIP = "eip"
+	//ResourceTypeVxNet vxnet
+	ResourceTypeVxNet = "vxnet"
+	//ResourceTypeRouter router
+	ResourceTypeRouter = "router"
+	//ResourceTypeSecurityGroup security_group
+	ResourceTypeSecurityGroup = "security_group"
+	//ResourceTypeLoadBalancer loadbalancer
+	ResourceTypeLoadBalancer = "loadbalancer"
+	//ResourceTypeNIC nic
+	ResourceTypeNIC = "nic"
+	//ResourceTypeKeyPair keypair
+	ResourceTypeKeyPair = "keypair"
+	//ResourceTypeSnapshot snapshot
+	ResourceTypeSnapshot = "snapshot"
+	//ResourceTypeCluster cluster
+	ResourceTypeCluster = "cluster"
+
+	attachTagsRetries = 3
+)
+
+// AttachTags attach the tags with these tagIDs to all resources with these resourceIDs,
+// the attach request will be retried before giving up
+func AttachTags(tagService *service.TagService, resourceType string, resourceIDs []string, tagIDs []string) error {
+	if len(resourceIDs) == 0 || len(tagIDs) == 0 {
+		return nil
+	}
+	pairs := make([]*service.ResourceTagPair, 0, len(resourceIDs)*len(tagIDs))
+	for _, resourceID := range resourceIDs {
+		for _, tagID := range tagIDs {
+			pairs = append(pairs, &service.ResourceTagPair{
+				ResourceID:   service.String(resourceID),
+				ResourceType: service.String(resourceType),
+				TagID:        service.String(tagID),
+			})
+		}
+	}
+	input := &service.AttachTagsInput{ResourceTagPairs: pairs}
+
+	var err error
+	for i := 0; i < attachTagsRetries; i++ {
+		var output *service.AttachTagsOutput
+		output, err = tagService.AttachTags(input)
+		if err == nil && output.RetCode != nil && *output.RetCode == 0 {
+			return nil
+		}
+		if err == nil {
+			err = fmt.Errorf("Attach tags %v to %s %v failed", tagIDs, resourceType, resourceIDs)
+		}
+		logger.Warn("AttachTags to %s %v error : [%s]", resourceType, resourceIDs, err.Error())
+		utils.DefaultClock.Sleep(time.Second)
+	}
+	return err
+}
+
+// MissingTags return the tagIDs which are not attached in tags
+func MissingTags(tags []*service.Tag, tagIDs []string) []string {
+	attached := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if tag != nil && tag.TagID != nil {
+			attached[*tag.TagID] = true
+		}
+	}
+	missing := []string{}
+	for _, tagID := range tagIDs {
+		if !attached[tagID] {
+			missing = append(missing, tagID)
+		}
+	}
+	return missing
+}
+
+func mergeTags(tagIDs []string, more []string) []string {
+	merged := append([]string{}, tagIDs...)
+	for _, tagID := range more {
+		found := false
+		for _, existing := range merged {
+			if existing == tagID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, tagID)
+		}
+	}
+	return merged
+}
+
+// DefaultTagIDs return the IDs of the default tags of the resource type in the config,
+// the tags configured by name are created if they do not exist
+func DefaultTagIDs(tagService *service.TagService, resourceType string) ([]string, error) {
+	tags := tagService.Config.DefaultTagsOf(resourceType)
+	tagIDs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, "tag-") {
+			tagIDs = append(tagIDs, tag)
+			continue
+		}
+		tagID, err := ensureTag(tagService, tag)
+		if err != nil {
+			return nil, err
+		}
+		tagIDs = append(tagIDs, tagID)
+	}
+	return tagIDs, nil
+}
+
+// AttachDefaultTags attach the default tags of the resource type in the config to the resources
+func AttachDefaultTags(tagService *service.TagService, resourceType string, resourceIDs []string) error {
+	if len(resourceIDs) == 0 {
+		return nil
+	}
+	tagIDs, err := DefaultTagIDs(tagService, resourceType)
+	if err != nil {
+		return err
+	}
+	return AttachTags(tagService, resourceType, resourceIDs, tagIDs)
+}
+
+// attachDefaultTags attach the default tags to the resources created by the service with
+// the config in the zone, it does nothing unless default tags are configured
+func attachDefaultTags(conf *config.Config, zone *string, resourceType string, resourceIDs ...string) error {
+	if len(conf.DefaultTagsOf(resourceType)) == 0 {
+		return nil
+	}
+	tagService := &service.TagService{
+		Config:     conf,
+		Properties: &service.TagServiceProperties{Zone: zone},
+	}
+	return AttachDefaultTags(tagService, resourceType, resourceIDs)
+}
+
+func ensureTag(tagService *service.TagService, name string) (string, error) {
+	output, err := tagService.DescribeTags(&service.DescribeTagsInput{
+		SearchWord: service.String(name),
+		Limit:      service.Int(100),
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, tag := range output.TagSet {
+		if service.StringValue(tag.TagName) == name && tag.TagID != nil {
+			return *tag.TagID, nil
+		}
+	}
+	logger.Info("Creating default tag [%s]", name)
+	createOutput, err := tagService.CreateTag(&service.CreateTagInput{TagName: service.String(name)})
+	if err != nil {
+		return "", err
+	}
+	if createOutput.TagID == nil {
+		return "", fmt.Errorf("Create tag [%s] response error", name)
+	}
+	return *createOutput.TagID, nil
+}
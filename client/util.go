@@ -10,8 +10,12 @@ import (
 
 // WaitJob wait the job with this jobID finish
 func WaitJob(jobService *service.JobService, jobID string, timeout time.Duration, waitInterval time.Duration) error {
+	return waitJobUntil(jobService, jobID, timeout, waitInterval, nil)
+}
+
+func waitJobUntil(jobService *service.JobService, jobID string, timeout time.Duration, waitInterval time.Duration, done <-chan struct{}) error {
 	logger.Debug("Waiting for Job [%s] finished", jobID)
-	return utils.WaitForSpecificOrError(func() (bool, error) {
+	return utils.WaitForSpecificOrErrorUntil(func() (bool, error) {
 		input := &service.DescribeJobsInput{Jobs: []*string{&jobID}}
 		output, err := jobService.DescribeJobs(input)
 		if err != nil {
@@ -37,7 +41,14 @@ func WaitJob(jobService *service.JobService, jobID string, timeout time.Duration
 		}
 		logger.Error("Unknow status [%s] for job [%s]", *j.Status, jobID)
 		return false, nil
-	}, timeout, waitInterval)
+	}, timeout, waitInterval, done)
+}
+
+func waitOutputJob(jobService *service.JobService, jobID *string, timeout time.Duration, waitInterval time.Duration) error {
+	if jobID == nil || *jobID == "" {
+		return fmt.Errorf("Job ID not returned")
+	}
+	return WaitJob(jobService, *jobID, timeout, waitInterval)
 }
 
 // CheckJobStatus get job status
@@ -72,9 +83,13 @@ func describeInstance(instanceService *service.InstanceService, instanceID strin
 
 // WaitInstanceStatus wait the instance with this instanceID to expect status
 func WaitInstanceStatus(instanceService *service.InstanceService, instanceID string, status string, timeout time.Duration, waitInterval time.Duration) (ins *service.Instance, err error) {
+	return waitInstanceStatusUntil(instanceService, instanceID, status, timeout, waitInterval, nil)
+}
+
+func waitInstanceStatusUntil(instanceService *service.InstanceService, instanceID string, status string, timeout time.Duration, waitInterval time.Duration, done <-chan struct{}) (ins *service.Instance, err error) {
 	logger.Debug("Waiting for Instance [%s] status [%s] ", instanceID, status)
 	errorTimes := 0
-	err = utils.WaitForSpecificOrError(func() (bool, error) {
+	err = utils.WaitForSpecificOrErrorUntil(func() (bool, error) {
 		i, err := describeInstance(instanceService, instanceID)
 		if err != nil {
 			logger.Error("DescribeInstance [%s] error : [%s]", instanceID, err.Error())
@@ -94,14 +109,18 @@ func WaitInstanceStatus(instanceService *service.InstanceService, instanceID str
 			return true, nil
 		}
 		return false, nil
-	}, timeout, waitInterval)
+	}, timeout, waitInterval, done)
 	return
 }
 
 // WaitInstanceNetwork wait the instance with this instanceID network become ready
 func WaitInstanceNetwork(instanceService *service.InstanceService, instanceID string, timeout time.Duration, waitInterval time.Duration) (ins *service.Instance, err error) {
+	return waitInstanceNetworkUntil(instanceService, instanceID, timeout, waitInterval, nil)
+}
+
+func waitInstanceNetworkUntil(instanceService *service.InstanceService, instanceID string, timeout time.Duration, waitInterval time.Duration, done <-chan struct{}) (ins *service.Instance, err error) {
 	logger.Debug("Waiting for IP address to be assigned to Instance [%s]", instanceID)
-	err = utils.WaitForSpecificOrError(func() (bool, error) {
+	err = utils.WaitForSpecificOrErrorUntil(func() (bool, error) {
 		i, err := describeInstance(instanceService, instanceID)
 		if err != nil {
 			return false, err
@@ -112,7 +131,7 @@ func WaitInstanceNetwork(instanceService *service.InstanceService, instanceID st
 		ins = i
 		logger.Debug("Instance [%s] get IP address [%s]", instanceID, *ins.VxNets[0].PrivateIP)
 		return true, nil
-	}, timeout, waitInterval)
+	}, timeout, waitInterval, done)
 	return
 }
 
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+// AllocateVIP allocate a VIP in the vxnet with this vxnetID and wait it finished, the address
+// is allocated by the vxnet if addr is empty
+func AllocateVIP(vipService *service.VIPService, jobService *service.JobService, vxnetID string, name string, addr string, timeout time.Duration, waitInterval time.Duration) (*service.VIP, error) {
+	input := &service.CreateVIPsInput{
+		VxNetID: service.String(vxnetID),
+		Count:   service.Int(1),
+	}
+	if name != "" {
+		input.VIPName = service.String(name)
+	}
+	if addr != "" {
+		input.VIPRange = service.String(addr)
+	}
+	output, err := vipService.CreateVIPs(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(output.VIPs) == 0 || output.VIPs[0] == nil {
+		return nil, fmt.Errorf("Allocate VIP in vxnet [%s] response error", vxnetID)
+	}
+	if output.JobID != nil {
+		if err = WaitJob(jobService, *output.JobID, timeout, waitInterval); err != nil {
+			return nil, err
+		}
+	}
+	return describeVIP(vipService, *output.VIPs[0])
+}
+
+// BindVIP bind the VIP with this vipID to the instance with this instanceID, or to the NIC with
+// this nicID if instanceID is empty, and wait it finished. A VIP bound elsewhere is moved, for failover
+func BindVIP(vipService *service.VIPService, jobService *service.JobService, vipID string, instanceID string, nicID string, timeout time.Duration, waitInterval time.Duration) (*service.VIP, error) {
+	if instanceID == "" && nicID == "" {
+		return nil, fmt.Errorf("Neither instance nor NIC to bind VIP [%s] is given", vipID)
+	}
+	vip, err := describeVIP(vipService, vipID)
+	if err != nil {
+		return nil, err
+	}
+	if isVIPBoundTo(vip, instanceID, nicID) {
+		return vip, nil
+	}
+	if service.StringValue(vip.InstanceID) != "" || service.StringValue(vip.NICID) != "" {
+		logger.Debug("Unbinding VIP [%s] from instance [%s]", vipID, service.StringValue(vip.InstanceID))
+		if err = UnbindVIP(vipService, jobService, vipID, timeout, waitInterval); err != nil {
+			return nil, err
+		}
+	}
+
+	input := &service.AssociateVIPInput{VIP: service.String(vipID)}
+	if instanceID != "" {
+		input.Instance = service.String(instanceID)
+	} else {
+		input.NIC = service.String(nicID)
+	}
+	output, err := vipService.AssociateVIP(input)
+	if err != nil {
+		return nil, err
+	}
+	if output.JobID != nil {
+		if err = WaitJob(jobService, *output.JobID, timeout, waitInterval); err != nil {
+			return nil, err
+		}
+	}
+	vip, err = describeVIP(vipService, vipID)
+	if err != nil {
+		return nil, err
+	}
+	if !isVIPBoundTo(vip, instanceID, nicID) {
+		return vip, fmt.Errorf("VIP [%s] is not bound to [%s%s]", vipID, instanceID, nicID)
+	}
+	return vip, nil
+}
+
+// UnbindVIP unbind the VIP with this vipID from its instance and wait it finished
+func UnbindVIP(vipService *service.VIPService, jobService *service.JobService, vipID string, timeout time.Duration, waitInterval time.Duration) error {
+	output, err := vipService.DissociateVIPs(&service.DissociateVIPsInput{
+		VIPs: []*string{service.String(vipID)},
+	})
+	if err != nil {
+		return err
+	}
+	if output.JobID != nil {
+		return WaitJob(jobService, *output.JobID, timeout, waitInterval)
+	}
+	return nil
+}
+
+func isVIPBoundTo(vip *service.VIP, instanceID string, nicID string) bool {
+	if instanceID != "" {
+		return service.StringValue(vip.InstanceID) == instanceID
+	}
+	return service.StringValue(vip.NICID) == nicID
+}
+
+func describeVIP(vipService *service.VIPService, vipID string) (*service.VIP, error) {
+	output, err := vipService.DescribeVIPs(&service.DescribeVIPsInput{
+		VIPs: []*string{service.String(vipID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.VIPSet) == 0 {
+		return nil, fmt.Errorf("VIP with id [%s] not exist", vipID)
+	}
+	return output.VIPSet[0], nil
+}
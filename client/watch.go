@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+// InstanceStatusEvent is a status transition of an instance observed by WatchInstanceStatus
+type InstanceStatusEvent struct {
+	InstanceID string
+	// From and FromTransition are empty for the first observation of the instance
+	From             string
+	FromTransition   string
+	Status           string
+	TransitionStatus string
+	Time             time.Time
+	// Terminal is true once the instance stays in a stable status without transition
+	Terminal bool
+	// Err is set if the instance can not be watched anymore, the watching stops after it
+	Err error
+}
+
+type instanceState struct {
+	status           string
+	transitionStatus string
+	terminal         bool
+}
+
+// IsInstanceStatusTerminal checks whether the instance stays in the status without transition
+func IsInstanceStatusTerminal(status string, transitionStatus string) bool {
+	return transitionStatus == "" && status != "" && status != InstanceStatusPending
+}
+
+// WatchInstanceStatus poll the status of the instances every waitInterval, and send an event on the returned channel
+// for each status or transition status change, until all instances reach a terminal status or ctx is done.
+// The channel is closed when the watching stops
+func WatchInstanceStatus(ctx context.Context, instanceService *service.InstanceService, instanceIDs []string, waitInterval time.Duration) <-chan *InstanceStatusEvent {
+	events := make(chan *InstanceStatusEvent, len(instanceIDs))
+	go func() {
+		defer close(events)
+		states := map[string]*instanceState{}
+		errorTimes := 0
+		for {
+			instances, err := describeInstances(instanceService, pendingInstances(instanceIDs, states))
+			if err != nil {
+				logger.Error("DescribeInstances %v error : [%s]", instanceIDs, err.Error())
+				errorTimes++
+				if errorTimes > 3 {
+					sendInstanceEvent(ctx, events, &InstanceStatusEvent{Time: utils.DefaultClock.Now(), Err: err})
+					return
+				}
+			} else {
+				errorTimes = 0
+				for _, instanceID := range pendingInstances(instanceIDs, states) {
+					event := observeInstance(states, instanceID, instances[instanceID])
+					if event != nil && !sendInstanceEvent(ctx, events, event) {
+						return
+					}
+				}
+				if len(pendingInstances(instanceIDs, states)) == 0 {
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-utils.DefaultClock.After(waitInterval):
+			}
+		}
+	}()
+	return events
+}
+
+func sendInstanceEvent(ctx context.Context, events chan<- *InstanceStatusEvent, event *InstanceStatusEvent) bool {
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func observeInstance(states map[string]*instanceState, instanceID string, instance *service.Instance) *InstanceStatusEvent {
+	previous, ok := states[instanceID]
+	if !ok {
+		previous = &instanceState{}
+	}
+	event := &InstanceStatusEvent{
+		InstanceID:     instanceID,
+		From:           previous.status,
+		FromTransition: previous.transitionStatus,
+		Time:           utils.DefaultClock.Now(),
+	}
+	if instance == nil {
+		states[instanceID] = &instanceState{terminal: true}
+		event.Terminal = true
+		event.Err = fmt.Errorf("Instance with id [%s] not exist", instanceID)
+		return event
+	}
+
+	current := &instanceState{
+		status:           service.StringValue(instance.Status),
+		transitionStatus: service.StringValue(instance.TransitionStatus),
+	}
+	current.terminal = IsInstanceStatusTerminal(current.status, current.transitionStatus)
+	states[instanceID] = current
+	if ok && current.status == previous.status && current.transitionStatus == previous.transitionStatus {
+		return nil
+	}
+	event.Status = current.status
+	event.TransitionStatus = current.transitionStatus
+	event.Terminal = current.terminal
+	return event
+}
+
+func pendingInstances(instanceIDs []string, states map[string]*instanceState) []string {
+	pending := []string{}
+	for _, instanceID := range instanceIDs {
+		if state, ok := states[instanceID]; !ok || !state.terminal {
+			pending = append(pending, instanceID)
+		}
+	}
+	return pending
+}
+
+func describeInstances(instanceService *service.InstanceService, instanceIDs []string) (map[string]*service.Instance, error) {
+	instances := map[string]*service.Instance{}
+	limit := 100
+	for start := 0; start < len(instanceIDs); start += limit {
+		end := start + limit
+		if end > len(instanceIDs) {
+			end = len(instanceIDs)
+		}
+		output, err := instanceService.DescribeInstances(&service.DescribeInstancesInput{
+			Instances: service.StringSlice(instanceIDs[start:end]),
+			Limit:     service.Int(limit),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, instance := range output.InstanceSet {
+			instances[service.StringValue(instance.InstanceID)] = instance
+		}
+	}
+	return instances, nil
+}
@@ -0,0 +1,56 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package config
+
+import (
+	"time"
+)
+
+// An AuditRecord records a mutating API call made by the SDK.
+type AuditRecord struct {
+	Time     time.Time
+	Duration time.Duration
+	Action   string
+	Zone     string
+	// Params are the request parameters, the values of the secret ones are masked.
+	Params map[string]string
+	// JobID is the ID of the job started by the call, if any.
+	JobID   string
+	RetCode int
+	Message string
+	// Error is the error of the call, empty if it succeeded.
+	Error string
+}
+
+// Succeeded checks whether the call succeeded.
+func (r *AuditRecord) Succeeded() bool {
+	return r.Error == ""
+}
+
+// An AuditSink receives the AuditRecord of every mutating API call, it is
+// called synchronously after the call and should not block.
+type AuditSink interface {
+	Record(record *AuditRecord)
+}
+
+// AuditSinkFunc is an adapter to use a function as AuditSink.
+type AuditSinkFunc func(record *AuditRecord)
+
+// Record calls f(record).
+func (f AuditSinkFunc) Record(record *AuditRecord) {
+	f(record)
+}
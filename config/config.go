@@ -17,6 +17,7 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -25,7 +26,6 @@ import (
 	"os"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/yunify/qingcloud-sdk-go/logger"
 	"github.com/yunify/qingcloud-sdk-go/utils"
@@ -42,20 +42,74 @@ type Config struct {
 	URI               string `yaml:"uri"`
 	ConnectionRetries int    `yaml:"connection_retries"`
 	ConnectionTimeout int    `yaml:"connection_timeout"`
+	// IPVersion forces dialing the "ipv4" or "ipv6" addresses of the host, empty dials both.
+	IPVersion string `yaml:"ip_version"`
+	// DisableCompression stops requesting gzip compressed responses, it is applied to the Connection
+	// created by New, NewWithEndpoint or by loading the configuration.
+	DisableCompression bool `yaml:"disable_compression"`
+	// ProxyFromEnvironment sends the requests through the proxy of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	// environment variables, it is applied like DisableCompression.
+	ProxyFromEnvironment bool `yaml:"proxy_from_environment"`
+	// MaxResponseSize is the size limit of responses in bytes, 0 uses the default limit and negative disables it.
+	MaxResponseSize int64 `yaml:"max_response_size"`
+	// Timeouts are the default request timeouts of each action class.
+	Timeouts Timeouts `yaml:"timeouts"`
+	// TransientConflictTimeout is the seconds the client helpers wait for a busy resource to leave its
+	// transition state to retry the operation failed with ErrTransientConflict, 0 disables retrying.
+	TransientConflictTimeout int `yaml:"transient_conflict_timeout"`
+	// RequestPolicies override the retries and rate limit of the requests, keyed by the action like "UpdateLoadBalancers",
+	// the service like "LoadBalancer", or the action class "describe", "mutate" or "long_running", in this order.
+	RequestPolicies map[string]*RequestPolicy `yaml:"request_policies"`
 
 	LogLevel string `yaml:"log_level"`
 
 	Zone string `yaml:"zone"`
+	// ValidateZone checks the zone of requests against the zones the account can access.
+	ValidateZone bool `yaml:"validate_zone"`
+	// DeduplicateRequests collapses the identical concurrent Describe requests into one API call sharing its response.
+	DeduplicateRequests bool `yaml:"deduplicate_requests"`
+	// DefaultTags are the tags attached to every resource created by the client helpers,
+	// by tag ID or by tag name which is created if it does not exist.
+	DefaultTags []string `yaml:"default_tags"`
+	// ResourceDefaultTags are the tags attached to the resources of the type in addition to DefaultTags.
+	ResourceDefaultTags map[string][]string `yaml:"resource_default_tags"`
+	// UseJSONNumber decodes the numbers of untyped response fields, like monitor data, as json.Number
+	// instead of float64, so large integers keep their precision.
+	UseJSONNumber bool `yaml:"use_json_number"`
+	// StrictDecoding fails the request if a response field does not fit the output, instead of skipping it.
+	StrictDecoding bool `yaml:"strict_decoding"`
+	// AuditSink records every mutating API call, nil disables auditing.
+	AuditSink AuditSink `yaml:"-"`
+	// MirrorEndpoint receives a copy of the Describe requests, e.g. a new platform version before cutover,
+	// and the responses are compared by MirrorHook. Empty disables mirroring.
+	MirrorEndpoint string `yaml:"mirror_endpoint"`
+	// MirrorHook reports the result of every mirrored request, nil disables mirroring.
+	MirrorHook MirrorHook `yaml:"-"`
+	// MirrorConcurrency is the maximum number of mirrored requests running at the same time,
+	// the requests are not mirrored while it is reached. 0 uses DefaultMirrorConcurrency.
+	MirrorConcurrency int `yaml:"mirror_concurrency"`
+	// ContextResolvers resolve the Config of the requests in WithContext, e.g. by the tenant in the context.
+	ContextResolvers []ContextResolver `yaml:"-"`
 
 	CredentialProxyProtocol string `yaml:"credential_proxy_protocol"`
 	CredentialProxyHost     string `yaml:"credential_proxy_host"`
 	CredentialProxyPort     int    `yaml:"credential_proxy_port"`
 	CredentialProxyURI      string `yaml:"credential_proxy_uri"`
+	// TokenCacheDir shares the tokens of the credential proxy between processes by a FileTokenCache in the directory.
+	TokenCacheDir string `yaml:"token_cache_dir"`
+	// TokenCache caches the tokens of the credential proxy, nil uses DefaultTokenCache.
+	TokenCache TokenCache `yaml:"-"`
 
 	Token      string
 	Expiration int64
 
 	Connection *http.Client
+	// Context is the context of the requests set by WithContext, nil means context.Background().
+	Context context.Context `yaml:"-"`
+	// Clock times the retries and token refresh of the requests, nil uses utils.DefaultClock.
+	Clock utils.Clock `yaml:"-"`
+
+	mirrors *mirrorWorkers
 }
 
 // New create a Config with given AccessKeyID and SecretAccessKey.
@@ -68,72 +122,94 @@ func New(accessKeyID, secretAccessKey string) (*Config, error) {
 	config.AccessKeyID = accessKeyID
 	config.SecretAccessKey = secretAccessKey
 
-	config.Connection = &http.Client{}
+	config.Connection = &http.Client{
+		Transport: config.newTransport(),
+	}
 
 	return config, nil
 }
 
 // NewWithEndpoint create a Config with given AccessKeyID, SecretAccessKey and endpoint
 func NewWithEndpoint(accessKeyID, secretAccessKey, endpoint string) (*Config, error) {
-	qcURL, err := url.Parse(endpoint)
+	config, err := NewDefault()
 	if err != nil {
 		return nil, err
 	}
+
+	err = config.setEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	config.AccessKeyID = accessKeyID
+	config.SecretAccessKey = secretAccessKey
+	config.Connection = &http.Client{
+		Transport: config.newTransport(),
+	}
+	return config, nil
+}
+
+// setEndpoint sets the protocol, host, port and URI of the Config from the endpoint URL.
+func (c *Config) setEndpoint(endpoint string) error {
+	qcURL, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
 	if qcURL.Opaque != "" {
-		return nil, fmt.Errorf("wrong URL format")
+		return fmt.Errorf("wrong URL format")
 	}
 
-	if !strings.Contains(qcURL.Host, ":") {
+	if qcURL.Port() == "" {
 		if qcURL.Scheme == "https" {
 			qcURL.Host += ":443"
 		} else if qcURL.Scheme == "http" {
 			qcURL.Host += ":80"
 		} else {
-			return nil, fmt.Errorf("can not find default port ")
+			return fmt.Errorf("can not find default port ")
 		}
 	}
 
-	config, err := NewDefault()
-	if err != nil {
-		return nil, err
-	}
-
 	host, port, err := net.SplitHostPort(qcURL.Host)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	config.Port, _ = strconv.Atoi(port)
-	config.Host = host
-	config.AccessKeyID = accessKeyID
-	config.SecretAccessKey = secretAccessKey
-	config.Protocol = qcURL.Scheme
-	config.URI = qcURL.Path
-	config.Connection = &http.Client{}
-	return config, nil
+	c.Port, _ = strconv.Atoi(port)
+	c.Host = host
+	c.Protocol = qcURL.Scheme
+	c.URI = qcURL.Path
+	return nil
 }
 
 // NewDefault create a Config with default configuration.
 func NewDefault() (*Config, error) {
-	config := &Config{}
+	config := &Config{mirrors: newMirrorWorkers()}
 	err := config.LoadDefaultConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	timeout := time.Duration(config.ConnectionTimeout) * time.Second
-	transport := &http.Transport{
-		Dial: func(network, addr string) (net.Conn, error) {
-			return net.DialTimeout(network, addr, timeout)
-		},
-	}
 	config.Connection = &http.Client{
-		Transport: transport,
+		Transport: config.newTransport(),
 	}
 
 	return config, nil
 }
 
+// GetClock returns the Clock of the requests.
+func (c *Config) GetClock() utils.Clock {
+	if c.Clock != nil {
+		return c.Clock
+	}
+	return utils.DefaultClock
+}
+
+// CloseIdleConnections closes the idle connections of the Connection.
+func (c *Config) CloseIdleConnections() {
+	if c.Connection != nil {
+		c.Connection.CloseIdleConnections()
+	}
+}
+
 // LoadDefaultConfig loads the default configuration for Config.
 // It returns error if yaml decode failed.
 func (c *Config) LoadDefaultConfig() error {
@@ -189,14 +265,18 @@ func (c *Config) LoadConfigFromContent(content []byte) error {
 
 	logger.SetLevel(c.LogLevel)
 
-	timeout := time.Duration(c.ConnectionTimeout) * time.Second
-	transport := &http.Transport{
-		Dial: func(network, addr string) (net.Conn, error) {
-			return net.DialTimeout(network, addr, timeout)
-		},
+	err = c.validateIPVersion()
+	if err != nil {
+		logger.Error("Config parse error: " + err.Error())
+		return err
 	}
+
+	if c.TokenCacheDir != "" {
+		c.TokenCache = &FileTokenCache{Dir: c.TokenCacheDir}
+	}
+
 	c.Connection = &http.Client{
-		Transport: transport,
+		Transport: c.newTransport(),
 	}
 
 	return nil
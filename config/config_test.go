@@ -17,7 +17,9 @@
 package config
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/yunify/qingcloud-sdk-go/logger"
@@ -117,4 +119,87 @@ func TestNewWithEndpoint(t *testing.T) {
 	assert.Equal(t, 444, config.Port)
 	assert.Equal(t, "/iaas", config.URI)
 
+	config, err = NewWithEndpoint("AccessKeyID", "SecretAccessKey", "https://[fd00::10]/iaas")
+	assert.Nil(t, err)
+	assert.Equal(t, "fd00::10", config.Host)
+	assert.Equal(t, 443, config.Port)
+
+	config, err = NewWithEndpoint("AccessKeyID", "SecretAccessKey", "http://[fd00::10]:8080/iaas")
+	assert.Nil(t, err)
+	assert.Equal(t, "fd00::10", config.Host)
+	assert.Equal(t, 8080, config.Port)
+}
+
+func TestConfig_IPVersion(t *testing.T) {
+	config, err := NewDefault()
+	assert.Nil(t, err)
+	assert.Equal(t, "tcp", config.dialNetwork("tcp"))
+
+	assert.Nil(t, config.LoadConfigFromContent([]byte("ip_version: 'ipv6'")))
+	assert.Equal(t, IPVersion6, config.IPVersion)
+	assert.Equal(t, "tcp6", config.dialNetwork("tcp"))
+
+	config.IPVersion = IPVersion4
+	assert.Equal(t, "tcp4", config.dialNetwork("tcp"))
+
+	assert.NotNil(t, config.LoadConfigFromContent([]byte("ip_version: '5'")))
+}
+
+func TestConfig_ProxyFromEnvironment(t *testing.T) {
+	config, err := NewDefault()
+	assert.Nil(t, err)
+	assert.Nil(t, config.newTransport().Proxy)
+
+	assert.Nil(t, config.LoadConfigFromContent([]byte("proxy_from_environment: true")))
+	assert.True(t, config.ProxyFromEnvironment)
+	assert.NotNil(t, config.Connection.Transport.(*http.Transport).Proxy)
+}
+
+func TestConfig_ActionTimeout(t *testing.T) {
+	config := Config{}
+	assert.Equal(t, time.Duration(0), config.ActionTimeout("DescribeInstances"))
+
+	err := config.LoadConfigFromContent([]byte(`
+timeouts:
+  describe: 10
+  mutate: 60
+  long_running: 1800
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, 10*time.Second, config.ActionTimeout("DescribeInstances"))
+	assert.Equal(t, 10*time.Second, config.ActionTimeout("GetMonitor"))
+	assert.Equal(t, 60*time.Second, config.ActionTimeout("RunInstances"))
+	assert.Equal(t, 60*time.Second, config.ActionTimeout("DeleteVolumes"))
+	assert.Equal(t, 1800*time.Second, config.ActionTimeout("CaptureInstance"))
+}
+
+func TestConfig_WithCredential(t *testing.T) {
+	config, err := New("AccessKeyID", "SecretAccessKey")
+	assert.Nil(t, err)
+	config.URI = "/iam"
+	config.Token = "Token"
+
+	delegated := config.WithCredential("TenantAccessKeyID", "TenantSecretAccessKey")
+	assert.Equal(t, "TenantAccessKeyID", delegated.AccessKeyID)
+	assert.Equal(t, "TenantSecretAccessKey", delegated.SecretAccessKey)
+	assert.Equal(t, "", delegated.Token)
+	assert.Equal(t, "/iaas", delegated.URI)
+	assert.True(t, config.Connection == delegated.Connection)
+	assert.Equal(t, "AccessKeyID", config.AccessKeyID)
+	assert.Equal(t, "Token", config.Token)
+}
+
+func TestConfig_DefaultTagsOf(t *testing.T) {
+	config, err := NewDefault()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{}, config.DefaultTagsOf("instance"))
+
+	err = config.LoadConfigFromContent([]byte(`
+default_tags: ['tag-prod', 'owner=team-x']
+resource_default_tags:
+  volume: ['tag-backup', 'tag-prod']
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tag-prod", "owner=team-x"}, config.DefaultTagsOf("instance"))
+	assert.Equal(t, []string{"tag-prod", "owner=team-x", "tag-backup"}, config.DefaultTagsOf("volume"))
 }
@@ -0,0 +1,89 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package config
+
+import (
+	"context"
+)
+
+type contextKey int
+
+const (
+	zoneContextKey contextKey = iota
+	credentialContextKey
+)
+
+type contextCredential struct {
+	accessKeyID     string
+	secretAccessKey string
+}
+
+// A ContextResolver resolves the Config of the requests with the context, e.g.
+// returns c.WithCredential with the access key of the tenant in the context.
+// It should copy c instead of modifying it.
+type ContextResolver func(ctx context.Context, c *Config) (*Config, error)
+
+// ContextWithZone returns a copy of ctx with the zone, which is the default zone
+// of the requests with the Config resolved by WithContext.
+func ContextWithZone(ctx context.Context, zone string) context.Context {
+	return context.WithValue(ctx, zoneContextKey, zone)
+}
+
+// ZoneFromContext returns the zone in ctx set by ContextWithZone.
+func ZoneFromContext(ctx context.Context) (string, bool) {
+	zone, ok := ctx.Value(zoneContextKey).(string)
+	return zone, ok
+}
+
+// ContextWithCredential returns a copy of ctx with the access key, which signs
+// the requests with the Config resolved by WithContext.
+func ContextWithCredential(ctx context.Context, accessKeyID, secretAccessKey string) context.Context {
+	return context.WithValue(ctx, credentialContextKey, contextCredential{
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+	})
+}
+
+// CredentialFromContext returns the access key in ctx set by ContextWithCredential.
+func CredentialFromContext(ctx context.Context) (string, string, bool) {
+	credential, ok := ctx.Value(credentialContextKey).(contextCredential)
+	return credential.accessKeyID, credential.secretAccessKey, ok
+}
+
+// WithContext returns a copy of the Config for the requests made in ctx, with
+// the zone and access key in ctx, then resolved by the ContextResolvers in order.
+// The requests with the copy are canceled with ctx. The copy shares the
+// Connection, so it is cheap to create one for each request of a multi-tenant server.
+func (c *Config) WithContext(ctx context.Context) (*Config, error) {
+	resolved := *c
+	resolvedConfig := &resolved
+	if zone, ok := ZoneFromContext(ctx); ok {
+		resolvedConfig.Zone = zone
+	}
+	if accessKeyID, secretAccessKey, ok := CredentialFromContext(ctx); ok {
+		resolvedConfig = resolvedConfig.WithCredential(accessKeyID, secretAccessKey)
+	}
+	for _, resolver := range c.ContextResolvers {
+		var err error
+		resolvedConfig, err = resolver(ctx, resolvedConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	resolvedConfig.Context = ctx
+	return resolvedConfig, nil
+}
@@ -0,0 +1,65 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tenantContextKey struct{}
+
+func TestConfigWithContext(t *testing.T) {
+	config, err := New("AccessKeyID", "SecretAccessKey")
+	assert.Nil(t, err)
+	config.Zone = "pek3a"
+
+	resolved, err := config.WithContext(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "pek3a", resolved.Zone)
+	assert.Equal(t, "AccessKeyID", resolved.AccessKeyID)
+
+	ctx := ContextWithCredential(ContextWithZone(context.Background(), "sh1a"), "TenantAccessKeyID", "TenantSecretAccessKey")
+	resolved, err = config.WithContext(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "sh1a", resolved.Zone)
+	assert.Equal(t, "TenantAccessKeyID", resolved.AccessKeyID)
+	assert.Equal(t, "TenantSecretAccessKey", resolved.SecretAccessKey)
+	assert.Equal(t, ctx, resolved.Context)
+	assert.Equal(t, config.Connection, resolved.Connection)
+	assert.Equal(t, "pek3a", config.Zone)
+	assert.Equal(t, "AccessKeyID", config.AccessKeyID)
+	assert.Nil(t, config.Context)
+
+	config.ContextResolvers = []ContextResolver{func(ctx context.Context, c *Config) (*Config, error) {
+		tenant, ok := ctx.Value(tenantContextKey{}).(string)
+		if !ok {
+			return nil, fmt.Errorf("tenant not found")
+		}
+		return c.WithCredential(tenant+"AccessKeyID", tenant+"SecretAccessKey"), nil
+	}}
+	resolved, err = config.WithContext(context.WithValue(ctx, tenantContextKey{}, "Tenant2"))
+	assert.Nil(t, err)
+	assert.Equal(t, "sh1a", resolved.Zone)
+	assert.Equal(t, "Tenant2AccessKeyID", resolved.AccessKeyID)
+
+	_, err = config.WithContext(context.Background())
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,33 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package config
+
+// WithCredential returns a copy of the Config which signs the requests with
+// the access key instead, e.g. the key delegated by a tenant. The copy shares
+// the Connection, so it is cheap to create one for a single request.
+func (c *Config) WithCredential(accessKeyID, secretAccessKey string) *Config {
+	delegated := *c
+	delegated.AccessKeyID = accessKeyID
+	delegated.SecretAccessKey = secretAccessKey
+	// the token of the credential proxy belongs to the original access key
+	delegated.Token = ""
+	delegated.Expiration = 0
+	if delegated.URI == "/iam" {
+		delegated.URI = "/iaas"
+	}
+	return &delegated
+}
@@ -0,0 +1,76 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	//IPVersionDualStack dials both the IPv4 and IPv6 addresses of the endpoint
+	IPVersionDualStack = ""
+	//IPVersion4 dials the IPv4 addresses of the endpoint only
+	IPVersion4 = "ipv4"
+	//IPVersion6 dials the IPv6 addresses of the endpoint only
+	IPVersion6 = "ipv6"
+)
+
+// dialNetwork returns the network to dial by the IPVersion.
+func (c *Config) dialNetwork(network string) string {
+	switch c.IPVersion {
+	case IPVersion4:
+		return network + "4"
+	case IPVersion6:
+		return network + "6"
+	}
+	return network
+}
+
+// validateIPVersion checks the IPVersion is one of the supported values.
+func (c *Config) validateIPVersion() error {
+	switch c.IPVersion {
+	case IPVersionDualStack, IPVersion4, IPVersion6:
+		return nil
+	}
+	return fmt.Errorf("ip_version [%s] is not one of [%s, %s]", c.IPVersion, IPVersion4, IPVersion6)
+}
+
+// newTransport creates the transport of the Connection. The dialer races the
+// IPv4 and IPv6 addresses of dual-stack endpoints (RFC 6555), and only dials
+// the addresses of the IPVersion once it is set. The transport requests gzip
+// compressed responses and decompresses them, unless DisableCompression is set.
+// The requests go through the proxy of the environment only if ProxyFromEnvironment is set.
+func (c *Config) newTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   time.Duration(c.ConnectionTimeout) * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	transport := &http.Transport{
+		DisableCompression: c.DisableCompression,
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialer.DialContext(ctx, c.dialNetwork(network), addr)
+		},
+	}
+	if c.ProxyFromEnvironment {
+		transport.Proxy = http.ProxyFromEnvironment
+	}
+	return transport
+}
@@ -0,0 +1,146 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package config
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultMirrorConcurrency is the number of mirrored requests running at the same time if MirrorConcurrency is not set.
+const DefaultMirrorConcurrency = 4
+
+// A MirrorDiff is the result of comparing the response of a request with the one of its mirror.
+type MirrorDiff struct {
+	Time     time.Time
+	Action   string
+	Endpoint string
+	// Differences are the paths of the response fields which differ, like "instance_set[0].status".
+	Differences []string
+	// Primary and Mirror are the responses in JSON.
+	Primary []byte
+	Mirror  []byte
+	// Error is the error of the mirrored request if it got no response.
+	Error string
+}
+
+// Matched checks whether the mirror responded the same as the primary endpoint.
+func (d *MirrorDiff) Matched() bool {
+	return d.Error == "" && len(d.Differences) == 0
+}
+
+// A MirrorHook receives the MirrorDiff of every mirrored request, it is called
+// in the background and should be safe to call concurrently.
+type MirrorHook func(diff *MirrorDiff)
+
+// MirrorConfig returns a copy of the Config sending the requests to the MirrorEndpoint,
+// without mirroring, auditing, deduplication and zone validation.
+func (c *Config) MirrorConfig() (*Config, error) {
+	mirror := *c
+	err := mirror.setEndpoint(c.MirrorEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	mirror.MirrorEndpoint = ""
+	mirror.MirrorHook = nil
+	mirror.mirrors = nil
+	mirror.AuditSink = nil
+	mirror.DeduplicateRequests = false
+	mirror.ValidateZone = false
+	return &mirror, nil
+}
+
+// mirrorWorkers bounds and tracks the mirrored requests running in the background.
+type mirrorWorkers struct {
+	mutex   sync.Mutex
+	wg      sync.WaitGroup
+	running int
+	closed  bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+func newMirrorWorkers() *mirrorWorkers {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &mirrorWorkers{ctx: ctx, cancel: cancel}
+}
+
+// mirrorWorkersMutex guards the creation of the workers of the Configs not made by New.
+var mirrorWorkersMutex sync.Mutex
+
+func (c *Config) getMirrorWorkers() *mirrorWorkers {
+	mirrorWorkersMutex.Lock()
+	defer mirrorWorkersMutex.Unlock()
+	if c.mirrors == nil {
+		c.mirrors = newMirrorWorkers()
+	}
+	return c.mirrors
+}
+
+// GoMirror runs the mirrored request f in the background with a context canceled by ShutdownMirrors.
+// It returns false without running f if MirrorConcurrency requests are already running, or after ShutdownMirrors.
+func (c *Config) GoMirror(f func(ctx context.Context)) bool {
+	concurrency := c.MirrorConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultMirrorConcurrency
+	}
+	w := c.getMirrorWorkers()
+	w.mutex.Lock()
+	if w.closed || w.running >= concurrency {
+		w.mutex.Unlock()
+		return false
+	}
+	w.running++
+	w.wg.Add(1)
+	w.mutex.Unlock()
+
+	go func() {
+		defer func() {
+			w.mutex.Lock()
+			w.running--
+			w.mutex.Unlock()
+			w.wg.Done()
+		}()
+		f(w.ctx)
+	}()
+	return true
+}
+
+// ShutdownMirrors stops mirroring the requests and waits the running mirrored requests return
+// until ctx is done, then cancels them.
+func (c *Config) ShutdownMirrors(ctx context.Context) error {
+	w := c.getMirrorWorkers()
+	w.mutex.Lock()
+	w.closed = true
+	w.mutex.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(drained)
+	}()
+
+	var err error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	w.cancel()
+	return err
+}
@@ -0,0 +1,121 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package config
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+// Action classes of RequestPolicies.
+const (
+	ActionClassDescribe    = "describe"
+	ActionClassMutate      = "mutate"
+	ActionClassLongRunning = "long_running"
+)
+
+// A RequestPolicy overrides the retries and the rate limit of the requests of a service or an action.
+type RequestPolicy struct {
+	// Retries overrides ConnectionRetries if not nil.
+	Retries *int `yaml:"retries"`
+	// RetryInterval is the seconds waited before retrying a request, 0 waits 1 second.
+	RetryInterval float64 `yaml:"retry_interval"`
+	// RateLimit is the requests sent per second, 0 disables the rate limit.
+	RateLimit float64 `yaml:"rate_limit"`
+	// Burst is the requests sent at once before the rate limit applies, at least 1.
+	Burst int `yaml:"burst"`
+
+	mutex  sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// RetryBackoff returns the time waited before retrying a request.
+func (p *RequestPolicy) RetryBackoff() time.Duration {
+	if p == nil || p.RetryInterval <= 0 {
+		return time.Second
+	}
+	return time.Duration(p.RetryInterval * float64(time.Second))
+}
+
+// Wait blocks until the rate limit allows one more request, it returns the error of ctx if ctx is done first.
+// The requests waiting are served in order, the ones canceled still count.
+func (p *RequestPolicy) Wait(ctx context.Context, clock utils.Clock) error {
+	if p == nil || p.RateLimit <= 0 {
+		return nil
+	}
+	p.mutex.Lock()
+	now := clock.Now()
+	burst := float64(p.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	if p.last.IsZero() {
+		p.tokens = burst
+	} else if elapsed := now.Sub(p.last); elapsed > 0 {
+		p.tokens += elapsed.Seconds() * p.RateLimit
+		if p.tokens > burst {
+			p.tokens = burst
+		}
+	}
+	if now.After(p.last) {
+		p.last = now
+	}
+	p.tokens--
+	wait := time.Duration(0)
+	if p.tokens < 0 {
+		wait = time.Duration(-p.tokens / p.RateLimit * float64(time.Second))
+	}
+	p.mutex.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	select {
+	case <-clock.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RequestPolicy returns the policy of the requests of the action of the service, looked up
+// in RequestPolicies by the action, the service and the action class in turn, nil if none.
+func (c *Config) RequestPolicy(service, action string) *RequestPolicy {
+	if len(c.RequestPolicies) == 0 {
+		return nil
+	}
+	for _, key := range []string{action, service, ActionClass(action)} {
+		if policy, ok := c.RequestPolicies[key]; ok && key != "" {
+			return policy
+		}
+	}
+	return nil
+}
+
+// ActionClass returns the class of the action as classified by the action timeouts.
+func ActionClass(action string) string {
+	if IsDescribeAction(action) {
+		return ActionClassDescribe
+	} else if hasAnyPrefix(action, longRunningActionPrefixes) {
+		return ActionClassLongRunning
+	}
+	return ActionClassMutate
+}
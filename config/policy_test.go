@@ -0,0 +1,85 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+func TestConfig_RequestPolicy(t *testing.T) {
+	config, err := NewDefault()
+	assert.Nil(t, err)
+	assert.Nil(t, config.RequestPolicy("Job", "DescribeJobs"))
+
+	err = config.LoadConfigFromContent([]byte(`
+request_policies:
+  describe:
+    retries: 5
+    retry_interval: 0.2
+  LoadBalancer:
+    retries: 0
+    rate_limit: 1
+  DescribeLoadBalancers:
+    rate_limit: 10
+    burst: 5
+`))
+	assert.Nil(t, err)
+
+	policy := config.RequestPolicy("Job", "DescribeJobs")
+	assert.Equal(t, 5, *policy.Retries)
+	assert.Equal(t, 200*time.Millisecond, policy.RetryBackoff())
+	policy = config.RequestPolicy("LoadBalancer", "UpdateLoadBalancers")
+	assert.Equal(t, 0, *policy.Retries)
+	assert.Equal(t, time.Second, policy.RetryBackoff())
+	policy = config.RequestPolicy("LoadBalancer", "DescribeLoadBalancers")
+	assert.Nil(t, policy.Retries)
+	assert.Equal(t, 5, policy.Burst)
+	assert.Nil(t, config.RequestPolicy("Job", "RetryJobs"))
+
+	assert.Equal(t, ActionClassDescribe, ActionClass("GetMonitor"))
+	assert.Equal(t, ActionClassLongRunning, ActionClass("CaptureInstance"))
+	assert.Equal(t, ActionClassMutate, ActionClass("RunInstances"))
+}
+
+func TestRequestPolicy_Wait(t *testing.T) {
+	clock := utils.NewFakeClock(time.Now())
+	policy := &RequestPolicy{RateLimit: 2, Burst: 2}
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, policy.Wait(context.Background(), clock))
+	}
+	assert.Equal(t, 500*time.Millisecond, clock.Slept())
+
+	clock.Advance(10 * time.Second)
+	slept := clock.Slept()
+	assert.Nil(t, policy.Wait(context.Background(), clock))
+	assert.Nil(t, policy.Wait(context.Background(), clock))
+	assert.Equal(t, slept, clock.Slept())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	slow := &RequestPolicy{RateLimit: 0.001}
+	assert.Nil(t, slow.Wait(ctx, utils.RealClock{}))
+	assert.Equal(t, context.Canceled, slow.Wait(ctx, utils.RealClock{}))
+	var nilPolicy *RequestPolicy
+	assert.Nil(t, nilPolicy.Wait(ctx, clock))
+	assert.Equal(t, time.Second, nilPolicy.RetryBackoff())
+}
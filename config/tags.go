@@ -0,0 +1,31 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package config
+
+// DefaultTagsOf returns the default tags of the resources of the type, the
+// global DefaultTags followed by the ResourceDefaultTags of the type.
+func (c *Config) DefaultTagsOf(resourceType string) []string {
+	tags := []string{}
+	seen := map[string]bool{}
+	for _, tag := range append(append([]string{}, c.DefaultTags...), c.ResourceDefaultTags[resourceType]...) {
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,63 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// Timeouts stores the default request timeouts in seconds of each action class,
+// 0 means the requests of the class have no timeout.
+type Timeouts struct {
+	// Describe is the timeout of the actions which only read resources, like DescribeInstances.
+	Describe int `yaml:"describe"`
+	// Mutate is the timeout of the actions which change resources, like RunInstances.
+	Mutate int `yaml:"mutate"`
+	// LongRunning is the timeout of the actions which transfer data, like CaptureInstance.
+	LongRunning int `yaml:"long_running"`
+}
+
+var describeActionPrefixes = []string{"Describe", "Get", "List", "Check"}
+
+var longRunningActionPrefixes = []string{"Capture", "Export", "Import", "Upload"}
+
+// ActionTimeout returns the timeout of the action by its class.
+func (c *Config) ActionTimeout(action string) time.Duration {
+	seconds := c.Timeouts.Mutate
+	switch ActionClass(action) {
+	case ActionClassDescribe:
+		seconds = c.Timeouts.Describe
+	case ActionClassLongRunning:
+		seconds = c.Timeouts.LongRunning
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// IsDescribeAction checks whether the action only reads resources.
+func IsDescribeAction(action string) bool {
+	return hasAnyPrefix(action, describeActionPrefixes)
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// A CachedToken is the temporary credential issued by the credential proxy.
+type CachedToken struct {
+	AccessKeyID     string `json:"access_key"`
+	SecretAccessKey string `json:"secret_key"`
+	Token           string `json:"id_token"`
+	// Expiration is the unix time the token expires at.
+	Expiration int64 `json:"expiration"`
+}
+
+// Expired checks whether the token is expired at the time.
+func (t *CachedToken) Expired(now time.Time) bool {
+	return t.Token == "" || now.Unix() >= t.Expiration
+}
+
+// A TokenCache stores the tokens by the URL of the credential proxy issuing
+// them, so that the tokens are refreshed once for all the configs sharing the
+// cache. A miss is returned as nil token and nil error.
+type TokenCache interface {
+	Get(key string) (*CachedToken, error)
+	Set(key string, token *CachedToken) error
+}
+
+// DefaultTokenCache is the cache of the configs without TokenCache, shared in the process.
+var DefaultTokenCache TokenCache = NewMemoryTokenCache()
+
+// MemoryTokenCache is a TokenCache in memory.
+type MemoryTokenCache struct {
+	mutex  sync.Mutex
+	tokens map[string]CachedToken
+}
+
+// NewMemoryTokenCache creates an empty MemoryTokenCache.
+func NewMemoryTokenCache() *MemoryTokenCache {
+	return &MemoryTokenCache{tokens: map[string]CachedToken{}}
+}
+
+// Get returns a copy of the token cached for the key.
+func (c *MemoryTokenCache) Get(key string) (*CachedToken, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	token, ok := c.tokens[key]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// Set caches a copy of the token for the key.
+func (c *MemoryTokenCache) Set(key string, token *CachedToken) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.tokens[key] = *token
+	return nil
+}
+
+// FileTokenCache is a TokenCache storing each token in a file of the directory,
+// which shares the tokens between the processes on the host.
+type FileTokenCache struct {
+	Dir string
+}
+
+// Get reads the token cached for the key.
+func (c *FileTokenCache) Get(key string) (*CachedToken, error) {
+	content, err := ioutil.ReadFile(c.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	token := &CachedToken{}
+	if err := json.Unmarshal(content, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Set writes the token for the key, the file is replaced at once so that
+// the other processes never read a partial token.
+func (c *FileTokenCache) Set(key string, token *CachedToken) error {
+	content, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return err
+	}
+	file, err := ioutil.TempFile(c.Dir, ".token-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), c.path(key))
+}
+
+func (c *FileTokenCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,57 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "qingcloud-token-cache")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	for _, cache := range []TokenCache{NewMemoryTokenCache(), &FileTokenCache{Dir: dir + "/tokens"}} {
+		token, err := cache.Get("http://169.254.169.254:80/latest/meta-data/security-credentials")
+		assert.Nil(t, err)
+		assert.Nil(t, token)
+
+		assert.Nil(t, cache.Set("http://169.254.169.254:80/latest/meta-data/security-credentials", &CachedToken{
+			AccessKeyID:     "AccessKeyID",
+			SecretAccessKey: "SecretAccessKey",
+			Token:           "Token",
+			Expiration:      now.Add(time.Hour).Unix(),
+		}))
+		token, err = cache.Get("http://169.254.169.254:80/latest/meta-data/security-credentials")
+		assert.Nil(t, err)
+		assert.Equal(t, "AccessKeyID", token.AccessKeyID)
+		assert.Equal(t, "Token", token.Token)
+		assert.False(t, token.Expired(now))
+		assert.True(t, token.Expired(now.Add(2*time.Hour)))
+	}
+
+	config, err := NewDefault()
+	assert.Nil(t, err)
+	assert.Nil(t, config.LoadConfigFromContent([]byte("token_cache_dir: '"+dir+"'\n")))
+	assert.Equal(t, &FileTokenCache{Dir: dir}, config.TokenCache)
+}
@@ -0,0 +1,37 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+// Package examples shows the usage of the services and validates the SDK against a platform.
+//
+// The Example functions are compiled with the SDK and rendered by godoc. The smoke
+// tests are gated by the "smoke" build tag, so they can be run against a private
+// cloud to check its compatibility with the SDK:
+//
+//	QINGCLOUD_CONFIG=~/.qingcloud/config.yaml QINGCLOUD_ZONE=pek3a go test -v -tags smoke ./examples/
+//
+// QINGCLOUD_CONFIG defaults to the user config, and QINGCLOUD_ZONE defaults to
+// the zone of the config.
+//
+// The smoke tests in describe_smoke_test.go are generated by gen_smoke.go from the
+// service package, which is generated from the API specs, run "go generate" after
+// regenerating the services. For every service they call each Describe action
+// taking no required parameter, so they are read only. The create, describe and
+// delete smoke tests are written by hand and only cover the cheap resources which
+// can be created without any other resource, namely tags, key pairs, security
+// groups and vxnets, besides the zones which are only described.
+package examples
+
+//go:generate go run gen_smoke.go
@@ -0,0 +1,112 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package examples
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/client"
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/service"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+func ExampleInstanceService_DescribeInstances() {
+	conf, _ := config.New("ACCESS_KEY_ID", "SECRET_ACCESS_KEY")
+	qcService, _ := service.Init(conf)
+	instanceService, _ := qcService.Instance("pek3a")
+
+	output, err := instanceService.DescribeInstances(&service.DescribeInstancesInput{
+		Status: service.StringSlice([]string{"running"}),
+		Limit:  service.Int(100),
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, instance := range output.InstanceSet {
+		fmt.Println(service.StringValue(instance.InstanceID), service.StringValue(instance.InstanceName))
+	}
+}
+
+func ExampleSecurityGroupService_CreateSecurityGroup() {
+	conf, _ := config.New("ACCESS_KEY_ID", "SECRET_ACCESS_KEY")
+	qcService, _ := service.Init(conf)
+	securityGroupService, _ := qcService.SecurityGroup("pek3a")
+
+	output, err := securityGroupService.CreateSecurityGroup(&service.CreateSecurityGroupInput{
+		SecurityGroupName: service.String("web"),
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(service.StringValue(output.SecurityGroupID))
+}
+
+func ExampleSnapshotIterator_Export() {
+	conf, _ := config.New("ACCESS_KEY_ID", "SECRET_ACCESS_KEY")
+	qcService, _ := service.Init(conf)
+	volumeService, _ := qcService.Volume("pek3a")
+
+	input := &service.DescribeVolumesInput{}
+	it := &service.SnapshotIterator{}
+	err := it.Export(input, func() (interface{}, error) {
+		return volumeService.DescribeVolumes(input)
+	}, utils.NewCSVEncoder(os.Stdout, []string{"volume_id", "volume_name", "size"}, false))
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+func ExampleEnsureVxNet() {
+	conf, _ := config.New("ACCESS_KEY_ID", "SECRET_ACCESS_KEY")
+	qcService, _ := service.Init(conf)
+	vxnetService, _ := qcService.VxNet("pek3a")
+	tagService, _ := qcService.Tag("pek3a")
+
+	vxnetID, err := client.EnsureVxNet(vxnetService, tagService, &client.VxNetSpec{
+		Name:      "backend",
+		VxNetType: client.VxNetTypeManaged,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(vxnetID)
+}
+
+func ExampleWaitJob() {
+	conf, _ := config.New("ACCESS_KEY_ID", "SECRET_ACCESS_KEY")
+	qcService, _ := service.Init(conf)
+	instanceService, _ := qcService.Instance("pek3a")
+	jobService, _ := qcService.Job("pek3a")
+
+	output, err := instanceService.StopInstances(&service.StopInstancesInput{
+		Instances: service.StringSlice([]string{"i-xxxxxxxx"}),
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	err = client.WaitJob(jobService, service.StringValue(output.JobID), 5*time.Minute, 3*time.Second)
+	if err != nil {
+		fmt.Println(err)
+	}
+}
@@ -0,0 +1,252 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+//go:build ignore
+// +build ignore
+
+// gen_smoke generates describe_smoke_test.go from the service package, which is
+// generated from the API specs. For every service it emits a smoke test calling
+// each Describe action whose input has no required parameter.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const (
+	serviceDir = "../service"
+	outputFile = "describe_smoke_test.go"
+)
+
+// smokeService is a service with the Describe actions to call.
+type smokeService struct {
+	Name        string
+	Test        string
+	Constructor string
+	Zoned       bool
+	Actions     []*smokeAction
+}
+
+// smokeAction is a Describe action of a service.
+type smokeAction struct {
+	Name  string
+	Input string
+	Limit bool
+}
+
+func main() {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), serviceDir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pkg, ok := pkgs["service"]
+	if !ok {
+		log.Fatalf("package service not found in %s", serviceDir)
+	}
+
+	structs := map[string]*ast.StructType{}
+	funcs := []*ast.FuncDecl{}
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					if spec, ok := spec.(*ast.TypeSpec); ok {
+						if structType, ok := spec.Type.(*ast.StructType); ok {
+							structs[spec.Name.Name] = structType
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if decl.Recv != nil && len(decl.Recv.List) == 1 {
+					funcs = append(funcs, decl)
+				}
+			}
+		}
+	}
+
+	services := map[string]*smokeService{
+		"QingCloudService": {Name: "QingCloudService", Test: "QingCloud"},
+	}
+	for _, f := range funcs {
+		if receiverName(f) != "QingCloudService" {
+			continue
+		}
+		result := serviceResult(f)
+		if result == "" || !strings.HasSuffix(result, "Service") {
+			continue
+		}
+		params := f.Type.Params.List
+		zoned := len(params) == 1 && len(params[0].Names) == 1 && typeName(params[0].Type) == "string"
+		if len(params) != 0 && !zoned {
+			continue
+		}
+		services[result] = &smokeService{Name: result, Test: f.Name.Name, Constructor: f.Name.Name, Zoned: zoned}
+	}
+
+	for _, f := range funcs {
+		s, ok := services[receiverName(f)]
+		if !ok || !strings.HasPrefix(f.Name.Name, "Describe") {
+			continue
+		}
+		input, output := f.Name.Name+"Input", f.Name.Name+"Output"
+		params := f.Type.Params.List
+		if len(params) != 1 || typeName(params[0].Type) != "*"+input || serviceResult(f) != output {
+			continue
+		}
+		inputStruct, ok := structs[input]
+		if !ok || hasRequiredField(inputStruct) {
+			continue
+		}
+		s.Actions = append(s.Actions, &smokeAction{
+			Name:  f.Name.Name,
+			Input: input,
+			Limit: hasField(inputStruct, "Limit"),
+		})
+	}
+
+	list := []*smokeService{}
+	for _, s := range services {
+		if len(s.Actions) == 0 {
+			continue
+		}
+		sort.Slice(s.Actions, func(i, j int) bool { return s.Actions[i].Name < s.Actions[j].Name })
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	buffer := &bytes.Buffer{}
+	if err := smokeTemplate.Execute(buffer, list); err != nil {
+		log.Fatal(err)
+	}
+	content, err := format.Source(buffer.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(outputFile, content, 0644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("generated %s with %d services\n", outputFile, len(list))
+}
+
+func receiverName(f *ast.FuncDecl) string {
+	return strings.TrimPrefix(typeName(f.Recv.List[0].Type), "*")
+}
+
+// serviceResult returns the pointed type of the first result if the results are (*T, error).
+func serviceResult(f *ast.FuncDecl) string {
+	if f.Type.Results == nil || len(f.Type.Results.List) != 2 || typeName(f.Type.Results.List[1].Type) != "error" {
+		return ""
+	}
+	result := typeName(f.Type.Results.List[0].Type)
+	if !strings.HasPrefix(result, "*") {
+		return ""
+	}
+	return strings.TrimPrefix(result, "*")
+}
+
+func typeName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.StarExpr:
+		return "*" + typeName(expr.X)
+	}
+	return ""
+}
+
+func hasRequiredField(s *ast.StructType) bool {
+	for _, field := range s.Fields.List {
+		if field.Comment != nil && strings.Contains(field.Comment.Text(), "Required") {
+			return true
+		}
+	}
+	return false
+}
+
+func hasField(s *ast.StructType, name string) bool {
+	for _, field := range s.Fields.List {
+		for _, ident := range field.Names {
+			if ident.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var smokeTemplate = template.Must(template.New("smoke").Parse(`// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+// Code generated by gen_smoke.go. DO NOT EDIT.
+
+//go:build smoke
+// +build smoke
+
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+{{range .}}
+func TestSmokeDescribe{{.Test}}(t *testing.T) {
+{{- if .Constructor}}
+	s, err := smokeService.{{.Constructor}}({{if .Zoned}}smokeZone{{end}})
+	if !assert.Nil(t, err) {
+		return
+	}
+{{- else}}
+	s := smokeService
+{{- end}}
+{{- range .Actions}}
+	t.Run("{{.Name}}", func(t *testing.T) {
+		output, err := s.{{.Name}}(&service.{{.Input}}{ {{- if .Limit}}Limit: service.Int(1){{end -}} })
+		if assert.Nil(t, err) {
+			assertRetCode(t, output.RetCode)
+		}
+	})
+{{- end}}
+}
+{{end}}`))
@@ -0,0 +1,55 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+//go:build smoke
+// +build smoke
+
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+func TestSmokeSecurityGroup(t *testing.T) {
+	securityGroupService, err := smokeService.SecurityGroup(smokeZone)
+	if !assert.Nil(t, err) {
+		return
+	}
+	name := smokeName("sg")
+	output, err := securityGroupService.CreateSecurityGroup(&service.CreateSecurityGroupInput{SecurityGroupName: service.String(name)})
+	if !assert.Nil(t, err) || !assertRetCode(t, output.RetCode) {
+		return
+	}
+	securityGroupID := service.StringValue(output.SecurityGroupID)
+	defer func() {
+		deleteOutput, err := securityGroupService.DeleteSecurityGroups(&service.DeleteSecurityGroupsInput{
+			SecurityGroups: service.StringSlice([]string{securityGroupID}),
+		})
+		if assert.Nil(t, err) {
+			assertRetCode(t, deleteOutput.RetCode)
+		}
+	}()
+
+	describeOutput, err := securityGroupService.DescribeSecurityGroups(&service.DescribeSecurityGroupsInput{
+		SecurityGroups: service.StringSlice([]string{securityGroupID}),
+	})
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(describeOutput.SecurityGroupSet)) {
+		assert.Equal(t, name, service.StringValue(describeOutput.SecurityGroupSet[0].SecurityGroupName))
+	}
+}
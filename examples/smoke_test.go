@@ -0,0 +1,72 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+//go:build smoke
+// +build smoke
+
+package examples
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+const (
+	smokeTimeout      = 5 * time.Minute
+	smokeWaitInterval = 3 * time.Second
+)
+
+var smokeService *service.QingCloudService
+var smokeZone string
+
+func TestMain(m *testing.M) {
+	conf, err := config.NewDefault()
+	if err == nil {
+		if path := os.Getenv("QINGCLOUD_CONFIG"); path != "" {
+			err = conf.LoadConfigFromFilepath(path)
+		} else {
+			err = conf.LoadUserConfig()
+		}
+	}
+	if err == nil {
+		smokeService, err = service.Init(conf)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load smoke test config error: %s\n", err)
+		os.Exit(1)
+	}
+	smokeZone = os.Getenv("QINGCLOUD_ZONE")
+	if smokeZone == "" {
+		smokeZone = conf.Zone
+	}
+	os.Exit(m.Run())
+}
+
+// smokeName returns the name of the resources created by the smoke test of the service.
+func smokeName(name string) string {
+	return fmt.Sprintf("sdk-smoke-%s-%d", name, time.Now().Unix())
+}
+
+// assertRetCode asserts that the ret_code of the output is 0.
+func assertRetCode(t *testing.T, retCode *int) bool {
+	return assert.Equal(t, 0, service.IntValue(retCode))
+}
@@ -0,0 +1,51 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+//go:build smoke
+// +build smoke
+
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+func TestSmokeTag(t *testing.T) {
+	tagService, err := smokeService.Tag(smokeZone)
+	if !assert.Nil(t, err) {
+		return
+	}
+	name := smokeName("tag")
+	output, err := tagService.CreateTag(&service.CreateTagInput{TagName: service.String(name)})
+	if !assert.Nil(t, err) || !assertRetCode(t, output.RetCode) {
+		return
+	}
+	tagID := service.StringValue(output.TagID)
+	defer func() {
+		deleteOutput, err := tagService.DeleteTags(&service.DeleteTagsInput{Tags: service.StringSlice([]string{tagID})})
+		if assert.Nil(t, err) {
+			assertRetCode(t, deleteOutput.RetCode)
+		}
+	}()
+
+	describeOutput, err := tagService.DescribeTags(&service.DescribeTagsInput{Tags: service.StringSlice([]string{tagID})})
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(describeOutput.TagSet)) {
+		assert.Equal(t, name, service.StringValue(describeOutput.TagSet[0].TagName))
+	}
+}
@@ -0,0 +1,55 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+//go:build smoke
+// +build smoke
+
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/client"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+func TestSmokeVxNet(t *testing.T) {
+	vxnetService, err := smokeService.VxNet(smokeZone)
+	if !assert.Nil(t, err) {
+		return
+	}
+	name := smokeName("vxnet")
+	output, err := vxnetService.CreateVxNets(&service.CreateVxNetsInput{
+		VxNetName: service.String(name),
+		VxNetType: service.Int(client.VxNetTypeManaged),
+	})
+	if !assert.Nil(t, err) || !assertRetCode(t, output.RetCode) || !assert.Equal(t, 1, len(output.VxNets)) {
+		return
+	}
+	vxnetID := service.StringValue(output.VxNets[0])
+	defer func() {
+		deleteOutput, err := vxnetService.DeleteVxNets(&service.DeleteVxNetsInput{VxNets: service.StringSlice([]string{vxnetID})})
+		if assert.Nil(t, err) {
+			assertRetCode(t, deleteOutput.RetCode)
+		}
+	}()
+
+	describeOutput, err := vxnetService.DescribeVxNets(&service.DescribeVxNetsInput{VxNets: service.StringSlice([]string{vxnetID})})
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(describeOutput.VxNetSet)) {
+		assert.Equal(t, name, service.StringValue(describeOutput.VxNetSet[0].VxNetName))
+	}
+}
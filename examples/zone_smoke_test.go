@@ -0,0 +1,47 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+//go:build smoke
+// +build smoke
+
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/service"
+)
+
+func TestSmokeZone(t *testing.T) {
+	output, err := smokeService.DescribeZones(nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assertRetCode(t, output.RetCode)
+	zones := []string{}
+	for _, zone := range output.ZoneSet {
+		zones = append(zones, service.StringValue(zone.ZoneID))
+	}
+	assert.Contains(t, zones, smokeZone)
+
+	jobService, err := smokeService.Job(smokeZone)
+	assert.Nil(t, err)
+	jobOutput, err := jobService.DescribeJobs(&service.DescribeJobsInput{Limit: service.Int(1)})
+	if assert.Nil(t, err) {
+		assertRetCode(t, jobOutput.RetCode)
+	}
+}
@@ -0,0 +1,65 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package request
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+)
+
+// recordAttempt appends the result of sending the request at start to the attempts.
+func (r *Request) recordAttempt(start time.Time, response *http.Response, err error) {
+	attempt := errors.Attempt{
+		Time:     start,
+		Endpoint: r.HTTPRequest.URL.Host,
+	}
+	if response != nil {
+		attempt.StatusCode = response.StatusCode
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	r.Attempts = append(r.Attempts, attempt)
+}
+
+// recordBackoff records the time waited after the last attempt.
+func (r *Request) recordBackoff(backoff time.Duration) {
+	if len(r.Attempts) > 0 {
+		r.Attempts[len(r.Attempts)-1].Backoff = backoff
+	}
+}
+
+// attachAttempts attaches the attempts to the QingCloudError the response
+// is unpacked to, the other errors are not caused by the API.
+func (r *Request) attachAttempts(err error) error {
+	if e, ok := errors.AsQingCloudError(err); ok && len(r.Attempts) > 0 {
+		r.Attempts[len(r.Attempts)-1].RetCode = e.RetCode
+		e.Attempts = r.Attempts
+	}
+	return err
+}
+
+// requestError wraps the error of the last attempt with the attempts.
+func (r *Request) requestError(err error) error {
+	return &errors.RequestError{
+		APIName:  r.Operation.APIName,
+		Attempts: r.Attempts,
+		Err:      err,
+	}
+}
@@ -0,0 +1,137 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package request
+
+import (
+	"io/ioutil"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+)
+
+// auditMask replaces the values of the secret parameters in audit records.
+const auditMask = "******"
+
+// auditSecretParams are the substrings of the names of secret parameters, like login_passwd.
+// The user data may carry secrets too, and its attachments are large.
+var auditSecretParams = []string{"passwd", "password", "secret", "token", "private_key", "credential",
+	"userdata_value", "user_data", "attachment_content"}
+
+// auditActionSecretParams are the names of the parameters secret only in the actions, like the passwords of
+// the VPN users in the val2 of router static entries, matched with or without the index prefix like "entries.1.".
+var auditActionSecretParams = map[string][]string{
+	"AddRouterStaticEntries":            {"val2"},
+	"ModifyRouterStaticEntryAttributes": {"val2"},
+}
+
+// auditSigningParams are the parameters added by signing, which are not recorded.
+var auditSigningParams = map[string]bool{
+	"action":            true,
+	"access_key_id":     true,
+	"signature":         true,
+	"signature_method":  true,
+	"signature_version": true,
+	"time_stamp":        true,
+	"version":           true,
+	"expires":           true,
+}
+
+// audit records the mutating call sent at start to the audit sink of the config.
+func (r *Request) audit(start time.Time, err error) {
+	sink := r.Operation.Config.AuditSink
+	if sink == nil || r.HTTPRequest == nil || config.IsDescribeAction(r.Operation.APIName) {
+		return
+	}
+	record := &config.AuditRecord{
+		Time:     start,
+		Duration: r.Operation.Config.GetClock().Now().Sub(start),
+		Action:   r.Operation.APIName,
+		Params:   r.auditParams(),
+	}
+	record.Zone = record.Params["zone"]
+	if err != nil {
+		record.Error = err.Error()
+		if e, ok := errors.AsQingCloudError(err); ok {
+			record.RetCode = e.RetCode
+			record.Message = e.Message
+		}
+	} else if r.Output != nil && r.Output.Kind() == reflect.Ptr && !r.Output.IsNil() {
+		output := r.Output.Elem()
+		if field := output.FieldByName("JobID"); field.IsValid() {
+			if jobID, ok := field.Interface().(*string); ok && jobID != nil {
+				record.JobID = *jobID
+			}
+		}
+		if field := output.FieldByName("Message"); field.IsValid() {
+			if message, ok := field.Interface().(*string); ok && message != nil {
+				record.Message = *message
+			}
+		}
+	}
+	sink.Record(record)
+}
+
+// auditParams returns the parameters of the request with the secret values masked.
+func (r *Request) auditParams() map[string]string {
+	values := r.HTTPRequest.URL.Query()
+	if r.HTTPRequest.Method == "POST" && r.HTTPRequest.GetBody != nil {
+		if body, err := r.HTTPRequest.GetBody(); err == nil {
+			content, err := ioutil.ReadAll(body)
+			body.Close()
+			if form, parseErr := url.ParseQuery(string(content)); err == nil && parseErr == nil {
+				for key, value := range form {
+					values[key] = value
+				}
+			}
+		}
+	}
+	params := make(map[string]string, len(values))
+	for key := range values {
+		if auditSigningParams[key] {
+			continue
+		}
+		params[key] = values.Get(key)
+		if isSecretParam(key) || isActionSecretParam(r.Operation.APIName, key) {
+			params[key] = auditMask
+		}
+	}
+	return params
+}
+
+func isSecretParam(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range auditSecretParams {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+func isActionSecretParam(action string, key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range auditActionSecretParams[action] {
+		if key == secret || strings.HasSuffix(key, "."+secret) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/request/data"
+)
+
+type ResetInstancesInput struct {
+	Instances   []*string `json:"instances" name:"instances" location:"params"`
+	LoginPasswd *string   `json:"login_passwd" name:"login_passwd" location:"params"`
+}
+
+func (i *ResetInstancesInput) Validate() error {
+	return nil
+}
+
+type ResetInstancesOutput struct {
+	Message *string `json:"message" name:"message"`
+	JobID   *string `json:"job_id" name:"job_id" location:"elements"`
+	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
+}
+
+type RouterStaticEntry struct {
+	Val1 *string `json:"val1" name:"val1"`
+	Val2 *string `json:"val2" name:"val2"`
+}
+
+type AddRouterStaticEntriesInput struct {
+	Entries      []*RouterStaticEntry `json:"entries" name:"entries" location:"params"`
+	RouterStatic *string              `json:"router_static" name:"router_static" location:"params"`
+}
+
+func (i *AddRouterStaticEntriesInput) Validate() error {
+	return nil
+}
+
+type ModifyRouterStaticEntryAttributesInput struct {
+	RouterStaticEntry *string `json:"router_static_entry" name:"router_static_entry" location:"params"`
+	Val1              *string `json:"val1" name:"val1" location:"params"`
+	Val2              *string `json:"val2" name:"val2" location:"params"`
+}
+
+func (i *ModifyRouterStaticEntryAttributesInput) Validate() error {
+	return nil
+}
+
+func TestRequestAudit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.FormValue("instances.1") == "i-denied" {
+			w.Write([]byte(`{"ret_code":1400,"message":"PermissionDenied"}`))
+			return
+		}
+		w.Write([]byte(`{"ret_code":0,"job_id":"j-xxxxxxxx"}`))
+	}))
+	defer server.Close()
+
+	records := []*config.AuditRecord{}
+	conf := newTestConfig(t, server)
+	conf.AuditSink = config.AuditSinkFunc(func(record *config.AuditRecord) {
+		records = append(records, record)
+	})
+	send := func(apiName string, method string, instanceID string) error {
+		r, err := New(&data.Operation{
+			Config:        conf,
+			Properties:    &InstanceServiceProperties{Zone: String("pek3a")},
+			APIName:       apiName,
+			RequestMethod: method,
+		}, &ResetInstancesInput{
+			Instances:   []*string{String(instanceID)},
+			LoginPasswd: String("Passw0rd"),
+		}, &ResetInstancesOutput{})
+		assert.Nil(t, err)
+		return r.Send()
+	}
+
+	assert.Nil(t, send("ResetInstances", "GET", "i-xxxxxxxx"))
+	assert.Nil(t, send("DescribeInstances", "GET", "i-xxxxxxxx"))
+	assert.NotNil(t, send("ResetInstances", "POST", "i-denied"))
+
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, "ResetInstances", records[0].Action)
+	assert.Equal(t, "pek3a", records[0].Zone)
+	assert.Equal(t, map[string]string{
+		"instances.1":  "i-xxxxxxxx",
+		"login_passwd": auditMask,
+		"zone":         "pek3a",
+	}, records[0].Params)
+	assert.Equal(t, "j-xxxxxxxx", records[0].JobID)
+	assert.True(t, records[0].Succeeded())
+
+	assert.Equal(t, "i-denied", records[1].Params["instances.1"])
+	assert.Equal(t, auditMask, records[1].Params["login_passwd"])
+	assert.Equal(t, 1400, records[1].RetCode)
+	assert.Equal(t, "PermissionDenied", records[1].Message)
+	assert.False(t, records[1].Succeeded())
+}
+
+func TestRequestAuditVPNUserPassword(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ret_code":0}`))
+	}))
+	defer server.Close()
+
+	records := []*config.AuditRecord{}
+	conf := newTestConfig(t, server)
+	conf.AuditSink = config.AuditSinkFunc(func(record *config.AuditRecord) {
+		records = append(records, record)
+	})
+	send := func(apiName string, i data.Input) {
+		r, err := New(&data.Operation{
+			Config:        conf,
+			Properties:    &InstanceServiceProperties{Zone: String("pek3a")},
+			APIName:       apiName,
+			RequestMethod: "GET",
+		}, i, &ResetInstancesOutput{})
+		assert.Nil(t, err)
+		assert.Nil(t, r.Send())
+	}
+
+	send("AddRouterStaticEntries", &AddRouterStaticEntriesInput{
+		RouterStatic: String("rtm-vpn"),
+		Entries:      []*RouterStaticEntry{{Val1: String("alice"), Val2: String("Passw0rd1")}, {Val1: String("bob"), Val2: String("Passw0rd2")}},
+	})
+	send("ModifyRouterStaticEntryAttributes", &ModifyRouterStaticEntryAttributesInput{
+		RouterStaticEntry: String("rse-alice"),
+		Val1:              String("alice"),
+		Val2:              String("Passw0rd3"),
+	})
+
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, "alice", records[0].Params["entries.1.val1"])
+	assert.Equal(t, auditMask, records[0].Params["entries.1.val2"])
+	assert.Equal(t, auditMask, records[0].Params["entries.2.val2"])
+	assert.Equal(t, "alice", records[1].Params["val1"])
+	assert.Equal(t, auditMask, records[1].Params["val2"])
+}
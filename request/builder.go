@@ -18,6 +18,7 @@ package request
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -158,6 +159,20 @@ func (b *Builder) parseRequestParams() error {
 				if value != nil {
 					requestParams[tagName] = strconv.Itoa(int(*value))
 				}
+			case *int64:
+				if tagDefault != "" {
+					requestParams[tagName] = tagDefault
+				}
+				if value != nil {
+					requestParams[tagName] = strconv.FormatInt(*value, 10)
+				}
+			case *uint64:
+				if tagDefault != "" {
+					requestParams[tagName] = tagDefault
+				}
+				if value != nil {
+					requestParams[tagName] = strconv.FormatUint(*value, 10)
+				}
 			case *bool:
 			case *time.Time:
 				if tagDefault != "" {
@@ -187,6 +202,26 @@ func (b *Builder) parseRequestParams() error {
 						requestParams[key] = strconv.Itoa(int(*item))
 					}
 				}
+			case []*int64:
+				for index, item := range value {
+					key := tagName + "." + strconv.Itoa(index+1)
+					if tagDefault != "" {
+						requestParams[tagName] = tagDefault
+					}
+					if item != nil {
+						requestParams[key] = strconv.FormatInt(*item, 10)
+					}
+				}
+			case []*uint64:
+				for index, item := range value {
+					key := tagName + "." + strconv.Itoa(index+1)
+					if tagDefault != "" {
+						requestParams[tagName] = tagDefault
+					}
+					if item != nil {
+						requestParams[key] = strconv.FormatUint(*item, 10)
+					}
+				}
 			default:
 				if value != nil {
 					value = value.(interface{})
@@ -207,6 +242,14 @@ func (b *Builder) parseRequestParams() error {
 									if fieldValue != nil {
 										requestParams[tagKey] = strconv.Itoa(int(*fieldValue))
 									}
+								case *int64:
+									if fieldValue != nil {
+										requestParams[tagKey] = strconv.FormatInt(*fieldValue, 10)
+									}
+								case *uint64:
+									if fieldValue != nil {
+										requestParams[tagKey] = strconv.FormatUint(*fieldValue, 10)
+									}
 								case *string:
 									if fieldValue != nil {
 										requestParams[tagKey] = *fieldValue
@@ -237,14 +280,14 @@ func (b *Builder) parseRequestParams() error {
 func (b *Builder) parseRequestURL() error {
 	conf := b.operation.Config
 
-	endpoint := conf.Protocol + "://" + conf.Host + ":" + strconv.Itoa(conf.Port)
+	endpoint := conf.Protocol + "://" + net.JoinHostPort(conf.Host, strconv.Itoa(conf.Port))
 	requestURI := regexp.MustCompile(`/+`).ReplaceAllString(conf.URI, "/")
 
 	b.parsedURL = endpoint + requestURI
 
 	if b.parsedParams != nil && b.operation.RequestMethod == "GET" {
 		if _, ok := (*b.parsedParams)["zone"]; !ok {
-			zone := (*b.parsedProperties)["zone"]
+			zone := b.defaultZone()
 			if zone != "" {
 				(*b.parsedParams)["zone"] = zone
 			}
@@ -263,11 +306,18 @@ func (b *Builder) parseRequestURL() error {
 	return nil
 }
 
+func (b *Builder) defaultZone() string {
+	if zone := (*b.parsedProperties)["zone"]; zone != "" {
+		return zone
+	}
+	return b.operation.Config.Zone
+}
+
 func (b *Builder) parseRequestForm() error {
 	if b.parsedParams != nil && b.operation.RequestMethod == "POST" {
 		var values = make(url.Values)
 		if _, ok := (*b.parsedParams)["zone"]; !ok {
-			zone := (*b.parsedProperties)["zone"]
+			zone := b.defaultZone()
 			if zone != "" {
 				(*b.parsedParams)["zone"] = zone
 			}
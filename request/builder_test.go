@@ -108,4 +108,86 @@ func TestBuilder(t *testing.T) {
 	assert.True(t, strings.Contains(httpRequest.URL.String(), "tags.1=tag1"))
 	assert.True(t, strings.Contains(httpRequest.URL.String(), "verbose=1"))
 	assert.True(t, strings.Contains(httpRequest.URL.String(), "zone=beta"))
+	// the compression is negotiated by the transport
+	assert.Equal(t, "", httpRequest.Header.Get("Accept-Encoding"))
+}
+
+func TestBuilderIPv6Host(t *testing.T) {
+	conf, err := config.NewWithEndpoint("AccessKeyID", "SecretAccessKey", "https://[fd00::10]/iaas")
+	assert.Nil(t, err)
+	assert.Equal(t, "fd00::10", conf.Host)
+
+	builder := &Builder{}
+	operation := &data.Operation{
+		Config: conf,
+		Properties: &InstanceServiceProperties{
+			Zone: String("beta"),
+		},
+		APIName:       "DescribeInstances",
+		RequestMethod: "GET",
+	}
+	inputValue := reflect.ValueOf(&DescribeInstancesInput{})
+	httpRequest, err := builder.BuildHTTPRequest(operation, &inputValue)
+	assert.Nil(t, err)
+	assert.Equal(t, "[fd00::10]:443", httpRequest.URL.Host)
+	assert.True(t, strings.HasPrefix(httpRequest.URL.String(), "https://[fd00::10]:443/iaas?"))
+}
+
+type VolumeQuota struct {
+	Bytes *uint64 `json:"bytes" name:"bytes"`
+	Size  *int64  `json:"size" name:"size"`
+}
+
+type ResizeVolumesInput struct {
+	Quotas  []*VolumeQuota `json:"quotas" name:"quotas" location:"params"`
+	Sizes   []*int64       `json:"sizes" name:"sizes" location:"params"`
+	Size    *int64         `json:"size" name:"size" location:"params"`
+	Quota   *uint64        `json:"quota" name:"quota" default:"1" location:"params"`
+	Traffic []*uint64      `json:"traffic" name:"traffic" location:"params"`
+	Volume  *string        `json:"volume" name:"volume" location:"params"`
+}
+
+func (i *ResizeVolumesInput) Validate() error {
+	return nil
+}
+
+func TestBuilderInt64(t *testing.T) {
+	conf, err := config.NewDefault()
+	assert.Nil(t, err)
+
+	operation := &data.Operation{
+		Config:        conf,
+		Properties:    &InstanceServiceProperties{Zone: String("beta")},
+		APIName:       "ResizeVolumes",
+		RequestMethod: "GET",
+	}
+	size := int64(1) << 40
+	inputValue := reflect.ValueOf(&ResizeVolumesInput{
+		Sizes: []*int64{&size},
+		Size:  &size,
+	})
+	httpRequest, err := (&Builder{}).BuildHTTPRequest(operation, &inputValue)
+	assert.Nil(t, err)
+	query := httpRequest.URL.Query()
+	assert.Equal(t, "1099511627776", query.Get("size"))
+	assert.Equal(t, "1099511627776", query.Get("sizes.1"))
+	assert.Equal(t, "1", query.Get("quota"))
+
+	// the values above 2^53 lose their precision as float64
+	large := int64(1)<<53 + 1
+	traffic := uint64(1)<<63 + 1
+	inputValue = reflect.ValueOf(&ResizeVolumesInput{
+		Size:    &large,
+		Quota:   &traffic,
+		Traffic: []*uint64{&traffic},
+		Quotas:  []*VolumeQuota{{Bytes: &traffic, Size: &large}},
+	})
+	httpRequest, err = (&Builder{}).BuildHTTPRequest(operation, &inputValue)
+	assert.Nil(t, err)
+	query = httpRequest.URL.Query()
+	assert.Equal(t, "9007199254740993", query.Get("size"))
+	assert.Equal(t, "9223372036854775809", query.Get("quota"))
+	assert.Equal(t, "9223372036854775809", query.Get("traffic.1"))
+	assert.Equal(t, "9223372036854775809", query.Get("quotas.1.bytes"))
+	assert.Equal(t, "9007199254740993", query.Get("quotas.1.size"))
 }
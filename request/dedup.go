@@ -0,0 +1,161 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package request
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+)
+
+// sharedResponse is a response read into memory, so every request waiting
+// for it can unpack its own copy.
+type sharedResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+func (s *sharedResponse) httpResponse(request *http.Request) *http.Response {
+	return &http.Response{
+		StatusCode:    s.statusCode,
+		Header:        s.header,
+		Body:          ioutil.NopCloser(bytes.NewReader(s.body)),
+		ContentLength: int64(len(s.body)),
+		Request:       request,
+	}
+}
+
+type flightCall struct {
+	done     chan struct{}
+	response *sharedResponse
+	err      error
+	// canceled is set if the context of the call ended, its error is not shared.
+	canceled bool
+}
+
+// flightGroup collapses the concurrent calls with the same key into one.
+type flightGroup struct {
+	mutex sync.Mutex
+	calls map[string]*flightCall
+}
+
+var describeFlights = &flightGroup{calls: map[string]*flightCall{}}
+
+// do calls f unless a call with the key is running, in which case it waits
+// for the running call and returns its result. The waiting ends once ctx is done,
+// and if the running call ends by its own context the waiting call calls f by itself.
+func (g *flightGroup) do(ctx context.Context, key string, f func() (*sharedResponse, error)) (*sharedResponse, bool, error) {
+	for {
+		g.mutex.Lock()
+		call, ok := g.calls[key]
+		if !ok {
+			break
+		}
+		g.mutex.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, true, ctx.Err()
+		}
+		if !call.canceled {
+			return call.response, true, call.err
+		}
+	}
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mutex.Unlock()
+
+	call.response, call.err = f()
+	call.canceled = call.err != nil && ctx.Err() != nil
+
+	g.mutex.Lock()
+	delete(g.calls, key)
+	g.mutex.Unlock()
+	close(call.done)
+	return call.response, false, call.err
+}
+
+func (r *Request) deduplicated() bool {
+	return r.Operation.Config.DeduplicateRequests && config.IsDescribeAction(r.Operation.APIName)
+}
+
+// flightKey identifies the request by its endpoint, access key and params,
+// it is built before the request is signed so the time stamp is excluded.
+func (r *Request) flightKey() string {
+	params := url.Values{}
+	for key, values := range r.HTTPRequest.URL.Query() {
+		params[key] = values
+	}
+	for key, values := range r.HTTPRequest.Form {
+		params[key] = values
+	}
+	return r.HTTPRequest.Method + " " + r.HTTPRequest.URL.Host + r.HTTPRequest.URL.Path + " " +
+		r.Operation.Config.AccessKeyID + " " + params.Encode()
+}
+
+// sendShared sends the request unless an identical one is in flight, then
+// unpacks the shared response into the output of r.
+func (r *Request) sendShared(key string) error {
+	response, shared, err := describeFlights.do(r.HTTPRequest.Context(), key, func() (*sharedResponse, error) {
+		if err := r.send(); err != nil {
+			return nil, err
+		}
+		return r.readSharedResponse()
+	})
+	if err != nil {
+		return err
+	}
+	if shared {
+		logger.Debug("Shared the response of an identical %s request", r.Operation.APIName)
+	}
+	r.HTTPResponse = response.httpResponse(r.HTTPRequest)
+	return nil
+}
+
+func (r *Request) readSharedResponse() (*sharedResponse, error) {
+	defer r.HTTPResponse.Body.Close()
+
+	limit := r.Operation.Config.MaxResponseSize
+	if limit == 0 {
+		limit = DefaultMaxResponseSize
+	}
+	body := io.Reader(r.HTTPResponse.Body)
+	if limit > 0 {
+		body = io.LimitReader(body, limit+1)
+	}
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && int64(len(content)) > limit {
+		return nil, errors.ResponseTooLargeError{APIName: r.Operation.APIName, Limit: limit}
+	}
+	return &sharedResponse{
+		statusCode: r.HTTPResponse.StatusCode,
+		header:     r.HTTPResponse.Header,
+		body:       content,
+	}, nil
+}
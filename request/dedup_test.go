@@ -0,0 +1,82 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package request
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlightGroupFollowerContext(t *testing.T) {
+	group := &flightGroup{calls: map[string]*flightCall{}}
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go group.do(context.Background(), "key", func() (*sharedResponse, error) {
+		close(started)
+		<-release
+		return &sharedResponse{statusCode: 200}, nil
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, shared, err := group.do(ctx, "key", func() (*sharedResponse, error) {
+		t.Error("the follower should wait for the running call")
+		return nil, nil
+	})
+	assert.True(t, shared)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	close(release)
+}
+
+func TestFlightGroupLeaderCanceled(t *testing.T) {
+	group := &flightGroup{calls: map[string]*flightCall{}}
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	leaderDone := make(chan error)
+	go func() {
+		_, _, err := group.do(leaderCtx, "key", func() (*sharedResponse, error) {
+			close(started)
+			<-leaderCtx.Done()
+			return nil, leaderCtx.Err()
+		})
+		leaderDone <- err
+	}()
+	<-started
+
+	followerDone := make(chan struct{})
+	var response *sharedResponse
+	var shared bool
+	var err error
+	go func() {
+		response, shared, err = group.do(context.Background(), "key", func() (*sharedResponse, error) {
+			return &sharedResponse{statusCode: 200}, nil
+		})
+		close(followerDone)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancelLeader()
+
+	assert.Equal(t, context.Canceled, <-leaderDone)
+	<-followerDone
+	assert.Nil(t, err)
+	assert.False(t, shared)
+	assert.Equal(t, 200, response.statusCode)
+}
@@ -0,0 +1,79 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// An Attempt records one try to send a request.
+type Attempt struct {
+	Time     time.Time
+	Endpoint string
+	// StatusCode is 0 if no response was received.
+	StatusCode int
+	// RetCode is the ret_code of the response, 0 if it succeeded or was not unpacked.
+	RetCode int
+	// Error is the transport error of the try.
+	Error string
+	// Backoff is the time waited before the next try.
+	Backoff time.Duration
+}
+
+// String returns the description of the Attempt.
+func (a Attempt) String() string {
+	description := a.Time.UTC().Format(time.RFC3339Nano) + " " + a.Endpoint
+	if a.StatusCode != 0 {
+		description += fmt.Sprintf(" status %d", a.StatusCode)
+	}
+	if a.RetCode != 0 {
+		description += fmt.Sprintf(" ret_code %d", a.RetCode)
+	}
+	if a.Error != "" {
+		description += " error: " + a.Error
+	}
+	if a.Backoff != 0 {
+		description += fmt.Sprintf(" backoff %s", a.Backoff)
+	}
+	return description
+}
+
+// RequestError indicates that sending a request failed in all attempts.
+// It wraps the transport error of the last attempt, e.g. a *url.Error, so use
+// errors.As instead of a type assertion to find it.
+type RequestError struct {
+	APIName  string
+	Attempts []Attempt
+	Err      error
+}
+
+// Error returns the description of RequestError with its attempts.
+func (e *RequestError) Error() string {
+	attempts := make([]string, len(e.Attempts))
+	for i, attempt := range e.Attempts {
+		attempts[i] = attempt.String()
+	}
+	return fmt.Sprintf(`request "%s" failed after %d attempts: %s, attempts: [%s]`,
+		e.APIName, len(e.Attempts), e.Err, strings.Join(attempts, "; "))
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
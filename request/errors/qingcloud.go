@@ -17,16 +17,71 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 )
 
+// Ret codes of QingCloud error responses.
+const (
+	RetCodeParameterInvalid     = 1100
+	RetCodeAuthenticationFail   = 1200
+	RetCodeRequestExpired       = 1300
+	RetCodePermissionDenied     = 1400
+	RetCodeResourceNotFound     = 2100
+	RetCodeBalanceNotEnough     = 2400
+	RetCodeQuotaExceeded        = 2500
+	RetCodeInternalError        = 5000
+	RetCodeServerBusy           = 5100
+	RetCodeResourceNotReady     = 5200
+	RetCodeServiceInMaintenance = 5300
+)
+
 // QingCloudError stores information of a QingCloud error response.
 type QingCloudError struct {
 	RetCode int    `json:"ret_code"`
 	Message string `json:"message"`
+	// Attempts is the history of sending the request.
+	Attempts []Attempt `json:"-"`
 }
 
 // Error returns the description of QingCloud error response.
 func (ise QingCloudError) Error() string {
 	return fmt.Sprintf("QingCloud Error: Code (%d), Message (%s)", ise.RetCode, ise.Message)
 }
+
+// TransientConflictRetCodes are the ret codes of the requests conflicting with a resource
+// in transition or busy, which may succeed once the resource leaves the transition state.
+var TransientConflictRetCodes = []int{RetCodeResourceNotReady}
+
+// ErrTransientConflict matches the QingCloudError of a transient conflict by errors.Is,
+// the request may succeed once the resource leaves its transition state.
+var ErrTransientConflict = stderrors.New("transient conflict, retry after the resource leaves its transition state")
+
+// TransientConflict checks whether the request conflicted with a resource in transition or busy.
+func (ise *QingCloudError) TransientConflict() bool {
+	return IsTransientConflict(ise.RetCode)
+}
+
+// Is reports whether the QingCloudError matches the target, i.e. ErrTransientConflict for a transient conflict.
+func (ise *QingCloudError) Is(target error) bool {
+	return target == ErrTransientConflict && ise.TransientConflict()
+}
+
+// IsTransientConflict checks whether the ret code is one of TransientConflictRetCodes.
+func IsTransientConflict(retCode int) bool {
+	for _, code := range TransientConflictRetCodes {
+		if code == retCode {
+			return true
+		}
+	}
+	return false
+}
+
+// AsQingCloudError returns the QingCloudError of the error response.
+func AsQingCloudError(err error) (*QingCloudError, bool) {
+	var e *QingCloudError
+	if stderrors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
@@ -0,0 +1,34 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package errors
+
+import (
+	"fmt"
+)
+
+// ResponseTooLargeError indicates that the response exceeds the size limit.
+type ResponseTooLargeError struct {
+	APIName string
+	Limit   int64
+}
+
+// Error returns the description of ResponseTooLargeError.
+func (e ResponseTooLargeError) Error() string {
+	return fmt.Sprintf(
+		`response of "%s" exceeds the size limit of %d bytes, paginate with "limit" and "offset" or project "fields" to reduce it`,
+		e.APIName, e.Limit)
+}
@@ -0,0 +1,169 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/request/data"
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+)
+
+// mirror sends a copy of the Describe request to the mirror endpoint of the config in the background,
+// unless MirrorConcurrency mirrored requests are running, and reports the differences of the responses to the mirror hook. The requests which got no response
+// from the primary endpoint are not mirrored.
+func (r *Request) mirror(err error) {
+	conf := r.Operation.Config
+	if conf.MirrorEndpoint == "" || conf.MirrorHook == nil || !config.IsDescribeAction(r.Operation.APIName) {
+		return
+	}
+	if _, ok := errors.AsQingCloudError(err); err != nil && !ok {
+		return
+	}
+	diff := &config.MirrorDiff{
+		Time:     conf.GetClock().Now(),
+		Action:   r.Operation.APIName,
+		Endpoint: conf.MirrorEndpoint,
+	}
+	m, err := r.newMirror()
+	if err == nil {
+		diff.Primary, err = json.Marshal(r.Output.Interface())
+	}
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Mirroring %s request error: %s", r.Operation.APIName, err))
+		return
+	}
+
+	started := conf.GoMirror(func(ctx context.Context) {
+		if timeout := m.Operation.Config.ActionTimeout(m.Operation.APIName); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		m.HTTPRequest = m.HTTPRequest.WithContext(ctx)
+		err := m.sign()
+		if err == nil {
+			err = m.send()
+		}
+		if err == nil {
+			err = m.unpack()
+		}
+		if _, ok := errors.AsQingCloudError(err); err != nil && !ok {
+			diff.Error = err.Error()
+		} else if diff.Mirror, err = json.Marshal(m.Output.Interface()); err != nil {
+			diff.Error = err.Error()
+		} else {
+			diff.Differences, err = diffJSON(diff.Primary, diff.Mirror)
+			if err != nil {
+				diff.Error = err.Error()
+			}
+		}
+		conf.MirrorHook(diff)
+	})
+	if !started {
+		logger.Warn(fmt.Sprintf("Mirroring %s request skipped, too many mirrored requests running", r.Operation.APIName))
+	}
+}
+
+// newMirror builds the copy of the request to the mirror endpoint, before the input can be changed by the caller.
+func (r *Request) newMirror() (*Request, error) {
+	conf, err := r.Operation.Config.MirrorConfig()
+	if err != nil {
+		return nil, err
+	}
+	operation := *r.Operation
+	operation.Config = conf
+	input, ok := r.Input.Interface().(data.Input)
+	if !ok {
+		return nil, fmt.Errorf("invalid input %s", r.Input.Type())
+	}
+	m, err := New(&operation, input, reflect.New(r.Output.Elem().Type()).Interface())
+	if err != nil {
+		return nil, err
+	}
+	if err := m.check(); err != nil {
+		return nil, err
+	}
+	if err := m.build(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// diffJSON returns the paths of the fields which differ between the JSON documents.
+func diffJSON(a, b []byte) ([]string, error) {
+	var x, y interface{}
+	if err := json.Unmarshal(a, &x); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &y); err != nil {
+		return nil, err
+	}
+	differences := []string{}
+	diffValues("", x, y, &differences)
+	return differences, nil
+}
+
+func diffValues(path string, x, y interface{}, differences *[]string) {
+	switch xv := x.(type) {
+	case map[string]interface{}:
+		yv, ok := y.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := []string{}
+		for key := range xv {
+			keys = append(keys, key)
+		}
+		for key := range yv {
+			if _, ok := xv[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+			diffValues(keyPath, xv[key], yv[key], differences)
+		}
+		return
+	case []interface{}:
+		yv, ok := y.([]interface{})
+		if !ok {
+			break
+		}
+		if len(xv) != len(yv) {
+			*differences = append(*differences, path)
+			return
+		}
+		for i := range xv {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), xv[i], yv[i], differences)
+		}
+		return
+	}
+	if !reflect.DeepEqual(x, y) {
+		*differences = append(*differences, path)
+	}
+}
@@ -0,0 +1,169 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package request
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/request/data"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+type MirrorInstance struct {
+	InstanceID *string `json:"instance_id" name:"instance_id"`
+	Status     *string `json:"status" name:"status"`
+}
+
+type DescribeMirrorInstancesOutput struct {
+	Message     *string           `json:"message" name:"message"`
+	InstanceSet []*MirrorInstance `json:"instance_set" name:"instance_set" location:"elements"`
+	RetCode     *int              `json:"ret_code" name:"ret_code" location:"elements"`
+}
+
+func TestRequestMirror(t *testing.T) {
+	newServer := func(response string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(response))
+		}))
+	}
+	primary := newServer(`{"ret_code":0,"instance_set":[{"instance_id":"i-1","status":"running"}]}`)
+	defer primary.Close()
+	mirror := newServer(`{"ret_code":0,"instance_set":[{"instance_id":"i-1","status":"stopped"}]}`)
+	defer mirror.Close()
+
+	diffs := make(chan *config.MirrorDiff, 1)
+	conf := newTestConfig(t, primary)
+	conf.Clock = utils.NewFakeClock(time.Now())
+	conf.MirrorEndpoint = mirror.URL + "/iaas"
+	conf.MirrorHook = func(diff *config.MirrorDiff) {
+		diffs <- diff
+	}
+	send := func(action string) *DescribeMirrorInstancesOutput {
+		output := &DescribeMirrorInstancesOutput{}
+		r, err := New(&data.Operation{
+			Config:        conf,
+			Properties:    &InstanceServiceProperties{Zone: String("beta")},
+			APIName:       action,
+			RequestMethod: "GET",
+		}, &RunInstancesInput{}, output)
+		assert.Nil(t, err)
+		assert.Nil(t, r.Send())
+		return output
+	}
+
+	output := send("DescribeInstances")
+	assert.Equal(t, "running", StringValue(output.InstanceSet[0].Status))
+	select {
+	case diff := <-diffs:
+		assert.Equal(t, "DescribeInstances", diff.Action)
+		assert.Equal(t, conf.MirrorEndpoint, diff.Endpoint)
+		assert.Equal(t, []string{"instance_set[0].status"}, diff.Differences)
+		assert.False(t, diff.Matched())
+	case <-time.After(5 * time.Second):
+		t.Fatal("mirror hook not called")
+	}
+
+	send("RunInstances")
+	select {
+	case <-diffs:
+		t.Fatal("mutating request mirrored")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	mirror.Close()
+	send("DescribeInstances")
+	select {
+	case diff := <-diffs:
+		assert.NotEmpty(t, diff.Error)
+		assert.False(t, diff.Matched())
+	case <-time.After(5 * time.Second):
+		t.Fatal("mirror hook not called")
+	}
+}
+
+func TestRequestMirrorConcurrency(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ret_code":0,"instance_set":[]}`))
+	}))
+	defer primary.Close()
+	started := make(chan struct{}, 2)
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-r.Context().Done()
+	}))
+	defer mirror.Close()
+
+	diffs := make(chan *config.MirrorDiff, 2)
+	conf := newTestConfig(t, primary)
+	conf.MirrorEndpoint = mirror.URL + "/iaas"
+	conf.MirrorConcurrency = 1
+	conf.MirrorHook = func(diff *config.MirrorDiff) {
+		diffs <- diff
+	}
+	send := func() {
+		r, err := New(&data.Operation{
+			Config:        conf,
+			Properties:    &InstanceServiceProperties{Zone: String("beta")},
+			APIName:       "DescribeInstances",
+			RequestMethod: "GET",
+		}, &RunInstancesInput{}, &DescribeMirrorInstancesOutput{})
+		assert.Nil(t, err)
+		assert.Nil(t, r.Send())
+	}
+
+	send()
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request not mirrored")
+	}
+	// the mirrored request is still running
+	send()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, conf.ShutdownMirrors(ctx))
+	select {
+	case diff := <-diffs:
+		assert.NotEmpty(t, diff.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("mirrored request not canceled")
+	}
+	assert.Equal(t, 0, len(started))
+	assert.Equal(t, 0, len(diffs))
+	assert.False(t, conf.GoMirror(func(ctx context.Context) {}))
+}
+
+func TestDiffJSON(t *testing.T) {
+	differences, err := diffJSON(
+		[]byte(`{"a":1,"b":{"c":[1,2],"d":"x"},"e":[1]}`),
+		[]byte(`{"a":1,"b":{"c":[1,3],"d":"x"},"e":[1,2],"f":true}`))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b.c[1]", "e", "f"}, differences)
+
+	differences, err = diffJSON([]byte(`{"a":[{"b":1}]}`), []byte(`{"a":[{"b":1}]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(differences))
+}
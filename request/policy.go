@@ -0,0 +1,42 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package request
+
+import (
+	"reflect"
+	"strings"
+)
+
+// serviceName returns the name of the service of the operation, like "LoadBalancer"
+// for the operations with LoadBalancerServiceProperties, to look up its RequestPolicy.
+func (r *Request) serviceName() string {
+	if r.Operation.ServiceName != "" {
+		return r.Operation.ServiceName
+	}
+	t := reflect.TypeOf(r.Operation.Properties)
+	if t == nil {
+		return ""
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	name := t.Name()
+	if !strings.HasSuffix(name, "ServiceProperties") || name == "QingCloudServiceProperties" {
+		return ""
+	}
+	return strings.TrimSuffix(name, "ServiceProperties")
+}
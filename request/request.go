@@ -17,15 +17,20 @@
 package request
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"reflect"
-	"time"
+	"strings"
+	"syscall"
 
+	"github.com/yunify/qingcloud-sdk-go/config"
 	"github.com/yunify/qingcloud-sdk-go/logger"
 	"github.com/yunify/qingcloud-sdk-go/request/data"
+	qcErrors "github.com/yunify/qingcloud-sdk-go/request/errors"
 	"github.com/yunify/qingcloud-sdk-go/utils"
 )
 
@@ -37,6 +42,9 @@ type Request struct {
 
 	HTTPRequest  *http.Request
 	HTTPResponse *http.Response
+
+	// Attempts is the history of sending HTTPRequest.
+	Attempts []qcErrors.Attempt
 }
 
 // DefaultCredentialProxyHost is default credential proxy host
@@ -67,7 +75,8 @@ type TokenOutput struct {
 func New(o *data.Operation, i data.Input, x interface{}) (*Request, error) {
 	input := reflect.ValueOf(i)
 	if input.Elem().IsValid() {
-		err := i.Validate()
+		input = injectZone(o, input)
+		err := input.Interface().(data.Input).Validate()
 		if err != nil {
 			return nil, err
 		}
@@ -84,29 +93,63 @@ func New(o *data.Operation, i data.Input, x interface{}) (*Request, error) {
 // Send sends API request.
 // It returns error if error occurred.
 func (r *Request) Send() error {
+	start := r.Operation.Config.GetClock().Now()
+	err := r.sendRequest()
+	r.audit(start, err)
+	r.mirror(err)
+	return err
+}
+
+func (r *Request) sendRequest() error {
 	err := r.check()
 	if err != nil {
 		return err
 	}
 
+	err = r.validateZone()
+	if err != nil {
+		return err
+	}
+
 	err = r.build()
 	if err != nil {
 		return err
 	}
 
+	var flightKey string
+	if r.deduplicated() {
+		flightKey = r.flightKey()
+	}
+
 	err = r.sign()
 	if err != nil {
 		return err
 	}
 
-	err = r.send()
+	ctx := r.Operation.Config.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timeout := r.Operation.Config.ActionTimeout(r.Operation.APIName)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	r.HTTPRequest = r.HTTPRequest.WithContext(ctx)
+
+	if flightKey != "" {
+		err = r.sendShared(flightKey)
+	} else {
+		err = r.send()
+	}
 	if err != nil {
 		return err
 	}
 
 	err = r.unpack()
 	if err != nil {
-		return err
+		return r.attachAttempts(err)
 	}
 
 	return nil
@@ -114,14 +157,13 @@ func (r *Request) Send() error {
 
 func (r *Request) check() error {
 	if r.Operation.Config.AccessKeyID == "" && r.Operation.Config.SecretAccessKey == "" || r.Operation.Config.URI == "/iam" && r.isTokenExpired() {
-		t := TokenOutput{}
-		err := t.GetToken(r.getCredentialProxyURL())
+		t, err := r.credentialToken()
 
 		if err != nil {
 			return err
 		}
-		r.Operation.Config.AccessKeyID = t.AccessKey
-		r.Operation.Config.SecretAccessKey = t.SecretAccess
+		r.Operation.Config.AccessKeyID = t.AccessKeyID
+		r.Operation.Config.SecretAccessKey = t.SecretAccessKey
 		r.Operation.Config.URI = "/iam"
 		r.Operation.Config.Token = t.Token
 		r.Operation.Config.Expiration = t.Expiration
@@ -170,27 +212,58 @@ func (r *Request) send() error {
 		return errors.New("connection not initialized")
 	}
 
+	clock := r.Operation.Config.GetClock()
+	policy := r.Operation.Config.RequestPolicy(r.serviceName(), r.Operation.APIName)
 	retries := r.Operation.Config.ConnectionRetries + 1
+	if policy != nil && policy.Retries != nil {
+		retries = *policy.Retries + 1
+	}
+	backoff := policy.RetryBackoff()
+	// an action which only reads resources may be sent twice safely, the idle
+	// connection retry is only allowed for it and only if retries are enabled
+	idleRetried := retries <= 1 || !config.IsDescribeAction(r.Operation.APIName)
 	for {
 		if retries > 0 {
+			if err := policy.Wait(r.HTTPRequest.Context(), clock); err != nil {
+				return r.requestError(err)
+			}
 			logger.Info(fmt.Sprintf(
 				"Sending request: [%d] %s",
 				utils.StringToUnixInt(r.HTTPRequest.Header.Get("Date"), "RFC 822"),
 				r.HTTPRequest.Host))
 
+			start := clock.Now()
 			response, err = r.Operation.Config.Connection.Do(r.HTTPRequest)
+			r.recordAttempt(start, response, err)
 			if err == nil {
 				retries = 0
+			} else if !idleRetried && isIdleConnectionError(err) {
+				// the server closed the kept-alive connection before reading the request,
+				// retry once at once on a new connection without counting a retry
+				logger.Warn(fmt.Sprintf("Retrying request on idle connection error: %s", err))
+				idleRetried = true
+				if err := r.rewindBody(); err != nil {
+					return err
+				}
+			} else if r.HTTPRequest.Context().Err() != nil {
+				// the request is canceled or timed out, retrying can not succeed
+				retries = 0
 			} else {
 				retries--
-				time.Sleep(time.Second)
+				if retries > 0 {
+					r.recordBackoff(backoff)
+				}
+				clock.Sleep(backoff)
+				if err := r.rewindBody(); err != nil {
+					return err
+				}
 			}
 		} else {
 			break
 		}
 	}
 	if err != nil {
-		return err
+		return r.requestError(err)
 	}
 
 	r.HTTPResponse = response
@@ -198,6 +271,30 @@ func (r *Request) send() error {
 	return nil
 }
 
+// rewindBody resets the body of the request to send it again.
+func (r *Request) rewindBody() error {
+	if r.HTTPRequest.GetBody == nil {
+		return nil
+	}
+	body, err := r.HTTPRequest.GetBody()
+	if err != nil {
+		return err
+	}
+	r.HTTPRequest.Body = body
+	return nil
+}
+
+// isIdleConnectionError checks whether the request failed since the
+// connection was closed or reset before any byte of the response was read.
+func isIdleConnectionError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	message := err.Error()
+	return strings.Contains(message, "connection reset by peer") ||
+		strings.Contains(message, "server closed idle connection")
+}
+
 func (r *Request) unpack() error {
 	u := &Unpacker{}
 	err := u.UnpackHTTPRequest(r.Operation, r.HTTPResponse, r.Output)
@@ -231,7 +328,7 @@ func (r *Request) isTokenExpired() bool {
 		return true
 	}
 
-	now := time.Now().UTC().Unix()
+	now := r.Operation.Config.GetClock().Now().UTC().Unix()
 
 	return now >= r.Operation.Config.Expiration
 }
@@ -0,0 +1,368 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package request
+
+import (
+	"compress/gzip"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/request/data"
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+func newTestConfig(t *testing.T, server *httptest.Server) *config.Config {
+	serverURL, _ := url.Parse(server.URL)
+	host, port, _ := net.SplitHostPort(serverURL.Host)
+	conf, err := config.New("AccessKeyID", "SecretAccessKey")
+	assert.Nil(t, err)
+	conf.Protocol = "http"
+	conf.Host = host
+	conf.Port, _ = strconv.Atoi(port)
+	conf.ConnectionRetries = 0
+	return conf
+}
+
+func TestRequestActionTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("action") == "CaptureInstance" {
+			time.Sleep(1200 * time.Millisecond)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ret_code":0}`))
+	}))
+	defer server.Close()
+
+	conf := newTestConfig(t, server)
+	conf.Timeouts = config.Timeouts{Describe: 1, Mutate: 1, LongRunning: 1}
+	send := func(action string) error {
+		r, err := New(&data.Operation{
+			Config:        conf,
+			Properties:    &InstanceServiceProperties{Zone: String("beta")},
+			APIName:       action,
+			RequestMethod: "GET",
+		}, &RunInstancesInput{}, &RunInstancesOutput{})
+		assert.Nil(t, err)
+		return r.Send()
+	}
+
+	assert.Nil(t, send("RunInstances"))
+	assert.NotNil(t, send("CaptureInstance"))
+}
+
+func TestRequestDeduplication(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ret_code":0,"message":"shared"}`))
+	}))
+	defer server.Close()
+
+	conf := newTestConfig(t, server)
+	conf.DeduplicateRequests = true
+	send := func(action string, zone string) *RunInstancesOutput {
+		output := &RunInstancesOutput{}
+		r, err := New(&data.Operation{
+			Config:        conf,
+			Properties:    &InstanceServiceProperties{Zone: String(zone)},
+			APIName:       action,
+			RequestMethod: "GET",
+		}, &RunInstancesInput{}, output)
+		assert.Nil(t, err)
+		assert.Nil(t, r.Send())
+		return output
+	}
+	sendConcurrently := func(action string, zones ...string) []*RunInstancesOutput {
+		atomic.StoreInt32(&calls, 0)
+		outputs := make([]*RunInstancesOutput, len(zones))
+		wg := sync.WaitGroup{}
+		for i, zone := range zones {
+			wg.Add(1)
+			go func(i int, zone string) {
+				defer wg.Done()
+				outputs[i] = send(action, zone)
+			}(i, zone)
+		}
+		wg.Wait()
+		return outputs
+	}
+
+	outputs := sendConcurrently("DescribeInstances", "pek3a", "pek3a", "pek3a", "pek3a")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, output := range outputs {
+		assert.Equal(t, "shared", StringValue(output.Message))
+	}
+	assert.False(t, outputs[0] == outputs[1])
+
+	sendConcurrently("DescribeInstances", "pek3a", "gd2")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	sendConcurrently("RunInstances", "pek3a", "pek3a")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	conf.DeduplicateRequests = false
+	sendConcurrently("DescribeInstances", "pek3a", "pek3a")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRequestRetryIdleConnectionError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// close the connection without responding, like an idle timeout of the gateway
+			conn, _, err := w.(http.Hijacker).Hijack()
+			assert.Nil(t, err)
+			conn.Close()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ret_code":0}`))
+	}))
+	defer server.Close()
+
+	conf := newTestConfig(t, server)
+	clock := utils.NewFakeClock(time.Now())
+	conf.Clock = clock
+	send := func(action, method string) error {
+		atomic.StoreInt32(&calls, 0)
+		r, err := New(&data.Operation{
+			Config:        conf,
+			Properties:    &InstanceServiceProperties{Zone: String("beta")},
+			APIName:       action,
+			RequestMethod: method,
+		}, &RunInstancesInput{}, &RunInstancesOutput{})
+		assert.Nil(t, err)
+		return r.Send()
+	}
+
+	// no retry at all without connection retries
+	assert.NotNil(t, send("DescribeInstances", "GET"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.NotNil(t, send("RunInstances", "POST"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// a describe action is retried at once, others wait for the backoff
+	conf.ConnectionRetries = 1
+	slept := clock.Slept()
+	assert.Nil(t, send("DescribeInstances", "GET"))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Nil(t, send("DescribeInstances", "POST"))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, slept, clock.Slept())
+
+	assert.Nil(t, send("RunInstances", "POST"))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.True(t, clock.Slept() > slept)
+
+	assert.True(t, isIdleConnectionError(io.EOF))
+	assert.True(t, isIdleConnectionError(&url.Error{Op: "Get", URL: "/", Err: io.EOF}))
+	assert.False(t, isIdleConnectionError(context.DeadlineExceeded))
+}
+
+func TestRequestAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ret_code":1400,"message":"PermissionDenied"}`))
+	}))
+
+	conf := newTestConfig(t, server)
+	send := func() error {
+		r, err := New(&data.Operation{
+			Config:        conf,
+			Properties:    &InstanceServiceProperties{Zone: String("beta")},
+			APIName:       "RunInstances",
+			RequestMethod: "GET",
+		}, &RunInstancesInput{}, &RunInstancesOutput{})
+		assert.Nil(t, err)
+		return r.Send()
+	}
+
+	err := send()
+	qcErr, ok := err.(*errors.QingCloudError)
+	assert.True(t, ok)
+	assert.Equal(t, 1, len(qcErr.Attempts))
+	assert.Equal(t, 200, qcErr.Attempts[0].StatusCode)
+	assert.Equal(t, 1400, qcErr.Attempts[0].RetCode)
+	assert.Equal(t, conf.Host+":"+strconv.Itoa(conf.Port), qcErr.Attempts[0].Endpoint)
+
+	server.Close()
+	conf.ConnectionRetries = 1
+	err = send()
+	requestErr, ok := err.(*errors.RequestError)
+	assert.True(t, ok)
+	assert.Equal(t, "RunInstances", requestErr.APIName)
+	assert.Equal(t, 2, len(requestErr.Attempts))
+	assert.Equal(t, time.Second, requestErr.Attempts[0].Backoff)
+	assert.Equal(t, time.Duration(0), requestErr.Attempts[1].Backoff)
+	assert.Equal(t, 0, requestErr.Attempts[1].StatusCode)
+	assert.NotEqual(t, "", requestErr.Attempts[1].Error)
+	assert.Contains(t, requestErr.Error(), "backoff 1s")
+	assert.NotNil(t, requestErr.Unwrap())
+	// the transport error is still found through the RequestError
+	var urlErr *url.Error
+	assert.True(t, stderrors.As(err, &urlErr))
+	var netErr net.Error
+	assert.True(t, stderrors.As(err, &netErr))
+}
+
+func TestRequestTokenCache(t *testing.T) {
+	tokenRequests := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == DefaultCredentialProxyURI {
+			atomic.AddInt32(&tokenRequests, 1)
+			w.Write([]byte(`{"access_key":"TokenAccessKeyID","secret_key":"TokenSecretAccessKey",` +
+				`"id_token":"Token","expiration":` + strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10) + `}`))
+			return
+		}
+		assert.Equal(t, "/iam", r.URL.Path)
+		assert.Equal(t, "TokenAccessKeyID", r.URL.Query().Get("access_key_id"))
+		assert.Equal(t, "Token", r.URL.Query().Get("token"))
+		w.Write([]byte(`{"ret_code":0}`))
+	}))
+	defer server.Close()
+
+	cache := config.NewMemoryTokenCache()
+	for i := 0; i < 2; i++ {
+		conf := newTestConfig(t, server)
+		conf.AccessKeyID, conf.SecretAccessKey = "", ""
+		conf.CredentialProxyProtocol = "http"
+		conf.CredentialProxyHost = conf.Host
+		conf.CredentialProxyPort = conf.Port
+		conf.TokenCache = cache
+		r, err := New(&data.Operation{
+			Config:        conf,
+			Properties:    &InstanceServiceProperties{Zone: String("beta")},
+			APIName:       "RunInstances",
+			RequestMethod: "GET",
+		}, &RunInstancesInput{}, &RunInstancesOutput{})
+		assert.Nil(t, err)
+		assert.Nil(t, r.Send())
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokenRequests))
+}
+
+func TestRequestRetriesWithClock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	conf := newTestConfig(t, server)
+	server.Close()
+	clock := utils.NewFakeClock(time.Now())
+	conf.Clock = clock
+	conf.ConnectionRetries = 3
+
+	r, err := New(&data.Operation{
+		Config:        conf,
+		Properties:    &InstanceServiceProperties{Zone: String("beta")},
+		APIName:       "RunInstances",
+		RequestMethod: "GET",
+	}, &RunInstancesInput{}, &RunInstancesOutput{})
+	assert.Nil(t, err)
+	began := time.Now()
+	assert.NotNil(t, r.Send())
+	assert.True(t, time.Since(began) < time.Second)
+	assert.Equal(t, 4, len(r.Attempts))
+	assert.Equal(t, 4*time.Second, clock.Slept())
+}
+
+func TestRequestPolicyOverridesRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	conf := newTestConfig(t, server)
+	server.Close()
+	clock := utils.NewFakeClock(time.Now())
+	conf.Clock = clock
+	conf.ConnectionRetries = 3
+	conf.RequestPolicies = map[string]*config.RequestPolicy{
+		"Instance": {Retries: Int(1), RetryInterval: 0.2},
+	}
+
+	send := func(properties interface{}) *Request {
+		r, err := New(&data.Operation{
+			Config:        conf,
+			Properties:    properties,
+			APIName:       "RunInstances",
+			RequestMethod: "GET",
+		}, &RunInstancesInput{}, &RunInstancesOutput{})
+		assert.Nil(t, err)
+		assert.NotNil(t, r.Send())
+		return r
+	}
+
+	r := send(&InstanceServiceProperties{Zone: String("beta")})
+	assert.Equal(t, "Instance", r.serviceName())
+	assert.Equal(t, 2, len(r.Attempts))
+	assert.Equal(t, 200*time.Millisecond, r.Attempts[0].Backoff)
+	assert.Equal(t, 400*time.Millisecond, clock.Slept())
+
+	conf.RequestPolicies["Instance"].RateLimit = 1
+	r = send(&InstanceServiceProperties{Zone: String("beta")})
+	assert.Equal(t, 2, len(r.Attempts))
+	assert.Equal(t, 400*time.Millisecond+200*time.Millisecond+800*time.Millisecond+200*time.Millisecond, clock.Slept())
+}
+
+func TestRequestDisableCompression(t *testing.T) {
+	encodings := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Accept-Encoding"))
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Header().Set("Content-Encoding", "gzip")
+			writer := gzip.NewWriter(w)
+			writer.Write([]byte(`{"action":"RunInstancesResponse","ret_code":0}`))
+			writer.Close()
+			return
+		}
+		w.Write([]byte(`{"action":"RunInstancesResponse","ret_code":0}`))
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	host, port, _ := net.SplitHostPort(serverURL.Host)
+	for _, disabled := range []bool{false, true} {
+		conf, err := config.NewDefault()
+		assert.Nil(t, err)
+		err = conf.LoadConfigFromContent([]byte(fmt.Sprintf(
+			"qy_access_key_id: AccessKeyID\nqy_secret_access_key: SecretAccessKey\nprotocol: http\nhost: %s\nport: %s\ndisable_compression: %t\n",
+			host, port, disabled)))
+		assert.Nil(t, err)
+		r, err := New(&data.Operation{
+			Config:        conf,
+			Properties:    &InstanceServiceProperties{Zone: String("beta")},
+			APIName:       "RunInstances",
+			RequestMethod: "GET",
+		}, &RunInstancesInput{}, &RunInstancesOutput{})
+		assert.Nil(t, err)
+		assert.Nil(t, r.Send())
+	}
+	assert.Equal(t, []string{"gzip", ""}, encodings)
+}
@@ -23,9 +23,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/yunify/qingcloud-sdk-go/logger"
 	"github.com/yunify/qingcloud-sdk-go/utils"
@@ -55,6 +57,7 @@ func (is *Signer) WriteSignature(request *http.Request) error {
 	request.URL = newRequest.URL
 	request.Body = newRequest.Body
 	request.ContentLength = newRequest.ContentLength
+	request.GetBody = newRequest.GetBody
 
 	logger.Info(fmt.Sprintf(
 		"Signed QingCloud request: [%d] %s",
@@ -103,13 +106,17 @@ func (is *Signer) BuildStringToSign(request *http.Request) (string, error) {
 
 // BuildStringToSignByValues build the string to sign.
 func (is *Signer) BuildStringToSignByValues(requestDate string, requestMethod string, requestPath string, requestParams url.Values) (string, error) {
+	err := normalizeParams(requestParams)
+	if err != nil {
+		return "", err
+	}
+
 	requestParams.Set("access_key_id", is.AccessKeyID)
 	requestParams.Set("signature_method", "HmacSHA256")
 	requestParams.Set("signature_version", "1")
 
 	var timeValue time.Time
 	if requestDate != "" {
-		var err error
 		timeValue, err = utils.StringToTime(requestDate, "RFC 822")
 		if err != nil {
 			return "", err
@@ -161,3 +168,40 @@ func (is *Signer) BuildStringToSignByValues(requestDate string, requestMethod st
 
 	return stringToSign, nil
 }
+
+var offsetTimePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?)([ +-])(\d{2}:\d{2})$`)
+
+// normalizeParams checks and normalizes the parameter values which commonly
+// break the signature before signing them.
+func normalizeParams(requestParams url.Values) error {
+	for key, values := range requestParams {
+		for index, value := range values {
+			if !utf8.ValidString(value) {
+				return fmt.Errorf("parameter \"%s\" is not valid UTF-8", key)
+			}
+			if strings.ContainsRune(value, 0) {
+				return fmt.Errorf("parameter \"%s\" contains NUL character", key)
+			}
+			values[index] = normalizeTimeValue(value)
+		}
+	}
+	return nil
+}
+
+// normalizeTimeValue converts the time with zone offset to UTC, the "+" of an
+// offset not encoded in URL is decoded as a space.
+func normalizeTimeValue(value string) string {
+	matches := offsetTimePattern.FindStringSubmatch(value)
+	if matches == nil {
+		return value
+	}
+	sign := matches[3]
+	if sign == " " {
+		sign = "+"
+	}
+	timeValue, err := time.Parse(time.RFC3339Nano, matches[1]+sign+matches[4])
+	if err != nil {
+		return value
+	}
+	return utils.TimeToString(timeValue, "ISO 8601")
+}
@@ -65,3 +65,47 @@ func TestSigner1(t *testing.T) {
 	assert.True(t, strings.Contains(
 		httpRequest.URL.String(), "signature=32bseYy39DOlatuewpeuW5vpmW51sD1A%2FJdGynqSpP8%3D"))
 }
+
+func TestSignerNormalizeParams(t *testing.T) {
+	sign := func(rawURL string) (string, string, error) {
+		httpRequest, err := http.NewRequest("GET", rawURL, nil)
+		assert.Nil(t, err)
+		httpRequest.Header.Set("Date", utils.TimeToString(time.Time{}, "RFC 822"))
+		s := Signer{
+			AccessKeyID:     "ENV_ACCESS_KEY_ID",
+			SecretAccessKey: "ENV_SECRET_ACCESS_KEY",
+		}
+		signature, err := s.BuildSignature(httpRequest)
+		return signature, s.BuiltURL, err
+	}
+
+	expected, builtURL, err := sign("https://api.qc.dev/iaas?action=GetMonitor&start_time=2017-03-21T07%3A00%3A00Z")
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(builtURL, "start_time=2017-03-21T07%3A00%3A00Z"))
+	for _, startTime := range []string{"2017-03-21T15:00:00+08:00", "2017-03-21T15%3A00%3A00%2B08%3A00", "2017-03-21T02:00:00-05:00"} {
+		signature, _, err := sign("https://api.qc.dev/iaas?action=GetMonitor&start_time=" + startTime)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, signature, startTime)
+	}
+
+	expected, builtURL, err = sign("https://api.qc.dev/iaas?action=DescribeInstances&search_word=web%20server")
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(builtURL, "search_word=web%20server"))
+	for _, searchWord := range []string{"web+server", "web%20server%20", "%20web%20server"} {
+		signature, _, err := sign("https://api.qc.dev/iaas?action=DescribeInstances&search_word=" + searchWord)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, signature, searchWord)
+	}
+
+	expected, builtURL, err = sign("https://api.qc.dev/iaas?action=ModifyInstanceAttributes&description=%E4%B8%BB%E6%9C%BA")
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(builtURL, "description=%E4%B8%BB%E6%9C%BA"))
+	signature, _, err := sign("https://api.qc.dev/iaas?action=ModifyInstanceAttributes&description=主机")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, signature)
+
+	_, _, err = sign("https://api.qc.dev/iaas?action=ModifyInstanceAttributes&description=%E4%B8")
+	assert.NotNil(t, err)
+	_, _, err = sign("https://api.qc.dev/iaas?action=ModifyInstanceAttributes&description=a%00b")
+	assert.NotNil(t, err)
+}
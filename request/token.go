@@ -0,0 +1,63 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package request
+
+import (
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/logger"
+	"github.com/yunify/qingcloud-sdk-go/request/data"
+)
+
+// ResolveCredentials loads the credentials of the credential proxy into the config as the
+// requests do, if the config has no access key or its token expired.
+func ResolveCredentials(conf *config.Config) error {
+	r := &Request{Operation: &data.Operation{Config: conf}}
+	return r.check()
+}
+
+// credentialToken returns the token of the credential proxy from the token
+// cache of the config, the token is requested and cached if it is missing or expired.
+func (r *Request) credentialToken() (*config.CachedToken, error) {
+	cache := r.Operation.Config.TokenCache
+	if cache == nil {
+		cache = config.DefaultTokenCache
+	}
+	key := r.getCredentialProxyURL()
+
+	cached, err := cache.Get(key)
+	if err != nil {
+		logger.Warn("Get cached token error: %s", err)
+	} else if cached != nil && !cached.Expired(r.Operation.Config.GetClock().Now()) {
+		return cached, nil
+	}
+
+	t := TokenOutput{}
+	err = t.GetToken(key)
+	if err != nil {
+		return nil, err
+	}
+	token := &config.CachedToken{
+		AccessKeyID:     t.AccessKey,
+		SecretAccessKey: t.SecretAccess,
+		Token:           t.Token,
+		Expiration:      t.Expiration,
+	}
+	if err := cache.Set(key, token); err != nil {
+		logger.Warn("Cache token error: %s", err)
+	}
+	return token, nil
+}
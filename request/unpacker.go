@@ -18,9 +18,13 @@ package request
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/yunify/qingcloud-sdk-go/logger"
@@ -29,6 +33,9 @@ import (
 	"github.com/yunify/qingcloud-sdk-go/utils"
 )
 
+// DefaultMaxResponseSize is the default size limit of responses in bytes
+const DefaultMaxResponseSize = 64 << 20
+
 // Unpacker is the response unpacker.
 type Unpacker struct {
 	operation *data.Operation
@@ -57,25 +64,27 @@ func (u *Unpacker) UnpackHTTPRequest(o *data.Operation, r *http.Response, x *ref
 }
 
 func (u *Unpacker) parseResponse() error {
+	if u.httpResponse == nil {
+		return fmt.Errorf("http response is nil point")
+	}
 	if u.httpResponse.StatusCode == 200 {
 		var resp = u.httpResponse
-		if resp == nil {
-			return fmt.Errorf("http response is nil point")
-		}
 
 		var contentType = resp.Header.Get("Content-Type")
 
 		if strings.HasPrefix(contentType, "application/json") {
 			buffer := &bytes.Buffer{}
-			buffer.ReadFrom(u.httpResponse.Body)
-			u.httpResponse.Body.Close()
+			err := u.readBody(buffer)
+			if err != nil {
+				return err
+			}
 
 			logger.Info(fmt.Sprintf(
 				"Response json string: [%d] %s",
 				utils.StringToUnixInt(u.httpResponse.Header.Get("Date"), "RFC 822"),
 				string(buffer.Bytes())))
 
-			_, err := utils.JSONDecode(buffer.Bytes(), u.output.Interface())
+			err = u.decodeResponse(buffer.Bytes())
 			if err != nil {
 				return err
 			}
@@ -94,6 +103,143 @@ func (u *Unpacker) parseResponse() error {
 	return nil
 }
 
+func (u *Unpacker) readBody(buffer *bytes.Buffer) error {
+	defer u.httpResponse.Body.Close()
+
+	body := io.Reader(u.httpResponse.Body)
+	if strings.EqualFold(u.httpResponse.Header.Get("Content-Encoding"), "gzip") {
+		reader, err := gzip.NewReader(u.httpResponse.Body)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		body = reader
+	}
+
+	limit := u.maxResponseSize()
+	if limit < 0 {
+		_, err := buffer.ReadFrom(body)
+		return err
+	}
+
+	_, err := buffer.ReadFrom(io.LimitReader(body, limit+1))
+	if err != nil {
+		return err
+	}
+	if int64(buffer.Len()) > limit {
+		err := errors.ResponseTooLargeError{
+			APIName: u.operation.APIName,
+			Limit:   limit,
+		}
+		logger.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+func (u *Unpacker) maxResponseSize() int64 {
+	if u.operation.Config == nil || u.operation.Config.MaxResponseSize == 0 {
+		return DefaultMaxResponseSize
+	}
+	return u.operation.Config.MaxResponseSize
+}
+
+func (u *Unpacker) decodeResponse(content []byte) error {
+	useNumber := u.operation.Config != nil && u.operation.Config.UseJSONNumber
+	var err error
+	if useNumber {
+		err = utils.JSONDecodeUseNumber(content, u.output.Interface())
+	} else {
+		_, err = utils.JSONDecode(content, u.output.Interface())
+	}
+	if err != nil {
+		if _, ok := err.(*json.SyntaxError); ok {
+			return err
+		}
+		if u.operation.Config != nil && u.operation.Config.StrictDecoding {
+			return err
+		}
+
+		// Responses of other platform versions may return fields in a type
+		// the output does not expect, decode the rest of them anyway.
+		u.output.Elem().Set(reflect.Zero(u.output.Elem().Type()))
+		var skipped []string
+		if useNumber {
+			skipped, err = utils.JSONDecodeLenientUseNumber(content, u.output.Interface())
+		} else {
+			skipped, err = utils.JSONDecodeLenient(content, u.output.Interface())
+		}
+		if err != nil {
+			return err
+		}
+		logger.Warn(fmt.Sprintf(
+			"Response of [%s] fields skipped: %s",
+			u.operation.APIName, strings.Join(skipped, ", ")))
+	}
+
+	normalizeOutput(u.output.Elem())
+	return nil
+}
+
+// normalizeOutput fills the absent fields that have a default tag, so fields
+// missing from the responses of older platform versions get a usable value.
+// The null items of lists are dropped, so the items never need nil checks.
+func normalizeOutput(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if !value.IsNil() {
+			normalizeOutput(value.Elem())
+		}
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Ptr {
+			items := reflect.MakeSlice(value.Type(), 0, value.Len())
+			for i := 0; i < value.Len(); i++ {
+				if !value.Index(i).IsNil() {
+					items = reflect.Append(items, value.Index(i))
+				}
+			}
+			if items.Len() != value.Len() && value.CanSet() {
+				value.Set(items)
+			}
+		}
+		for i := 0; i < value.Len(); i++ {
+			normalizeOutput(value.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			tagDefault := value.Type().Field(i).Tag.Get("default")
+			if tagDefault == "" || field.Kind() != reflect.Ptr || !field.IsNil() {
+				normalizeOutput(field)
+				continue
+			}
+			switch field.Interface().(type) {
+			case *string:
+				field.Set(reflect.ValueOf(&tagDefault))
+			case *int:
+				if v, err := strconv.Atoi(tagDefault); err == nil {
+					field.Set(reflect.ValueOf(&v))
+				}
+			case *int64:
+				if v, err := strconv.ParseInt(tagDefault, 10, 64); err == nil {
+					field.Set(reflect.ValueOf(&v))
+				}
+			case *uint64:
+				if v, err := strconv.ParseUint(tagDefault, 10, 64); err == nil {
+					field.Set(reflect.ValueOf(&v))
+				}
+			case *bool:
+				if v, err := strconv.ParseBool(tagDefault); err == nil {
+					field.Set(reflect.ValueOf(&v))
+				}
+			}
+		}
+	}
+}
+
 func (u *Unpacker) parseError() error {
 	if u.output.IsNil() {
 		return fmt.Errorf("nil returned")
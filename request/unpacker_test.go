@@ -18,6 +18,9 @@ package request
 
 import (
 	"bytes"
+	"compress/gzip"
+	stderrors "errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"reflect"
@@ -26,6 +29,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
 	"github.com/yunify/qingcloud-sdk-go/request/data"
 	"github.com/yunify/qingcloud-sdk-go/request/errors"
 )
@@ -124,6 +128,101 @@ func TestUnpackerUnpackHTTPRequest(t *testing.T) {
 	assert.Equal(t, statusTime, TimeValue(output.VolumeSet[0].StatusTime))
 }
 
+func TestUnpacker_UnpackHTTPRequestWithGzip(t *testing.T) {
+	type DescribeInstanceTypesOutput struct {
+		RetCode *int    `json:"ret_code" name:"ret_code"`
+		Message *string `json:"message" name:"message"`
+		Action  *string `json:"action" name:"action"`
+	}
+
+	compressed := &bytes.Buffer{}
+	writer := gzip.NewWriter(compressed)
+	writer.Write([]byte(`{"action":"DescribeInstanceTypesResponse","ret_code":0}`))
+	writer.Close()
+
+	httpResponse := &http.Response{Header: http.Header{}}
+	httpResponse.StatusCode = 200
+	httpResponse.Header.Set("Content-Type", "application/json")
+	httpResponse.Header.Set("Content-Encoding", "gzip")
+	httpResponse.Body = ioutil.NopCloser(bytes.NewReader(compressed.Bytes()))
+
+	output := &DescribeInstanceTypesOutput{}
+	outputValue := reflect.ValueOf(output)
+	unpacker := Unpacker{}
+	err := unpacker.UnpackHTTPRequest(&data.Operation{}, httpResponse, &outputValue)
+	assert.Nil(t, err)
+	assert.Equal(t, "DescribeInstanceTypesResponse", StringValue(output.Action))
+
+	httpResponse.Body = ioutil.NopCloser(bytes.NewReader([]byte("not gzip")))
+	err = unpacker.UnpackHTTPRequest(&data.Operation{}, httpResponse, &outputValue)
+	assert.NotNil(t, err)
+}
+
+func TestUnpacker_UnpackHTTPRequestTooLarge(t *testing.T) {
+	type DescribeInstanceTypesOutput struct {
+		RetCode *int    `json:"ret_code" name:"ret_code"`
+		Message *string `json:"message" name:"message"`
+		Action  *string `json:"action" name:"action"`
+	}
+	responseString := `{"action":"DescribeInstanceTypesResponse","ret_code":0}`
+
+	for _, limit := range []int64{int64(len(responseString)), -1, 10} {
+		httpResponse := &http.Response{Header: http.Header{}}
+		httpResponse.StatusCode = 200
+		httpResponse.Header.Set("Content-Type", "application/json")
+		httpResponse.Body = ioutil.NopCloser(bytes.NewReader([]byte(responseString)))
+
+		output := &DescribeInstanceTypesOutput{}
+		outputValue := reflect.ValueOf(output)
+		unpacker := Unpacker{}
+		operation := &data.Operation{
+			APIName: "DescribeInstanceTypes",
+			Config:  &config.Config{MaxResponseSize: limit},
+		}
+		err := unpacker.UnpackHTTPRequest(operation, httpResponse, &outputValue)
+		if limit == 10 {
+			assert.Equal(t, errors.ResponseTooLargeError{APIName: "DescribeInstanceTypes", Limit: 10}, err)
+			continue
+		}
+		assert.Nil(t, err)
+		assert.Equal(t, "DescribeInstanceTypesResponse", StringValue(output.Action))
+	}
+}
+
+func TestUnpacker_UnpackHTTPRequestWithNullItems(t *testing.T) {
+	type Volume struct {
+		VolumeID *string `json:"volume_id" name:"volume_id"`
+	}
+	type Instance struct {
+		InstanceID *string   `json:"instance_id" name:"instance_id"`
+		Volumes    []*Volume `json:"volumes" name:"volumes"`
+	}
+	type DescribeInstancesOutput struct {
+		Message     *string     `json:"message" name:"message"`
+		InstanceSet []*Instance `json:"instance_set" name:"instance_set" location:"elements"`
+		RetCode     *int        `json:"ret_code" name:"ret_code" location:"elements"`
+	}
+
+	httpResponse := &http.Response{Header: http.Header{}}
+	httpResponse.StatusCode = 200
+	httpResponse.Header.Set("Content-Type", "application/json")
+	httpResponse.Body = ioutil.NopCloser(bytes.NewReader([]byte(
+		`{"instance_set":[null,{"instance_id":"i-xxxxxxxx","volumes":[null,{"volume_id":"vol-xxxxxxxx"},null]}],"ret_code":0}`)))
+
+	output := &DescribeInstancesOutput{}
+	outputValue := reflect.ValueOf(output)
+	unpacker := Unpacker{}
+	err := unpacker.UnpackHTTPRequest(&data.Operation{}, httpResponse, &outputValue)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(output.InstanceSet))
+	assert.Equal(t, "i-xxxxxxxx", StringValue(output.InstanceSet[0].InstanceID))
+	assert.Equal(t, 1, len(output.InstanceSet[0].Volumes))
+	assert.Equal(t, "vol-xxxxxxxx", StringValue(output.InstanceSet[0].Volumes[0].VolumeID))
+
+	err = unpacker.UnpackHTTPRequest(&data.Operation{}, nil, &outputValue)
+	assert.NotNil(t, err)
+}
+
 func TestUnpacker_UnpackHTTPRequestWithError(t *testing.T) {
 	type DescribeInstanceTypesOutput struct {
 		RetCode *int    `json:"ret_code" name:"ret_code"`
@@ -150,6 +249,35 @@ func TestUnpacker_UnpackHTTPRequestWithError(t *testing.T) {
 	}
 }
 
+func TestUnpacker_UnpackHTTPRequestWithTransientConflict(t *testing.T) {
+	type StopInstancesOutput struct {
+		RetCode *int    `json:"ret_code" name:"ret_code"`
+		Message *string `json:"message" name:"message"`
+	}
+
+	httpResponse := &http.Response{Header: http.Header{}}
+	httpResponse.StatusCode = 200
+	httpResponse.Header.Set("Content-Type", "application/json")
+	responseString := `{
+  	  "message":"ResourceNotReady, instance [i-xxxxxxxx] is starting, please try later",
+  	  "ret_code":5200
+	}`
+	httpResponse.Body = ioutil.NopCloser(bytes.NewReader([]byte(responseString)))
+
+	output := &StopInstancesOutput{}
+	outputValue := reflect.ValueOf(output)
+	unpacker := Unpacker{}
+	err := unpacker.UnpackHTTPRequest(&data.Operation{}, httpResponse, &outputValue)
+	qcErr, ok := err.(*errors.QingCloudError)
+	assert.True(t, ok)
+	assert.Equal(t, errors.RetCodeResourceNotReady, qcErr.RetCode)
+	assert.True(t, qcErr.TransientConflict())
+	assert.True(t, stderrors.Is(err, errors.ErrTransientConflict))
+	assert.True(t, stderrors.Is(fmt.Errorf("stop: %w", err), errors.ErrTransientConflict))
+	assert.False(t, stderrors.Is(&errors.QingCloudError{RetCode: errors.RetCodePermissionDenied}, errors.ErrTransientConflict))
+	assert.False(t, errors.IsTransientConflict(errors.RetCodePermissionDenied))
+}
+
 func TestUnpacker_UnpackHTTPRequestWithWrongType2(t *testing.T) {
 	type DescribeInstanceTypesOutput struct {
 		RetCode *int    `json:"ret_code" name:"ret_code"`
@@ -196,3 +324,95 @@ func TestUnpacker_UnpackHTTPRequestWithWrongHTTPStatus(t *testing.T) {
 	}
 	println("err", err.Error())
 }
+
+func TestUnpacker_UnpackHTTPRequestCompatibility(t *testing.T) {
+	type Listener struct {
+		CreateTime         *time.Time `json:"create_time" name:"create_time" format:"ISO 8601"`
+		HealthyCheckOption *string    `json:"healthy_check_option" name:"healthy_check_option" default:"10|5|2|5"`
+		ListenerID         *string    `json:"loadbalancer_listener_id" name:"loadbalancer_listener_id"`
+		ListenerPort       *int       `json:"listener_port" name:"listener_port"`
+		Scene              *int       `json:"scene" name:"scene"`
+	}
+
+	type DescribeListenersOutput struct {
+		Message     *string     `json:"message" name:"message"`
+		Action      *string     `json:"action" name:"action" location:"elements"`
+		ListenerSet []*Listener `json:"loadbalancer_listener_set" name:"loadbalancer_listener_set" location:"elements"`
+		RetCode     *int        `json:"ret_code" name:"ret_code" location:"elements"`
+		TotalCount  *int        `json:"total_count" name:"total_count" location:"elements"`
+	}
+
+	testCases := []struct {
+		name          string
+		response      string
+		strict        bool
+		hasError      bool
+		port          int
+		scene         *int
+		createTimeSet bool
+	}{
+		{
+			name: "current",
+			response: `{"action":"DescribeLoadBalancerListenersResponse","total_count":1,"ret_code":0,
+				"loadbalancer_listener_set":[{"loadbalancer_listener_id":"lbl-1","listener_port":80,"scene":1,
+				"healthy_check_option":"10|5|2|5","create_time":"2013-08-30T05:13:25Z"}]}`,
+			port:          80,
+			scene:         Int(1),
+			createTimeSet: true,
+		},
+		{
+			name: "fields absent",
+			response: `{"action":"DescribeLoadBalancerListenersResponse","ret_code":0,
+				"loadbalancer_listener_set":[{"loadbalancer_listener_id":"lbl-1","listener_port":80}]}`,
+			port: 80,
+		},
+		{
+			name: "fields in other types",
+			response: `{"action":"DescribeLoadBalancerListenersResponse","total_count":"1","ret_code":0,
+				"loadbalancer_listener_set":[{"loadbalancer_listener_id":"lbl-1","listener_port":80,"scene":"",
+				"create_time":"2013-08-30 05:13:25"}]}`,
+			port: 80,
+		},
+		{
+			name: "fields in other types with strict decoding",
+			response: `{"action":"DescribeLoadBalancerListenersResponse","total_count":"1","ret_code":0,
+				"loadbalancer_listener_set":[{"loadbalancer_listener_id":"lbl-1","listener_port":80}]}`,
+			strict:   true,
+			hasError: true,
+		},
+		{
+			name:     "invalid json",
+			response: `{"action":"DescribeLoadBalancerListenersResponse",`,
+			hasError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		httpResponse := &http.Response{Header: http.Header{}}
+		httpResponse.StatusCode = 200
+		httpResponse.Header.Set("Content-Type", "application/json")
+		httpResponse.Body = ioutil.NopCloser(bytes.NewReader([]byte(testCase.response)))
+
+		output := &DescribeListenersOutput{}
+		outputValue := reflect.ValueOf(output)
+		unpacker := Unpacker{}
+		operation := &data.Operation{
+			APIName: "DescribeLoadBalancerListeners",
+			Config:  &config.Config{StrictDecoding: testCase.strict},
+		}
+		err := unpacker.UnpackHTTPRequest(operation, httpResponse, &outputValue)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.name)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.name) {
+			continue
+		}
+		listener := output.ListenerSet[0]
+		assert.Equal(t, "lbl-1", StringValue(listener.ListenerID), testCase.name)
+		assert.Equal(t, testCase.port, IntValue(listener.ListenerPort), testCase.name)
+		assert.Equal(t, "10|5|2|5", StringValue(listener.HealthyCheckOption), testCase.name)
+		assert.Equal(t, testCase.scene, listener.Scene, testCase.name)
+		assert.Equal(t, testCase.createTimeSet, listener.CreateTime != nil, testCase.name)
+	}
+}
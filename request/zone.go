@@ -0,0 +1,166 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package request
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/request/data"
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+)
+
+// ZoneCacheTTL is how long the zones an account can access are cached for zone validation.
+var ZoneCacheTTL = 10 * time.Minute
+
+type zoneCacheEntry struct {
+	zones   map[string]bool
+	expires time.Time
+}
+
+var zoneCache = struct {
+	sync.Mutex
+	entries map[string]*zoneCacheEntry
+}{entries: map[string]*zoneCacheEntry{}}
+
+type describeZonesProperties struct{}
+
+type describeZonesInput struct{}
+
+func (i *describeZonesInput) Validate() error {
+	return nil
+}
+
+type describeZonesOutput struct {
+	Message *string `json:"message" name:"message"`
+	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
+	ZoneSet []*struct {
+		ZoneID *string `json:"zone_id" name:"zone_id"`
+	} `json:"zone_set" name:"zone_set" location:"elements"`
+}
+
+// injectZone returns the input with the zone of the service or Config set into its Zone if it is nil.
+// The zone is set into a copy of the input, so that the input of the caller is never modified
+// and can be reused for the requests to other zones.
+func injectZone(o *data.Operation, input reflect.Value) reflect.Value {
+	field := input.Elem().FieldByName("Zone")
+	if !field.IsValid() || !field.CanSet() || field.Type().String() != "*string" || !field.IsNil() {
+		return input
+	}
+	zone := operationZone(o)
+	if zone == "" {
+		return input
+	}
+	copied := reflect.New(input.Elem().Type())
+	copied.Elem().Set(input.Elem())
+	copied.Elem().FieldByName("Zone").Set(reflect.ValueOf(&zone))
+	return copied
+}
+
+// operationZone returns the zone of the service, or the zone of Config if the service has no zone.
+func operationZone(o *data.Operation) string {
+	if o.Properties != nil {
+		properties := reflect.ValueOf(o.Properties)
+		if properties.Kind() == reflect.Ptr && properties.Elem().Kind() == reflect.Struct {
+			if field := properties.Elem().FieldByName("Zone"); field.IsValid() && field.Type().String() == "*string" && !field.IsNil() && field.Elem().String() != "" {
+				return field.Elem().String()
+			}
+		}
+	}
+	return o.Config.Zone
+}
+
+// requestZone returns the zone the request will be sent to.
+func (r *Request) requestZone() string {
+	if r.Input != nil && r.Input.Elem().IsValid() {
+		field := r.Input.Elem().FieldByName("Zone")
+		if field.IsValid() && field.Type().String() == "*string" && !field.IsNil() {
+			return field.Elem().String()
+		}
+	}
+	return operationZone(r.Operation)
+}
+
+// validateZone checks that the zone of the request is one the account can access, if enabled in Config.
+func (r *Request) validateZone() error {
+	if !r.Operation.Config.ValidateZone || r.Operation.APIName == "DescribeZones" {
+		return nil
+	}
+	zone := r.requestZone()
+	if zone == "" {
+		return nil
+	}
+	zones, err := accessibleZones(r.Operation)
+	if err != nil {
+		return err
+	}
+	if zones[zone] {
+		return nil
+	}
+	allowedValues := make([]string, 0, len(zones))
+	for z := range zones {
+		allowedValues = append(allowedValues, z)
+	}
+	sort.Strings(allowedValues)
+	return errors.ParameterValueNotAllowedError{
+		ParameterName:  "Zone",
+		ParameterValue: zone,
+		AllowedValues:  allowedValues,
+	}
+}
+
+// accessibleZones returns the cached zones the account can access, it calls DescribeZones if not cached or expired.
+func accessibleZones(o *data.Operation) (map[string]bool, error) {
+	c := o.Config
+	key := c.Protocol + "://" + c.Host + ":" + strconv.Itoa(c.Port) + c.URI + "|" + c.AccessKeyID
+
+	zoneCache.Lock()
+	entry, ok := zoneCache.entries[key]
+	zoneCache.Unlock()
+	if ok && c.GetClock().Now().Before(entry.expires) {
+		return entry.zones, nil
+	}
+
+	x := &describeZonesOutput{}
+	r, err := New(&data.Operation{
+		Config:        c,
+		Properties:    &describeZonesProperties{},
+		APIName:       "DescribeZones",
+		RequestMethod: "GET",
+	}, &describeZonesInput{}, x)
+	if err != nil {
+		return nil, err
+	}
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	zones := map[string]bool{}
+	for _, zone := range x.ZoneSet {
+		if zone != nil && zone.ZoneID != nil {
+			zones[*zone.ZoneID] = true
+		}
+	}
+	zoneCache.Lock()
+	zoneCache.entries[key] = &zoneCacheEntry{zones: zones, expires: c.GetClock().Now().Add(ZoneCacheTTL)}
+	zoneCache.Unlock()
+	return zones, nil
+}
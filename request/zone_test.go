@@ -0,0 +1,151 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package request
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/request/data"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+type RunInstancesInput struct {
+	ImageID *string `json:"image_id" name:"image_id" location:"params"`
+	Zone    *string `json:"zone" name:"zone" location:"params"`
+}
+
+func (i *RunInstancesInput) Validate() error {
+	return nil
+}
+
+type RunInstancesOutput struct {
+	Message *string `json:"message" name:"message"`
+	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
+}
+
+func TestInjectZone(t *testing.T) {
+	conf, err := config.NewDefault()
+	assert.Nil(t, err)
+	conf.Zone = "pek3a"
+
+	operation := &data.Operation{
+		Config:     conf,
+		Properties: &InstanceServiceProperties{Zone: String("beta")},
+	}
+	input := &RunInstancesInput{}
+	r, err := New(operation, input, &RunInstancesOutput{})
+	assert.Nil(t, err)
+	assert.Equal(t, "beta", *r.Input.Interface().(*RunInstancesInput).Zone)
+	assert.Nil(t, input.Zone)
+
+	input = &RunInstancesInput{Zone: String("gd2")}
+	r, err = New(operation, input, &RunInstancesOutput{})
+	assert.Nil(t, err)
+	assert.Equal(t, "gd2", *r.Input.Interface().(*RunInstancesInput).Zone)
+
+	operation.Properties = &InstanceServiceProperties{Zone: String("")}
+	input = &RunInstancesInput{}
+	r, err = New(operation, input, &RunInstancesOutput{})
+	assert.Nil(t, err)
+	assert.Equal(t, "pek3a", *r.Input.Interface().(*RunInstancesInput).Zone)
+	assert.Nil(t, input.Zone)
+}
+
+func TestInjectZoneReusedInput(t *testing.T) {
+	zones := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		zones = append(zones, r.URL.Query().Get("zone"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"action":"RunInstancesResponse","ret_code":0}`))
+	}))
+	defer server.Close()
+
+	conf, err := config.NewWithEndpoint("AccessKeyID", "SecretAccessKey", server.URL+"/iaas")
+	assert.Nil(t, err)
+	input := &RunInstancesInput{ImageID: String("img-1")}
+	for _, zone := range []string{"pek3a", "gd2"} {
+		r, err := New(&data.Operation{
+			Config:        conf,
+			Properties:    &InstanceServiceProperties{Zone: String(zone)},
+			APIName:       "RunInstances",
+			RequestMethod: "GET",
+		}, input, &RunInstancesOutput{})
+		assert.Nil(t, err)
+		assert.Nil(t, r.Send())
+	}
+	assert.Equal(t, []string{"pek3a", "gd2"}, zones)
+	assert.Nil(t, input.Zone)
+}
+
+func TestValidateZone(t *testing.T) {
+	describeZonesCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("action") == "DescribeZones" {
+			describeZonesCalls++
+			w.Write([]byte(`{"action":"DescribeZonesResponse","zone_set":[{"zone_id":"pek3a"},{"zone_id":"gd2"}],"ret_code":0}`))
+			return
+		}
+		w.Write([]byte(`{"action":"RunInstancesResponse","ret_code":0}`))
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	host, port, _ := net.SplitHostPort(serverURL.Host)
+	conf, err := config.New("AccessKeyID", "SecretAccessKey")
+	assert.Nil(t, err)
+	conf.Protocol = "http"
+	conf.Host = host
+	conf.Port, _ = strconv.Atoi(port)
+	conf.ValidateZone = true
+	clock := utils.NewFakeClock(time.Now())
+	conf.Clock = clock
+
+	send := func(zone string) error {
+		r, err := New(&data.Operation{
+			Config:        conf,
+			Properties:    &InstanceServiceProperties{Zone: String(zone)},
+			APIName:       "RunInstances",
+			RequestMethod: "GET",
+		}, &RunInstancesInput{}, &RunInstancesOutput{})
+		assert.Nil(t, err)
+		return r.Send()
+	}
+
+	assert.Nil(t, send("pek3a"))
+	assert.Nil(t, send("gd2"))
+	err = send("beta")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `"Zone" value "beta" is not allowed`)
+	assert.Equal(t, 1, describeZonesCalls)
+
+	// the zones are described again once the cache expires on the clock of the config
+	clock.Advance(ZoneCacheTTL - time.Second)
+	assert.Nil(t, send("pek3a"))
+	assert.Equal(t, 1, describeZonesCalls)
+	clock.Advance(2 * time.Second)
+	assert.Nil(t, send("pek3a"))
+	assert.Equal(t, 2, describeZonesCalls)
+}
@@ -123,11 +123,16 @@ func (s *AccesskeyService) DescribeAccessKeys(i *DescribeAccessKeysInput) (*Desc
 
 type DescribeAccessKeysInput struct {
 	AccessKeys []*string `json:"access_keys" name:"access_keys" location:"params"`
+	Console    *string   `json:"console" name:"console" location:"params"`
 	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
 	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
 	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	Verbose    *int      `json:"verbose" name:"verbose" default:"0" location:"params"`
 }
 
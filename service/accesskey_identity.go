@@ -0,0 +1,114 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yunify/qingcloud-sdk-go/request"
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+)
+
+// Access key statuses.
+const (
+	AccessKeyStatusActive   = "active"
+	AccessKeyStatusDisabled = "disabled"
+)
+
+// Permission scopes of the credentials.
+const (
+	// PermissionScopeRoot is the key of the root user, which can access all resources of the account.
+	PermissionScopeRoot = "root"
+	// PermissionScopeSubUser is the key of a sub user, which can only access the resources granted by the root user.
+	PermissionScopeSubUser = "sub_user"
+	// PermissionScopeRole is the temporary credentials of the credential proxy, limited to the role of the instance.
+	PermissionScopeRole = "role"
+)
+
+// Identity is the account the credentials of a Config belong to.
+type Identity struct {
+	AccessKeyID string
+	// UserID is the owner of the access key, a sub user or the root user.
+	UserID     string
+	RootUserID string
+	Status     string
+	// IPWhiteList are the addresses the access key can be used from, empty means anywhere.
+	IPWhiteList []string
+	// Scope is the PermissionScope of the credentials.
+	Scope string
+	// Temporary is true if the credentials are delegated by the credential proxy,
+	// such keys are not listed by DescribeAccessKeys and only AccessKeyID is known.
+	Temporary bool
+}
+
+// Active checks whether the access key can be used.
+func (v *Identity) Active() bool {
+	return v.Temporary || v.Status == AccessKeyStatusActive
+}
+
+// WhoAmI describes the access key the requests are signed with, it fails fast with
+// a clear message if the key is wrong, disabled or not permitted to call the API.
+func (s *AccesskeyService) WhoAmI() (*Identity, error) {
+	// the credentials of the credential proxy are loaded before the access key is described
+	if err := request.ResolveCredentials(s.Config); err != nil {
+		return nil, err
+	}
+	accessKeyID := s.Config.AccessKeyID
+	output, err := s.DescribeAccessKeys(&DescribeAccessKeysInput{
+		AccessKeys: []*string{String(accessKeyID)},
+	})
+	if err != nil {
+		if e, ok := errors.AsQingCloudError(err); ok {
+			switch e.RetCode {
+			case errors.RetCodeAuthenticationFail:
+				return nil, fmt.Errorf("access key [%s] is wrong or deleted: %s", accessKeyID, e.Message)
+			case errors.RetCodePermissionDenied:
+				return nil, fmt.Errorf("access key [%s] is not permitted to describe itself: %s", accessKeyID, e.Message)
+			}
+		}
+		return nil, err
+	}
+	if s.Config.URI == "/iam" {
+		return &Identity{AccessKeyID: accessKeyID, Scope: PermissionScopeRole, Temporary: true}, nil
+	}
+	for _, key := range output.AccessKeySet {
+		if StringValue(key.AccessKeyID) != accessKeyID {
+			continue
+		}
+		identity := &Identity{
+			AccessKeyID: accessKeyID,
+			UserID:      StringValue(key.Owner),
+			RootUserID:  StringValue(key.RootUserID),
+			Status:      StringValue(key.Status),
+			Scope:       PermissionScopeRoot,
+		}
+		if identity.UserID != "" && identity.RootUserID != "" && identity.UserID != identity.RootUserID {
+			identity.Scope = PermissionScopeSubUser
+		}
+		for _, ip := range strings.Split(StringValue(key.IPWhiteList), ",") {
+			if ip = strings.TrimSpace(ip); ip != "" {
+				identity.IPWhiteList = append(identity.IPWhiteList, ip)
+			}
+		}
+		if !identity.Active() {
+			return identity, fmt.Errorf("access key [%s] is %s", accessKeyID, identity.Status)
+		}
+		return identity, nil
+	}
+	return nil, fmt.Errorf("access key [%s] not found", accessKeyID)
+}
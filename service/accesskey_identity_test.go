@@ -0,0 +1,110 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/request"
+)
+
+func TestAccesskeyServiceWhoAmI(t *testing.T) {
+	response := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "AccessKeyID", r.URL.Query().Get("access_keys.1"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	conf, err := config.NewWithEndpoint("AccessKeyID", "SecretAccessKey", server.URL+"/iaas")
+	assert.Nil(t, err)
+	qcService, err := Init(conf)
+	assert.Nil(t, err)
+	accessKeyService, err := qcService.Accesskey("pek3")
+	assert.Nil(t, err)
+
+	response = `{"ret_code": 0, "total_count": 1, "access_key_set": [{"access_key_id": "AccessKeyID",
+		"owner": "usr-sub", "root_user_id": "usr-root", "status": "active", "ip_white_list": "10.0.0.0/8, 192.168.0.1"}]}`
+	identity, err := accessKeyService.WhoAmI()
+	assert.Nil(t, err)
+	assert.Equal(t, "usr-sub", identity.UserID)
+	assert.Equal(t, "usr-root", identity.RootUserID)
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.0.1"}, identity.IPWhiteList)
+	assert.Equal(t, PermissionScopeSubUser, identity.Scope)
+	assert.True(t, identity.Active())
+
+	response = `{"ret_code": 0, "total_count": 1, "access_key_set": [{"access_key_id": "AccessKeyID",
+		"owner": "usr-root", "root_user_id": "usr-root", "status": "active"}]}`
+	identity, err = accessKeyService.WhoAmI()
+	assert.Nil(t, err)
+	assert.Equal(t, PermissionScopeRoot, identity.Scope)
+
+	response = `{"ret_code": 0, "total_count": 1, "access_key_set": [{"access_key_id": "AccessKeyID", "status": "disabled"}]}`
+	identity, err = accessKeyService.WhoAmI()
+	assert.EqualError(t, err, "access key [AccessKeyID] is disabled")
+	assert.False(t, identity.Active())
+
+	response = `{"ret_code": 1200, "message": "AuthFailure"}`
+	_, err = accessKeyService.WhoAmI()
+	assert.EqualError(t, err, "access key [AccessKeyID] is wrong or deleted: AuthFailure")
+
+	response = `{"ret_code": 0, "total_count": 0, "access_key_set": []}`
+	_, err = accessKeyService.WhoAmI()
+	assert.EqualError(t, err, "access key [AccessKeyID] not found")
+}
+
+func TestAccesskeyServiceWhoAmICredentialProxy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == request.DefaultCredentialProxyURI {
+			w.Write([]byte(`{"access_key":"TokenAccessKeyID","secret_key":"TokenSecretAccessKey",` +
+				`"id_token":"Token","expiration":` + strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10) + `}`))
+			return
+		}
+		assert.Equal(t, "TokenAccessKeyID", r.URL.Query().Get("access_keys.1"))
+		w.Write([]byte(`{"ret_code": 0, "total_count": 0, "access_key_set": []}`))
+	}))
+	defer server.Close()
+
+	conf, err := config.NewWithEndpoint("", "", server.URL+"/iaas")
+	assert.Nil(t, err)
+	serverURL, _ := url.Parse(server.URL)
+	host, port, _ := net.SplitHostPort(serverURL.Host)
+	conf.CredentialProxyProtocol = "http"
+	conf.CredentialProxyHost = host
+	conf.CredentialProxyPort, _ = strconv.Atoi(port)
+	conf.TokenCache = config.NewMemoryTokenCache()
+	qcService, err := Init(conf)
+	assert.Nil(t, err)
+	accessKeyService, err := qcService.Accesskey("pek3")
+	assert.Nil(t, err)
+
+	identity, err := accessKeyService.WhoAmI()
+	assert.Nil(t, err)
+	assert.Equal(t, "TokenAccessKeyID", identity.AccessKeyID)
+	assert.Equal(t, PermissionScopeRole, identity.Scope)
+	assert.True(t, identity.Temporary)
+}
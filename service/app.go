@@ -132,6 +132,11 @@ type DescribeAppVersionAttachmentsInput struct {
 	AttachmentIDs []*string `json:"attachment_ids" name:"attachment_ids" location:"params"`
 	// ContentKeys's available values: config.json, locale/zh-cn.json, locale/en.json, cluster.json.mustache
 	ContentKeys []*string `json:"content_keys" name:"content_keys" location:"params"`
+	Owner       *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID   *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord  *string   `json:"search_word" name:"search_word" location:"params"`
+	Status      []*string `json:"status" name:"status" location:"params"`
+	Tags        []*string `json:"tags" name:"tags" location:"params"`
 	VersionID   *string   `json:"version_id" name:"version_id" location:"params"`
 }
 
@@ -175,14 +180,18 @@ func (s *AppService) DescribeAppVersions(i *DescribeAppVersionsInput) (*Describe
 }
 
 type DescribeAppVersionsInput struct {
-	AppIDs  []*string `json:"app_ids" name:"app_ids" location:"params"`
-	Limit   *int      `json:"limit" name:"limit" location:"params"`
-	Name    *string   `json:"name" name:"name" location:"params"`
-	Offset  *int      `json:"offset" name:"offset" location:"params"`
-	Owner   *string   `json:"owner" name:"owner" location:"params"`
-	Reverse *string   `json:"reverse" name:"reverse" location:"params"`
-	SortKey *string   `json:"sort_key" name:"sort_key" location:"params"`
-	Status  []*string `json:"status" name:"status" location:"params"`
+	AppIDs     []*string `json:"app_ids" name:"app_ids" location:"params"`
+	Console    *string   `json:"console" name:"console" location:"params"`
+	Limit      *int      `json:"limit" name:"limit" location:"params"`
+	Name       *string   `json:"name" name:"name" location:"params"`
+	Offset     *int      `json:"offset" name:"offset" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse    *string   `json:"reverse" name:"reverse" location:"params"`
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	// Verbose's available values: 1, 0
 	Verbose    *int      `json:"verbose" name:"verbose" location:"params"`
 	VersionIDs []*string `json:"version_ids" name:"version_ids" location:"params"`
@@ -252,9 +261,14 @@ type DescribeAppsInput struct {
 	AppName    *string   `json:"app_name" name:"app_name" location:"params"`
 	AppType    []*string `json:"app_type" name:"app_type" location:"params"`
 	Category   *string   `json:"category" name:"category" location:"params"`
+	Console    *string   `json:"console" name:"console" location:"params"`
 	Limit      *int      `json:"limit" name:"limit" location:"params"`
 	Offset     *int      `json:"offset" name:"offset" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
 	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
 	Status     []*string `json:"status" name:"status" location:"params"`
 	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	// Verbose's available values: 1, 0
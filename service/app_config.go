@@ -0,0 +1,248 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+)
+
+// AppConfigSchemaKey is the content key of the config schema attachment of an app version.
+const AppConfigSchemaKey = "config.json"
+
+// AppConfigSchema is a node of the config.json schema of an app version.
+type AppConfigSchema struct {
+	Key         string             `json:"key"`
+	Label       string             `json:"label"`
+	Description string             `json:"description"`
+	Type        string             `json:"type"`
+	Default     interface{}        `json:"default"`
+	Required    interface{}        `json:"required"`
+	Range       []interface{}      `json:"range"`
+	Min         *float64           `json:"min"`
+	Max         *float64           `json:"max"`
+	Pattern     string             `json:"pattern"`
+	Properties  []*AppConfigSchema `json:"properties"`
+}
+
+// ParseAppConfigSchema decodes the config.json content of an app version.
+func ParseAppConfigSchema(content string) (*AppConfigSchema, error) {
+	schema := &AppConfigSchema{}
+	if err := json.Unmarshal([]byte(content), schema); err != nil {
+		return nil, fmt.Errorf("app config schema is invalid: %s", err.Error())
+	}
+	return schema, nil
+}
+
+// DescribeAppConfigSchema fetches and decodes the config.json schema of the app version.
+func (s *AppService) DescribeAppConfigSchema(versionID string) (*AppConfigSchema, error) {
+	output, err := s.DescribeAppVersionAttachments(&DescribeAppVersionAttachmentsInput{
+		VersionID:   String(versionID),
+		ContentKeys: StringSlice([]string{AppConfigSchemaKey}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, attachment := range output.VersionSet {
+		if content, ok := attachment.AttachmentContent[AppConfigSchemaKey]; ok && content != nil {
+			return ParseAppConfigSchema(*content)
+		}
+	}
+	return nil, fmt.Errorf("config schema of app version [%s] not found", versionID)
+}
+
+// Child returns the child schema with the given key path, it returns nil if not found.
+func (s *AppConfigSchema) Child(path ...string) *AppConfigSchema {
+	current := s
+	for _, key := range path {
+		var next *AppConfigSchema
+		for _, property := range current.Properties {
+			if property.Key == key {
+				next = property
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		current = next
+	}
+	return current
+}
+
+// ValidateEnv validates the env of the cluster against the "env" section of the schema
+// and fills in the default values of the absent keys.
+func (s *AppConfigSchema) ValidateEnv(env map[string]interface{}) error {
+	envSchema := s.Child("env")
+	if envSchema == nil {
+		if len(env) > 0 {
+			return fmt.Errorf("app config schema has no env")
+		}
+		return nil
+	}
+	return envSchema.validateObject("env", env)
+}
+
+// RenderConf validates the conf against the schema, fills in the default values
+// and returns the conf string for DeployAppVersion and CreateCluster.
+func (s *AppConfigSchema) RenderConf(conf map[string]interface{}) (string, error) {
+	if err := s.validateObject("conf", conf); err != nil {
+		return "", err
+	}
+	content, err := json.Marshal(conf)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+func (s *AppConfigSchema) isRequired() bool {
+	switch v := s.Required.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "yes" || v == "true"
+	}
+	return false
+}
+
+func (s *AppConfigSchema) validateObject(path string, value map[string]interface{}) error {
+	known := map[string]bool{}
+	for _, property := range s.Properties {
+		known[property.Key] = true
+		propertyPath := path + "." + property.Key
+
+		v, ok := value[property.Key]
+		if !ok || v == nil {
+			if len(property.Properties) > 0 {
+				child := map[string]interface{}{}
+				if err := property.validateObject(propertyPath, child); err != nil {
+					return err
+				}
+				if len(child) > 0 {
+					value[property.Key] = child
+				}
+				continue
+			}
+			if property.Default != nil {
+				value[property.Key] = property.Default
+				continue
+			}
+			if property.isRequired() {
+				return errors.ParameterRequiredError{
+					ParameterName: property.Key,
+					ParentName:    path,
+				}
+			}
+			continue
+		}
+
+		if len(property.Properties) > 0 {
+			child, ok := v.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf(`"%s" should be an object`, propertyPath)
+			}
+			if err := property.validateObject(propertyPath, child); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := property.validateValue(propertyPath, v); err != nil {
+			return err
+		}
+	}
+
+	for key := range value {
+		if !known[key] {
+			return fmt.Errorf(`"%s.%s" is not defined in app config schema`, path, key)
+		}
+	}
+	return nil
+}
+
+func (s *AppConfigSchema) validateValue(path string, value interface{}) error {
+	switch s.Type {
+	case "integer":
+		number, ok := numberValue(value)
+		if !ok || number != math.Trunc(number) {
+			return fmt.Errorf(`"%s" should be an integer, got %v`, path, value)
+		}
+		if err := s.validateNumber(path, number); err != nil {
+			return err
+		}
+	case "number":
+		number, ok := numberValue(value)
+		if !ok {
+			return fmt.Errorf(`"%s" should be a number, got %v`, path, value)
+		}
+		if err := s.validateNumber(path, number); err != nil {
+			return err
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf(`"%s" should be a boolean, got %v`, path, value)
+		}
+	case "string", "password", "":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf(`"%s" should be a string, got %v`, path, value)
+		}
+		if s.Pattern != "" {
+			matched, err := regexp.MatchString(s.Pattern, str)
+			if err != nil {
+				return fmt.Errorf(`pattern of "%s" is invalid: %s`, path, err.Error())
+			}
+			if !matched {
+				return fmt.Errorf(`"%s" value "%s" does not match pattern "%s"`, path, str, s.Pattern)
+			}
+		}
+	}
+
+	if len(s.Range) > 0 {
+		allowed := make([]string, len(s.Range))
+		for i, item := range s.Range {
+			allowed[i] = fmt.Sprint(item)
+		}
+		current := fmt.Sprint(value)
+		for _, item := range allowed {
+			if item == current {
+				return nil
+			}
+		}
+		return errors.ParameterValueNotAllowedError{
+			ParameterName:  strings.TrimPrefix(path, "conf."),
+			ParameterValue: current,
+			AllowedValues:  allowed,
+		}
+	}
+	return nil
+}
+
+func (s *AppConfigSchema) validateNumber(path string, number float64) error {
+	if s.Min != nil && number < *s.Min {
+		return fmt.Errorf(`"%s" value %v should not be less than %v`, path, number, *s.Min)
+	}
+	if s.Max != nil && number > *s.Max {
+		return fmt.Errorf(`"%s" value %v should not be greater than %v`, path, number, *s.Max)
+	}
+	return nil
+}
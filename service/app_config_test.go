@@ -0,0 +1,120 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testAppConfigSchema = `{
+  "type": "array",
+  "properties": [{
+    "key": "cluster",
+    "type": "array",
+    "properties": [{
+      "key": "name",
+      "type": "string",
+      "default": "demo",
+      "required": "no"
+    }, {
+      "key": "vxnet",
+      "type": "string",
+      "pattern": "^vxnet-",
+      "required": "yes"
+    }, {
+      "key": "node",
+      "type": "array",
+      "properties": [{
+        "key": "cpu",
+        "type": "integer",
+        "range": [1, 2, 4],
+        "default": 1,
+        "required": "yes"
+      }, {
+        "key": "count",
+        "type": "integer",
+        "min": 1,
+        "max": 9,
+        "default": 3,
+        "required": "yes"
+      }]
+    }]
+  }, {
+    "key": "env",
+    "type": "array",
+    "properties": [{
+      "key": "max_connections",
+      "type": "integer",
+      "min": 10,
+      "default": 100,
+      "required": "no"
+    }, {
+      "key": "enable_tls",
+      "type": "boolean",
+      "default": false,
+      "required": "no"
+    }]
+  }]
+}`
+
+func TestAppConfigSchemaValidateEnv(t *testing.T) {
+	schema, err := ParseAppConfigSchema(testAppConfigSchema)
+	assert.Nil(t, err)
+	assert.Equal(t, "count", schema.Child("cluster", "node", "count").Key)
+	assert.Nil(t, schema.Child("cluster", "unknown"))
+
+	env := map[string]interface{}{"max_connections": float64(500)}
+	assert.Nil(t, schema.ValidateEnv(env))
+	assert.Equal(t, false, env["enable_tls"])
+
+	assert.NotNil(t, schema.ValidateEnv(map[string]interface{}{"max_connections": float64(1)}))
+	assert.NotNil(t, schema.ValidateEnv(map[string]interface{}{"max_connections": 10.5}))
+	assert.NotNil(t, schema.ValidateEnv(map[string]interface{}{"enable_tls": "yes"}))
+	assert.NotNil(t, schema.ValidateEnv(map[string]interface{}{"unknown": "x"}))
+}
+
+func TestAppConfigSchemaRenderConf(t *testing.T) {
+	schema, err := ParseAppConfigSchema(testAppConfigSchema)
+	assert.Nil(t, err)
+
+	conf := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"cluster": {"vxnet": "vxnet-abc", "node": {"cpu": 2}}}`), &conf))
+	rendered, err := schema.RenderConf(conf)
+	assert.Nil(t, err)
+
+	result := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal([]byte(rendered), &result))
+	cluster := result["cluster"].(map[string]interface{})
+	assert.Equal(t, "demo", cluster["name"])
+	assert.Equal(t, float64(2), cluster["node"].(map[string]interface{})["cpu"])
+	assert.Equal(t, float64(3), cluster["node"].(map[string]interface{})["count"])
+	assert.Equal(t, float64(100), result["env"].(map[string]interface{})["max_connections"])
+
+	_, err = schema.RenderConf(map[string]interface{}{})
+	assert.NotNil(t, err)
+
+	conf = map[string]interface{}{"cluster": map[string]interface{}{"vxnet": "vxnet-abc", "node": map[string]interface{}{"cpu": float64(3)}}}
+	_, err = schema.RenderConf(conf)
+	assert.NotNil(t, err)
+
+	conf = map[string]interface{}{"cluster": map[string]interface{}{"vxnet": "abc"}}
+	_, err = schema.RenderConf(conf)
+	assert.NotNil(t, err)
+}
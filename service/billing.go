@@ -0,0 +1,149 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/request"
+	"github.com/yunify/qingcloud-sdk-go/request/data"
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+)
+
+var _ fmt.State
+var _ time.Time
+
+type BillingService struct {
+	Config     *config.Config
+	Properties *BillingServiceProperties
+}
+
+type BillingServiceProperties struct {
+	// QingCloud Zone ID
+	Zone *string `json:"zone" name:"zone"` // Required
+}
+
+func (s *QingCloudService) Billing(zone string) (*BillingService, error) {
+	properties := &BillingServiceProperties{
+		Zone: &zone,
+	}
+
+	return &BillingService{Config: s.Config, Properties: properties}, nil
+}
+
+// Documentation URL: https://docs.qingcloud.com/api/billing/get_balance.html
+func (s *BillingService) GetBalance(i *GetBalanceInput) (*GetBalanceOutput, error) {
+	if i == nil {
+		i = &GetBalanceInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "GetBalance",
+		RequestMethod: "GET",
+	}
+
+	x := &GetBalanceOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type GetBalanceInput struct {
+}
+
+func (v *GetBalanceInput) Validate() error {
+
+	return nil
+}
+
+type GetBalanceOutput struct {
+	Message  *string  `json:"message" name:"message"`
+	Action   *string  `json:"action" name:"action" location:"elements"`
+	Balance  *Decimal `json:"balance" name:"balance" location:"elements"`
+	Bonus    *Decimal `json:"bonus" name:"bonus" location:"elements"`
+	Currency *string  `json:"currency" name:"currency" location:"elements"`
+	RetCode  *int     `json:"ret_code" name:"ret_code" location:"elements"`
+}
+
+// Documentation URL: https://docs.qingcloud.com/api/billing/get_price.html
+func (s *BillingService) GetPrice(i *GetPriceInput) (*GetPriceOutput, error) {
+	if i == nil {
+		i = &GetPriceInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "GetPrice",
+		RequestMethod: "GET",
+	}
+
+	x := &GetPriceOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type GetPriceInput struct {
+	Resources []*PriceResource `json:"resources" name:"resources" location:"params"` // Required
+}
+
+func (v *GetPriceInput) Validate() error {
+
+	if len(v.Resources) == 0 {
+		return errors.ParameterRequiredError{
+			ParameterName: "Resources",
+			ParentName:    "GetPriceInput",
+		}
+	}
+
+	if len(v.Resources) > 0 {
+		for _, property := range v.Resources {
+			if err := property.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type GetPriceOutput struct {
+	Message    *string  `json:"message" name:"message"`
+	Action     *string  `json:"action" name:"action" location:"elements"`
+	PriceSet   []*Price `json:"price_set" name:"price_set" location:"elements"`
+	RetCode    *int     `json:"ret_code" name:"ret_code" location:"elements"`
+	TotalPrice *Decimal `json:"total_price" name:"total_price" location:"elements"`
+}
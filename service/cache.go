@@ -693,8 +693,11 @@ type DescribeCacheNodesInput struct {
 	CacheNodes []*string `json:"cache_nodes" name:"cache_nodes" location:"params"`
 	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
 	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
 	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	Verbose    *int      `json:"verbose" name:"verbose" location:"params"`
 }
 
@@ -740,9 +743,14 @@ func (s *CacheService) DescribeCacheParameterGroups(i *DescribeCacheParameterGro
 type DescribeCacheParameterGroupsInput struct {
 	CacheParameterGroups []*string `json:"cache_parameter_groups" name:"cache_parameter_groups" location:"params"`
 	CacheType            *string   `json:"cache_type" name:"cache_type" location:"params"`
+	Console              *string   `json:"console" name:"console" location:"params"`
 	Limit                *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset               *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner                *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID            *string   `json:"project_id" name:"project_id" location:"params"`
 	SearchWord           *string   `json:"search_word" name:"search_word" location:"params"`
+	Status               []*string `json:"status" name:"status" location:"params"`
+	Tags                 []*string `json:"tags" name:"tags" location:"params"`
 	Verbose              *int      `json:"verbose" name:"verbose" location:"params"`
 }
 
@@ -786,8 +794,13 @@ func (s *CacheService) DescribeCacheParameters(i *DescribeCacheParametersInput)
 }
 
 type DescribeCacheParametersInput struct {
-	CacheParameterGroup *string `json:"cache_parameter_group" name:"cache_parameter_group" location:"params"` // Required
-	Verbose             *int    `json:"verbose" name:"verbose" location:"params"`
+	CacheParameterGroup *string   `json:"cache_parameter_group" name:"cache_parameter_group" location:"params"` // Required
+	Owner               *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID           *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord          *string   `json:"search_word" name:"search_word" location:"params"`
+	Status              []*string `json:"status" name:"status" location:"params"`
+	Tags                []*string `json:"tags" name:"tags" location:"params"`
+	Verbose             *int      `json:"verbose" name:"verbose" location:"params"`
 }
 
 func (v *DescribeCacheParametersInput) Validate() error {
@@ -839,10 +852,14 @@ func (s *CacheService) DescribeCaches(i *DescribeCachesInput) (*DescribeCachesOu
 type DescribeCachesInput struct {
 	CacheType  []*string `json:"cache_type" name:"cache_type" location:"params"`
 	Caches     []*string `json:"caches" name:"caches" location:"params"`
+	Console    *string   `json:"console" name:"console" location:"params"`
 	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
 	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
 	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
 	Status     []*string `json:"status" name:"status" location:"params"`
 	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	Verbose    *int      `json:"verbose" name:"verbose" location:"params"`
@@ -0,0 +1,65 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+)
+
+func TestQingCloudServiceClose(t *testing.T) {
+	closed := make(chan struct{}, 1)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closed <- struct{}{}
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	conf, err := config.New("AccessKeyID", "SecretAccessKey")
+	assert.Nil(t, err)
+	qcService, err := Init(conf)
+	assert.Nil(t, err)
+
+	response, err := conf.Connection.Get(server.URL)
+	assert.Nil(t, err)
+	ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	select {
+	case <-closed:
+		t.Error("connection closed before Close")
+	default:
+	}
+
+	qcService.Close()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Error("idle connection not closed")
+	}
+}
@@ -278,6 +278,64 @@ type ChangeClusterVxNetOutput struct {
 	VxNetID   *string `json:"vxnet_id" name:"vxnet_id" location:"elements"`
 }
 
+// Documentation URL: https://docs.qingcloud.com/api/cluster/cordon_cluster_nodes.html
+func (s *ClusterService) CordonClusterNodes(i *CordonClusterNodesInput) (*CordonClusterNodesOutput, error) {
+	if i == nil {
+		i = &CordonClusterNodesInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "CordonClusterNodes",
+		RequestMethod: "GET",
+	}
+
+	x := &CordonClusterNodesOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type CordonClusterNodesInput struct {
+	Cluster *string   `json:"cluster" name:"cluster" location:"params"` // Required
+	Nodes   []*string `json:"nodes" name:"nodes" location:"params"`     // Required
+}
+
+func (v *CordonClusterNodesInput) Validate() error {
+
+	if v.Cluster == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "Cluster",
+			ParentName:    "CordonClusterNodesInput",
+		}
+	}
+
+	if len(v.Nodes) == 0 {
+		return errors.ParameterRequiredError{
+			ParameterName: "Nodes",
+			ParentName:    "CordonClusterNodesInput",
+		}
+	}
+
+	return nil
+}
+
+type CordonClusterNodesOutput struct {
+	Message   *string `json:"message" name:"message"`
+	Action    *string `json:"action" name:"action" location:"elements"`
+	ClusterID *string `json:"cluster_id" name:"cluster_id" location:"elements"`
+	JobID     *string `json:"job_id" name:"job_id" location:"elements"`
+	RetCode   *int    `json:"ret_code" name:"ret_code" location:"elements"`
+}
+
 // Documentation URL: https://docs.qingcloud.com/api/cluster/create_cluster.html
 func (s *ClusterService) CreateCluster(i *CreateClusterInput) (*CreateClusterOutput, error) {
 	if i == nil {
@@ -533,9 +591,14 @@ func (s *ClusterService) DescribeClusterDisplayTabs(i *DescribeClusterDisplayTab
 }
 
 type DescribeClusterDisplayTabsInput struct {
-	Cluster     *string `json:"cluster" name:"cluster" location:"params"`           // Required
-	DisplayTabs *string `json:"display_tabs" name:"display_tabs" location:"params"` // Required
-	Role        *string `json:"role" name:"role" location:"params"`
+	Cluster     *string   `json:"cluster" name:"cluster" location:"params"`           // Required
+	DisplayTabs *string   `json:"display_tabs" name:"display_tabs" location:"params"` // Required
+	Owner       *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID   *string   `json:"project_id" name:"project_id" location:"params"`
+	Role        *string   `json:"role" name:"role" location:"params"`
+	SearchWord  *string   `json:"search_word" name:"search_word" location:"params"`
+	Status      []*string `json:"status" name:"status" location:"params"`
+	Tags        []*string `json:"tags" name:"tags" location:"params"`
 }
 
 func (v *DescribeClusterDisplayTabsInput) Validate() error {
@@ -597,11 +660,13 @@ type DescribeClusterNodesInput struct {
 	Nodes      []*string `json:"nodes" name:"nodes" location:"params"`
 	Offset     *int      `json:"offset" name:"offset" location:"params"`
 	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
 	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
 	Role       *string   `json:"role" name:"role" location:"params"`
 	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
 	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
 	Status     *string   `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	Verbose    *int      `json:"verbose" name:"verbose" location:"params"`
 }
 
@@ -650,6 +715,11 @@ type DescribeClusterUsersInput struct {
 	ClusterStatus []*string `json:"cluster_status" name:"cluster_status" location:"params"`
 	Limit         *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset        *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner         *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID     *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord    *string   `json:"search_word" name:"search_word" location:"params"`
+	Status        []*string `json:"status" name:"status" location:"params"`
+	Tags          []*string `json:"tags" name:"tags" location:"params"`
 	Users         []*string `json:"users" name:"users" location:"params"`
 	Zones         []*string `json:"zones" name:"zones" location:"params"` // Required
 }
@@ -714,11 +784,13 @@ type DescribeClustersInput struct {
 	Clusters          []*string `json:"clusters" name:"clusters" location:"params"`
 	Console           *string   `json:"console" name:"console" location:"params"`
 	ExternalClusterID *string   `json:"external_cluster_id" name:"external_cluster_id" location:"params"`
+	Fields            []*string `json:"fields" name:"fields" location:"params"`
 	Limit             *int      `json:"limit" name:"limit" location:"params"`
 	Link              *string   `json:"link" name:"link" location:"params"`
 	Name              *string   `json:"name" name:"name" location:"params"`
 	Offset            *int      `json:"offset" name:"offset" location:"params"`
 	Owner             *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID         *string   `json:"project_id" name:"project_id" location:"params"`
 	Reverse           *int      `json:"reverse" name:"reverse" location:"params"`
 	Role              *string   `json:"role" name:"role" location:"params"`
 	// Scope's available values: all, cfgmgmt
@@ -726,6 +798,7 @@ type DescribeClustersInput struct {
 	SearchWord       *string   `json:"search_word" name:"search_word" location:"params"`
 	SortKey          *string   `json:"sort_key" name:"sort_key" location:"params"`
 	Status           *string   `json:"status" name:"status" location:"params"`
+	Tags             []*string `json:"tags" name:"tags" location:"params"`
 	TransitionStatus *string   `json:"transition_status" name:"transition_status" location:"params"`
 	Users            []*string `json:"users" name:"users" location:"params"`
 	Verbose          *int      `json:"verbose" name:"verbose" location:"params"`
@@ -865,6 +938,79 @@ type ModifyClusterAttributesOutput struct {
 	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
 }
 
+// Documentation URL: https://docs.qingcloud.com/api/cluster/modify_cluster_health_check.html
+func (s *ClusterService) ModifyClusterHealthCheck(i *ModifyClusterHealthCheckInput) (*ModifyClusterHealthCheckOutput, error) {
+	if i == nil {
+		i = &ModifyClusterHealthCheckInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "ModifyClusterHealthCheck",
+		RequestMethod: "GET",
+	}
+
+	x := &ModifyClusterHealthCheckOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type ModifyClusterHealthCheckInput struct {
+	Cluster *string `json:"cluster" name:"cluster" location:"params"` // Required
+	// Enable's available values: 0, 1
+	Enable      *int    `json:"enable" name:"enable" location:"params"`
+	HealthCheck *string `json:"health_check" name:"health_check" location:"params"`
+	Role        *string `json:"role" name:"role" location:"params"`
+}
+
+func (v *ModifyClusterHealthCheckInput) Validate() error {
+
+	if v.Cluster == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "Cluster",
+			ParentName:    "ModifyClusterHealthCheckInput",
+		}
+	}
+
+	if v.Enable != nil {
+		enableValidValues := []string{"0", "1"}
+		enableParameterValue := fmt.Sprint(*v.Enable)
+
+		enableIsValid := false
+		for _, value := range enableValidValues {
+			if value == enableParameterValue {
+				enableIsValid = true
+			}
+		}
+
+		if !enableIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Enable",
+				ParameterValue: enableParameterValue,
+				AllowedValues:  enableValidValues,
+			}
+		}
+	}
+
+	return nil
+}
+
+type ModifyClusterHealthCheckOutput struct {
+	Message   *string `json:"message" name:"message"`
+	Action    *string `json:"action" name:"action" location:"elements"`
+	ClusterID *string `json:"cluster_id" name:"cluster_id" location:"elements"`
+	RetCode   *int    `json:"ret_code" name:"ret_code" location:"elements"`
+}
+
 // Documentation URL: https://docs.qingcloud.com/api/cluster/modify_cluster_node_attributes.html
 func (s *ClusterService) ModifyClusterNodeAttributes(i *ModifyClusterNodeAttributesInput) (*ModifyClusterNodeAttributesOutput, error) {
 	if i == nil {
@@ -955,6 +1101,64 @@ type RecoverClustersOutput struct {
 	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
 }
 
+// Documentation URL: https://docs.qingcloud.com/api/cluster/repair_cluster_nodes.html
+func (s *ClusterService) RepairClusterNodes(i *RepairClusterNodesInput) (*RepairClusterNodesOutput, error) {
+	if i == nil {
+		i = &RepairClusterNodesInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "RepairClusterNodes",
+		RequestMethod: "GET",
+	}
+
+	x := &RepairClusterNodesOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type RepairClusterNodesInput struct {
+	Cluster *string   `json:"cluster" name:"cluster" location:"params"` // Required
+	Nodes   []*string `json:"nodes" name:"nodes" location:"params"`     // Required
+}
+
+func (v *RepairClusterNodesInput) Validate() error {
+
+	if v.Cluster == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "Cluster",
+			ParentName:    "RepairClusterNodesInput",
+		}
+	}
+
+	if len(v.Nodes) == 0 {
+		return errors.ParameterRequiredError{
+			ParameterName: "Nodes",
+			ParentName:    "RepairClusterNodesInput",
+		}
+	}
+
+	return nil
+}
+
+type RepairClusterNodesOutput struct {
+	Message   *string `json:"message" name:"message"`
+	Action    *string `json:"action" name:"action" location:"elements"`
+	ClusterID *string `json:"cluster_id" name:"cluster_id" location:"elements"`
+	JobID     *string `json:"job_id" name:"job_id" location:"elements"`
+	RetCode   *int    `json:"ret_code" name:"ret_code" location:"elements"`
+}
+
 // Documentation URL: https://docs.qingcloud.com/api/cluster/resize_cluster.html
 func (s *ClusterService) ResizeCluster(i *ResizeClusterInput) (*ResizeClusterOutput, error) {
 	if i == nil {
@@ -1282,6 +1486,64 @@ type StopClustersOutput struct {
 	RetCode *int               `json:"ret_code" name:"ret_code" location:"elements"`
 }
 
+// Documentation URL: https://docs.qingcloud.com/api/cluster/uncordon_cluster_nodes.html
+func (s *ClusterService) UncordonClusterNodes(i *UncordonClusterNodesInput) (*UncordonClusterNodesOutput, error) {
+	if i == nil {
+		i = &UncordonClusterNodesInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "UncordonClusterNodes",
+		RequestMethod: "GET",
+	}
+
+	x := &UncordonClusterNodesOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type UncordonClusterNodesInput struct {
+	Cluster *string   `json:"cluster" name:"cluster" location:"params"` // Required
+	Nodes   []*string `json:"nodes" name:"nodes" location:"params"`     // Required
+}
+
+func (v *UncordonClusterNodesInput) Validate() error {
+
+	if v.Cluster == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "Cluster",
+			ParentName:    "UncordonClusterNodesInput",
+		}
+	}
+
+	if len(v.Nodes) == 0 {
+		return errors.ParameterRequiredError{
+			ParameterName: "Nodes",
+			ParentName:    "UncordonClusterNodesInput",
+		}
+	}
+
+	return nil
+}
+
+type UncordonClusterNodesOutput struct {
+	Message   *string `json:"message" name:"message"`
+	Action    *string `json:"action" name:"action" location:"elements"`
+	ClusterID *string `json:"cluster_id" name:"cluster_id" location:"elements"`
+	JobID     *string `json:"job_id" name:"job_id" location:"elements"`
+	RetCode   *int    `json:"ret_code" name:"ret_code" location:"elements"`
+}
+
 // Documentation URL: https://docs.qingcloud.com/api/cluster/update_cluster_environment.html
 func (s *ClusterService) UpdateClusterEnvironment(i *UpdateClusterEnvironmentInput) (*UpdateClusterEnvironmentOutput, error) {
 	if i == nil {
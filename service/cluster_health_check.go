@@ -0,0 +1,124 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ClusterHealthCheck is the typed form of the health check and auto-recovery
+// settings of a cluster role, as defined by the "health_check" of cluster.json.
+type ClusterHealthCheck struct {
+	Enable             bool   `json:"enable"`
+	IntervalSec        int    `json:"interval_sec"`
+	TimeoutSec         int    `json:"timeout_sec"`
+	ActionTimeoutSec   int    `json:"action_timeout_sec,omitempty"`
+	HealthyThreshold   int    `json:"healthy_threshold"`
+	UnhealthyThreshold int    `json:"unhealthy_threshold"`
+	CheckCmd           string `json:"check_cmd,omitempty"`
+	ActionCmd          string `json:"action_cmd,omitempty"`
+}
+
+// Validate validates the ClusterHealthCheck.
+func (v *ClusterHealthCheck) Validate() error {
+	if !v.Enable {
+		return nil
+	}
+	if v.IntervalSec <= 0 {
+		return fmt.Errorf(`"IntervalSec" of ClusterHealthCheck should be positive`)
+	}
+	if v.TimeoutSec <= 0 || v.TimeoutSec > v.IntervalSec {
+		return fmt.Errorf(`"TimeoutSec" of ClusterHealthCheck should be between 1 and IntervalSec`)
+	}
+	if v.ActionTimeoutSec < 0 {
+		return fmt.Errorf(`"ActionTimeoutSec" of ClusterHealthCheck can not be negative`)
+	}
+	if v.HealthyThreshold <= 0 || v.UnhealthyThreshold <= 0 {
+		return fmt.Errorf(`"HealthyThreshold" and "UnhealthyThreshold" of ClusterHealthCheck should be positive`)
+	}
+	return nil
+}
+
+// ParseClusterHealthCheck decodes the health check of a cluster node.
+func ParseClusterHealthCheck(healthCheck interface{}) (*ClusterHealthCheck, error) {
+	var content []byte
+	switch v := healthCheck.(type) {
+	case nil:
+		return &ClusterHealthCheck{}, nil
+	case string:
+		content = []byte(v)
+	case *string:
+		content = []byte(StringValue(v))
+	default:
+		var err error
+		if content, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	check := &ClusterHealthCheck{}
+	if len(content) == 0 {
+		return check, nil
+	}
+	if err := json.Unmarshal(content, check); err != nil {
+		return nil, fmt.Errorf("cluster health check is invalid: %s", err.Error())
+	}
+	return check, nil
+}
+
+// HasRole checks whether the cluster has nodes of the role.
+// Clusters without roles only accept the empty role.
+func (v *Cluster) HasRole(role string) bool {
+	if len(v.Roles) == 0 {
+		return role == ""
+	}
+	for _, r := range v.Roles {
+		if StringValue(r) == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HealthCheckEnabled checks whether the health check of the role is enabled.
+func (v *Cluster) HealthCheckEnabled(role string) bool {
+	return BoolValue(v.HealthCheckEnablement[role])
+}
+
+// SetClusterHealthCheck enables or disables the health check and auto-recovery
+// of the cluster nodes with the role and updates its settings.
+func (s *ClusterService) SetClusterHealthCheck(clusterID, role string, check *ClusterHealthCheck) (*ModifyClusterHealthCheckOutput, error) {
+	if err := check.Validate(); err != nil {
+		return nil, err
+	}
+	input := &ModifyClusterHealthCheckInput{
+		Cluster: String(clusterID),
+		Enable:  Int(0),
+	}
+	if role != "" {
+		input.Role = String(role)
+	}
+	if check.Enable {
+		input.Enable = Int(1)
+		content, err := json.Marshal(check)
+		if err != nil {
+			return nil, err
+		}
+		input.HealthCheck = String(string(content))
+	}
+	return s.ModifyClusterHealthCheck(input)
+}
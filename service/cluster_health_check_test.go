@@ -0,0 +1,77 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseClusterHealthCheck(t *testing.T) {
+	check, err := ParseClusterHealthCheck(map[string]interface{}{
+		"enable":              true,
+		"interval_sec":        60,
+		"timeout_sec":         10,
+		"healthy_threshold":   2,
+		"unhealthy_threshold": 3,
+		"check_cmd":           "/opt/app/check.sh",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &ClusterHealthCheck{
+		Enable:             true,
+		IntervalSec:        60,
+		TimeoutSec:         10,
+		HealthyThreshold:   2,
+		UnhealthyThreshold: 3,
+		CheckCmd:           "/opt/app/check.sh",
+	}, check)
+	assert.Nil(t, check.Validate())
+
+	check, err = ParseClusterHealthCheck(`{"enable": false}`)
+	assert.Nil(t, err)
+	assert.False(t, check.Enable)
+
+	check, err = ParseClusterHealthCheck(nil)
+	assert.Nil(t, err)
+	assert.False(t, check.Enable)
+
+	_, err = ParseClusterHealthCheck("enable")
+	assert.NotNil(t, err)
+}
+
+func TestClusterHealthCheckValidate(t *testing.T) {
+	assert.Nil(t, (&ClusterHealthCheck{}).Validate())
+	assert.NotNil(t, (&ClusterHealthCheck{Enable: true}).Validate())
+	assert.NotNil(t, (&ClusterHealthCheck{
+		Enable: true, IntervalSec: 10, TimeoutSec: 20, HealthyThreshold: 2, UnhealthyThreshold: 2,
+	}).Validate())
+}
+
+func TestClusterRoles(t *testing.T) {
+	cluster := &Cluster{
+		Roles:                 StringSlice([]string{"master", "slave"}),
+		HealthCheckEnablement: map[string]*bool{"master": Bool(true), "slave": Bool(false)},
+	}
+	assert.True(t, cluster.HasRole("master"))
+	assert.False(t, cluster.HasRole(""))
+	assert.True(t, cluster.HealthCheckEnabled("master"))
+	assert.False(t, cluster.HealthCheckEnabled("slave"))
+	assert.False(t, cluster.HealthCheckEnabled("unknown"))
+
+	assert.True(t, (&Cluster{}).HasRole(""))
+}
@@ -0,0 +1,108 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+// ClusterNodeRole is the role of a cluster node defined by the AppCenter app,
+// nodes of the apps without roles have the empty role.
+type ClusterNodeRole string
+
+// ClusterNodeStatus is the status of a cluster node.
+type ClusterNodeStatus string
+
+// Statuses of cluster nodes.
+const (
+	ClusterNodeStatusPending   ClusterNodeStatus = "pending"
+	ClusterNodeStatusActive    ClusterNodeStatus = "active"
+	ClusterNodeStatusStopped   ClusterNodeStatus = "stopped"
+	ClusterNodeStatusSuspended ClusterNodeStatus = "suspended"
+	ClusterNodeStatusDeleted   ClusterNodeStatus = "deleted"
+	ClusterNodeStatusCeased    ClusterNodeStatus = "ceased"
+)
+
+// ClusterNodeHealthStatus is the result of the health check of a cluster node,
+// it is empty if the health check of the role is disabled or has not run yet.
+type ClusterNodeHealthStatus string
+
+// Health statuses of cluster nodes.
+const (
+	ClusterNodeHealthStatusUnknown   ClusterNodeHealthStatus = ""
+	ClusterNodeHealthStatusHealthy   ClusterNodeHealthStatus = "healthy"
+	ClusterNodeHealthStatusUnhealthy ClusterNodeHealthStatus = "unhealthy"
+)
+
+// NodeRole returns the typed role of the node.
+func (v *ClusterNode) NodeRole() ClusterNodeRole {
+	return ClusterNodeRole(StringValue(v.Role))
+}
+
+// NodeStatus returns the typed status of the node.
+func (v *ClusterNode) NodeStatus() ClusterNodeStatus {
+	return ClusterNodeStatus(StringValue(v.Status))
+}
+
+// NodeHealthStatus returns the typed health status of the node.
+func (v *ClusterNode) NodeHealthStatus() ClusterNodeHealthStatus {
+	return ClusterNodeHealthStatus(StringValue(v.HealthStatus))
+}
+
+// InTransition checks whether the node is changing its status, e.g. starting or updating.
+func (v *ClusterNode) InTransition() bool {
+	return StringValue(v.TransitionStatus) != ""
+}
+
+// IsReady checks whether the node is active, not in transition and not reported unhealthy.
+func (v *ClusterNode) IsReady() bool {
+	return v.NodeStatus() == ClusterNodeStatusActive && !v.InTransition() &&
+		v.NodeHealthStatus() != ClusterNodeHealthStatusUnhealthy
+}
+
+// GroupClusterNodesByRole groups the nodes by their roles, keeping the order of the nodes in each role.
+func GroupClusterNodesByRole(nodes []*ClusterNode) map[ClusterNodeRole][]*ClusterNode {
+	groups := map[ClusterNodeRole][]*ClusterNode{}
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		groups[node.NodeRole()] = append(groups[node.NodeRole()], node)
+	}
+	return groups
+}
+
+// ClusterNodesReady checks whether there are nodes and all of them are ready.
+func ClusterNodesReady(nodes []*ClusterNode) bool {
+	ready := false
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		if !node.IsReady() {
+			return false
+		}
+		ready = true
+	}
+	return ready
+}
+
+// NodesOfRole returns the nodes of the cluster with the role.
+func (v *Cluster) NodesOfRole(role ClusterNodeRole) []*ClusterNode {
+	return GroupClusterNodesByRole(v.Nodes)[role]
+}
+
+// RoleReady checks whether the cluster has nodes of the role and all of them are ready.
+func (v *Cluster) RoleReady(role ClusterNodeRole) bool {
+	return ClusterNodesReady(v.NodesOfRole(role))
+}
@@ -0,0 +1,71 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterNodeStatus(t *testing.T) {
+	node := &ClusterNode{
+		Role:         String("master"),
+		Status:       String("active"),
+		HealthStatus: String("healthy"),
+	}
+	assert.Equal(t, ClusterNodeRole("master"), node.NodeRole())
+	assert.Equal(t, ClusterNodeStatusActive, node.NodeStatus())
+	assert.Equal(t, ClusterNodeHealthStatusHealthy, node.NodeHealthStatus())
+	assert.True(t, node.IsReady())
+
+	node.TransitionStatus = String("updating")
+	assert.True(t, node.InTransition())
+	assert.False(t, node.IsReady())
+
+	node.TransitionStatus = String("")
+	node.HealthStatus = String("unhealthy")
+	assert.False(t, node.IsReady())
+
+	node.HealthStatus = nil
+	assert.Equal(t, ClusterNodeHealthStatusUnknown, node.NodeHealthStatus())
+	assert.True(t, node.IsReady())
+
+	node.Status = String("stopped")
+	assert.False(t, node.IsReady())
+}
+
+func TestGroupClusterNodesByRole(t *testing.T) {
+	cluster := &Cluster{Nodes: []*ClusterNode{
+		{NodeID: String("cln-1"), Role: String("master"), Status: String("active")},
+		{NodeID: String("cln-2"), Role: String("slave"), Status: String("active")},
+		nil,
+		{NodeID: String("cln-3"), Role: String("slave"), Status: String("pending")},
+		{NodeID: String("cln-4"), Status: String("active")},
+	}}
+	groups := GroupClusterNodesByRole(cluster.Nodes)
+	assert.Equal(t, 3, len(groups))
+	assert.Equal(t, 1, len(groups["master"]))
+	assert.Equal(t, "cln-2", StringValue(groups["slave"][0].NodeID))
+	assert.Equal(t, "cln-3", StringValue(groups["slave"][1].NodeID))
+	assert.Equal(t, "cln-4", StringValue(groups[""][0].NodeID))
+
+	assert.True(t, cluster.RoleReady("master"))
+	assert.False(t, cluster.RoleReady("slave"))
+	assert.False(t, cluster.RoleReady("unknown"))
+	assert.Equal(t, 0, len(cluster.NodesOfRole("unknown")))
+}
@@ -0,0 +1,33 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"context"
+)
+
+// WithContext returns a copy of the service with the Config resolved for the
+// requests made in ctx by config.WithContext, e.g. with the zone and access key
+// of the tenant in ctx. The services created by the copy with empty zone use the
+// zone in ctx.
+func (s *QingCloudService) WithContext(ctx context.Context) (*QingCloudService, error) {
+	conf, err := s.Config.WithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &QingCloudService{Config: conf, Properties: s.Properties}, nil
+}
@@ -0,0 +1,59 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+)
+
+func TestQingCloudServiceWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "TenantAccessKeyID", r.URL.Query().Get("access_key_id"))
+		assert.Equal(t, "sh1a", r.URL.Query().Get("zone"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ret_code": 0, "total_count": 0, "instance_set": []}`))
+	}))
+	defer server.Close()
+
+	conf, err := config.NewWithEndpoint("AccessKeyID", "SecretAccessKey", server.URL+"/iaas")
+	assert.Nil(t, err)
+	qcService, err := Init(conf)
+	assert.Nil(t, err)
+
+	ctx := config.ContextWithCredential(config.ContextWithZone(context.Background(), "sh1a"), "TenantAccessKeyID", "TenantSecretAccessKey")
+	tenantService, err := qcService.WithContext(ctx)
+	assert.Nil(t, err)
+	instanceService, err := tenantService.Instance("")
+	assert.Nil(t, err)
+	_, err = instanceService.DescribeInstances(nil)
+	assert.Nil(t, err)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	tenantService, err = qcService.WithContext(canceled)
+	assert.Nil(t, err)
+	instanceService, err = tenantService.Instance("")
+	assert.Nil(t, err)
+	_, err = instanceService.DescribeInstances(nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,25 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+// WithCredential returns a QingCloudService which signs the requests of the
+// services it creates with the access key, sharing the connections with s.
+//
+//	instanceService, _ := qcService.WithCredential(tenantKeyID, tenantSecret).Instance("pek3a")
+func (s *QingCloudService) WithCredential(accessKeyID, secretAccessKey string) *QingCloudService {
+	return &QingCloudService{Config: s.Config.WithCredential(accessKeyID, secretAccessKey), Properties: s.Properties}
+}
@@ -0,0 +1,49 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+)
+
+func TestQingCloudServiceWithCredential(t *testing.T) {
+	keys := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys <- r.URL.Query().Get("access_key_id")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"action": "DescribeZonesResponse", "ret_code": 0}`))
+	}))
+	defer server.Close()
+
+	conf, err := config.NewWithEndpoint("AccessKeyID", "SecretAccessKey", server.URL+"/iaas")
+	assert.Nil(t, err)
+	qcService, err := Init(conf)
+	assert.Nil(t, err)
+
+	_, err = qcService.WithCredential("TenantAccessKeyID", "TenantSecretAccessKey").DescribeZones(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "TenantAccessKeyID", <-keys)
+
+	_, err = qcService.DescribeZones(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "AccessKeyID", <-keys)
+}
@@ -0,0 +1,271 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number, like the money values of prices and balances,
+// which float64 can not represent without rounding errors. The zero value is 0.
+// It decodes from JSON numbers and strings and encodes to a JSON number.
+type Decimal struct {
+	// unscaled is never changed once set, so copies of a Decimal can share it.
+	unscaled *big.Int
+	scale    int32
+}
+
+var bigTen = big.NewInt(10)
+
+// MaxDecimalScale is the largest absolute scale of a parsed Decimal, the exponents beyond it
+// are refused since a number like "1e2000000000" would take gigabytes of digits.
+const MaxDecimalScale = 4096
+
+// NewDecimal returns the Decimal unscaled * 10^-scale, e.g. NewDecimal(150, 2) is 1.50.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// DecimalFromInt returns the Decimal of the integer.
+func DecimalFromInt(i int64) Decimal {
+	return NewDecimal(i, 0)
+}
+
+// ParseDecimal parses the decimal number in plain or exponent notation, like "-12.30" and "1.5e-3".
+func ParseDecimal(s string) (Decimal, error) {
+	text := strings.TrimSpace(s)
+	mantissa, exponent := text, int64(0)
+	if index := strings.IndexAny(text, "eE"); index != -1 {
+		var err error
+		mantissa = text[:index]
+		exponent, err = strconv.ParseInt(text[index+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal \"%s\"", s)
+		}
+	}
+	integer, fraction := mantissa, ""
+	if index := strings.IndexByte(mantissa, '.'); index != -1 {
+		integer, fraction = mantissa[:index], mantissa[index+1:]
+	}
+	digits := strings.TrimLeft(integer, "+-")
+	if len(integer)-len(digits) > 1 || digits+fraction == "" ||
+		strings.IndexFunc(digits+fraction, func(r rune) bool { return r < '0' || r > '9' }) != -1 {
+		return Decimal{}, fmt.Errorf("invalid decimal \"%s\"", s)
+	}
+	unscaled, ok := new(big.Int).SetString(integer+fraction, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal \"%s\"", s)
+	}
+	scale := int64(len(fraction)) - exponent
+	if scale > MaxDecimalScale || scale < -MaxDecimalScale {
+		return Decimal{}, fmt.Errorf("decimal \"%s\" out of range, its scale exceeds %d", s, MaxDecimalScale)
+	}
+	d := Decimal{unscaled: unscaled, scale: int32(scale)}
+	if d.scale < 0 {
+		return d.rescale(0), nil
+	}
+	return d, nil
+}
+
+// MustParseDecimal is ParseDecimal which panics on error, for constants.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d Decimal) value() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// rescale returns d with the scale, which should not be less than the scale of d unless d is rounded.
+func (d Decimal) rescale(scale int32) Decimal {
+	unscaled := new(big.Int).Set(d.value())
+	if scale > d.scale {
+		unscaled.Mul(unscaled, new(big.Int).Exp(bigTen, big.NewInt(int64(scale-d.scale)), nil))
+	} else if scale < d.scale {
+		unscaled.Quo(unscaled, new(big.Int).Exp(bigTen, big.NewInt(int64(d.scale-scale)), nil))
+	}
+	return Decimal{unscaled: unscaled, scale: scale}
+}
+
+// align returns d and other with the same scale.
+func (d Decimal) align(other Decimal) (Decimal, Decimal) {
+	if d.scale < other.scale {
+		return d.rescale(other.scale), other
+	}
+	if d.scale > other.scale {
+		return d, other.rescale(d.scale)
+	}
+	return d, other
+}
+
+// Add returns d + other.
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b := d.align(other)
+	return Decimal{unscaled: new(big.Int).Add(a.value(), b.value()), scale: a.scale}
+}
+
+// Sub returns d - other.
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b := d.align(other)
+	return Decimal{unscaled: new(big.Int).Sub(a.value(), b.value()), scale: a.scale}
+}
+
+// Mul returns d * other.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.value(), other.value()), scale: d.scale + other.scale}
+}
+
+// Div returns d / other rounded half away from zero to places decimal places, it panics if other is zero.
+func (d Decimal) Div(other Decimal, places int32) Decimal {
+	if other.Sign() == 0 {
+		panic("decimal division by zero")
+	}
+	quotient := new(big.Rat).Quo(d.rat(), other.rat())
+	return roundRat(quotient, places)
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.value()), scale: d.scale}
+}
+
+// Round returns d rounded half away from zero to places decimal places, e.g. 2 for cents.
+func (d Decimal) Round(places int32) Decimal {
+	if places >= d.scale {
+		return d.rescale(places)
+	}
+	return roundRat(d.rat(), places)
+}
+
+// Cmp compares d and other, it returns -1, 0 or +1 if d is less than, equal to or greater than other.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b := d.align(other)
+	return a.value().Cmp(b.value())
+}
+
+// Equal checks whether d and other are the same number, regardless of their scales.
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// Sign returns -1, 0 or +1 for the negative, zero or positive d.
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+// IsZero checks whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Float64 returns the nearest float64 of d, for display and statistics only.
+func (d Decimal) Float64() float64 {
+	f, _ := d.rat().Float64()
+	return f
+}
+
+func (d Decimal) rat() *big.Rat {
+	denominator := new(big.Int).Exp(bigTen, big.NewInt(int64(d.scale)), nil)
+	return new(big.Rat).SetFrac(d.value(), denominator)
+}
+
+// roundRat rounds r half away from zero to places decimal places.
+func roundRat(r *big.Rat, places int32) Decimal {
+	numerator := new(big.Int).Mul(r.Num(), new(big.Int).Exp(bigTen, big.NewInt(int64(places)), nil))
+	quotient, remainder := new(big.Int).QuoRem(numerator, r.Denom(), new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(r.Denom()) >= 0 {
+		if numerator.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return Decimal{unscaled: quotient, scale: places}
+}
+
+// String returns d in plain notation with its scale, e.g. "1.50".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.value()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale <= 0 {
+		return sign + digits + strings.Repeat("0", int(-d.scale))
+	}
+	if len(digits) <= int(d.scale) {
+		digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// MarshalText encodes d as its String.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes d by ParseDecimal.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON encodes d as a JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON decodes d from a JSON number or string, the empty string is 0.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+		if len(bytes.TrimSpace(data)) == 0 {
+			*d = Decimal{}
+			return nil
+		}
+	}
+	return d.UnmarshalText(data)
+}
+
+// SumDecimals returns the sum of the values.
+func SumDecimals(values ...Decimal) Decimal {
+	sum := Decimal{}
+	for _, value := range values {
+		sum = sum.Add(value)
+	}
+	return sum
+}
@@ -0,0 +1,44 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+//go:build go1.18
+// +build go1.18
+
+package service
+
+import (
+	"testing"
+)
+
+func FuzzParseDecimal(f *testing.F) {
+	for _, text := range []string{"0", "12.30", "-0.05", "1.5e-3", "2.5E2", "1e2147483648", "1e-4096", "9e4096"} {
+		f.Add(text)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		d, err := ParseDecimal(text)
+		if err != nil {
+			return
+		}
+		parsed, err := ParseDecimal(d.String())
+		if err != nil {
+			t.Fatalf("parse %q of %q: %s", d.String(), text, err)
+		}
+		if !parsed.Equal(d) {
+			t.Fatalf("%q of %q parsed as %q", d.String(), text, parsed.String())
+		}
+	})
+}
@@ -0,0 +1,128 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+)
+
+func TestParseDecimal(t *testing.T) {
+	for text, expected := range map[string]string{
+		"0":        "0",
+		"12.30":    "12.30",
+		"-0.05":    "-0.05",
+		".5":       "0.5",
+		"+1.":      "1",
+		"1.5e-3":   "0.0015",
+		"2.5E2":    "250",
+		"0.000001": "0.000001",
+	} {
+		d, err := ParseDecimal(text)
+		assert.Nil(t, err, text)
+		assert.Equal(t, expected, d.String(), text)
+	}
+	for _, text := range []string{"", "-", ".", "1.2.3", "1e", "--1", "1,5", "abc",
+		"1e2147483648", "1e2147483647", "1e-2147483648", "1e4097", "0." + strings.Repeat("0", 4096) + "1"} {
+		_, err := ParseDecimal(text)
+		assert.NotNil(t, err, text)
+	}
+	assert.Equal(t, "0", Decimal{}.String())
+
+	d, err := ParseDecimal("1e4096")
+	assert.Nil(t, err)
+	assert.Equal(t, 4097, len(d.String()))
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	// 0.1 + 0.2 is not 0.3 in float64
+	assert.True(t, MustParseDecimal("0.1").Add(MustParseDecimal("0.2")).Equal(MustParseDecimal("0.3")))
+	assert.Equal(t, "0.30", MustParseDecimal("0.1").Add(MustParseDecimal("0.20")).String())
+	assert.Equal(t, "-1.15", MustParseDecimal("1.05").Sub(MustParseDecimal("2.2")).String())
+	assert.Equal(t, "3.6900", MustParseDecimal("1.23").Mul(MustParseDecimal("3.00")).String())
+	assert.Equal(t, "0.33", DecimalFromInt(1).Div(DecimalFromInt(3), 2).String())
+	assert.Equal(t, "-0.67", DecimalFromInt(-2).Div(DecimalFromInt(3), 2).String())
+	assert.Equal(t, "1.50", NewDecimal(15, 1).Round(2).String())
+	assert.Equal(t, "2.35", MustParseDecimal("2.345").Round(2).String())
+	assert.Equal(t, "-2.35", MustParseDecimal("-2.345").Round(2).String())
+	assert.Equal(t, "2.34", MustParseDecimal("2.3449").Round(2).String())
+	assert.Equal(t, 1, MustParseDecimal("1.01").Cmp(DecimalFromInt(1)))
+	assert.Equal(t, -1, MustParseDecimal("-1").Sign())
+	assert.True(t, Decimal{}.IsZero())
+	assert.Equal(t, "5.50", MustParseDecimal("-5.50").Neg().String())
+	assert.Equal(t, 0.25, MustParseDecimal("0.25").Float64())
+	assert.Equal(t, "0.6", SumDecimals(MustParseDecimal("0.1"), MustParseDecimal("0.2"), MustParseDecimal("0.3")).String())
+	assert.Panics(t, func() { DecimalFromInt(1).Div(Decimal{}, 2) })
+}
+
+func TestDecimalJSON(t *testing.T) {
+	type Price struct {
+		Price   *Decimal `json:"price"`
+		Balance Decimal  `json:"balance"`
+		Bonus   *Decimal `json:"bonus"`
+	}
+	price := &Price{}
+	err := json.Unmarshal([]byte(`{"price": 0.1000, "balance": "123456789012345678.99", "bonus": null}`), price)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.1000", price.Price.String())
+	assert.Equal(t, "123456789012345678.99", price.Balance.String())
+	assert.Nil(t, price.Bonus)
+
+	content, err := json.Marshal(price)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"price":0.1000,"balance":123456789012345678.99,"bonus":null}`, string(content))
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"price": true}`), price))
+	assert.Nil(t, json.Unmarshal([]byte(`{"price": ""}`), price))
+	assert.True(t, price.Price.IsZero())
+}
+
+func TestGetPriceDecimal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "instance", r.URL.Query().Get("resources.1.type"))
+		assert.Equal(t, "2", r.URL.Query().Get("resources.1.cpu"))
+		assert.Equal(t, "eip", r.URL.Query().Get("resources.2.type"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"action":"GetPriceResponse","ret_code":0,"price_set":[
+			{"sequence":0,"resource_type":"instance","price":0.1},
+			{"sequence":1,"resource_type":"eip","price":"0.2"}]}`))
+	}))
+	defer server.Close()
+
+	conf, err := config.NewWithEndpoint("AccessKeyID", "SecretAccessKey", server.URL+"/iaas")
+	assert.Nil(t, err)
+	qcService, err := Init(conf)
+	assert.Nil(t, err)
+	billingService, err := qcService.Billing("pek3a")
+	assert.Nil(t, err)
+
+	_, err = billingService.GetPrice(&GetPriceInput{Resources: []*PriceResource{{CPU: Int(2)}}})
+	assert.NotNil(t, err)
+	output, err := billingService.GetPrice(&GetPriceInput{Resources: []*PriceResource{
+		{Type: String("instance"), CPU: Int(2), Memory: Int(4096)},
+		{Type: String("eip"), Bandwidth: Int(2)},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(output.PriceSet))
+	assert.Equal(t, "0.3", output.Total().String())
+}
@@ -0,0 +1,39 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+//go:build go1.18
+// +build go1.18
+
+package service
+
+import (
+	"testing"
+)
+
+func FuzzDecodeResponse(f *testing.F) {
+	for response := range decodeTestResponses {
+		f.Add([]byte(response))
+	}
+	f.Add([]byte(`{"instance_set":[null,{"vxnets":[null]}],"ret_code":0}`))
+	f.Add([]byte(`{"cluster_set":null,"ret_code":null}`))
+	f.Add([]byte(`[]`))
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		for _, newOutput := range decodeTestResponses {
+			decodeResponse(t, content, newOutput())
+		}
+	})
+}
@@ -0,0 +1,161 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/request"
+	"github.com/yunify/qingcloud-sdk-go/request/data"
+)
+
+var decodeTestResponses = map[string]func() interface{}{
+	`{"action":"DescribeInstancesResponse","total_count":1,"ret_code":0,"instance_set":[{
+		"instance_id":"i-abcdefgh","status":"running","create_time":"2017-03-21T15:32:49Z","owner":"usr-abcdefgh",
+		"vxnets":[{"vxnet_id":"vxnet-0","nic_id":"52:54:a9:6a:be:32","private_ip":"10.0.0.2"}],
+		"eip":{"eip_id":"eip-abcdefgh"},"security_group":{"security_group_id":"sg-abcdefgh"},
+		"volumes":[{"volume_id":"vol-abcdefgh","size":20}],"volume_ids":["vol-abcdefgh"],
+		"tags":[{"tag_id":"tag-abcdefgh"}]}]}`: func() interface{} { return &DescribeInstancesOutput{} },
+	`{"action":"DescribeClustersResponse","total_count":1,"ret_code":0,"cluster_set":[{
+		"cluster_id":"cl-abcdefgh","roles":["master","slave"],"role_count":{"master":1},
+		"health_check_enablement":{"master":true},"nodes":[{"node_id":"cln-abcdefgh","role":"master"}]}]}`: func() interface{} { return &DescribeClustersOutput{} },
+	`{"action":"DescribeLoadBalancerListenersResponse","total_count":1,"ret_code":0,"loadbalancer_listener_set":[{
+		"loadbalancer_listener_id":"lbl-abcdefgh","listener_port":80,"healthy_check_option":"10|5|2|5",
+		"backends":[{"loadbalancer_backend_id":"lbb-abcdefgh","port":80}]}]}`: func() interface{} { return &DescribeLoadBalancerListenersOutput{} },
+	`{"action":"DescribeRouterStaticsResponse","total_count":1,"ret_code":0,"router_static_set":[{
+		"router_static_id":"rtm-abcdefgh","static_type":4,"val1":"gre|1.2.3.4|key","val2":"10.0.0.1"}]}`: func() interface{} { return &DescribeRouterStaticsOutput{} },
+	`{"action":"DescribeJobsResponse","total_count":1,"ret_code":0,"job_set":[{
+		"job_id":"j-abcdefgh","status":"successful","resource_ids":"i-abcdefgh"}]}`: func() interface{} { return &DescribeJobsOutput{} },
+}
+
+// decodeTestMutations are the values each value of the responses is replaced with.
+var decodeTestMutations = []interface{}{nil, "", "x", 0, -1, 1.5, true, []interface{}{}, []interface{}{nil}, map[string]interface{}{}}
+
+func TestDecodeMutatedResponses(t *testing.T) {
+	for response, newOutput := range decodeTestResponses {
+		var document interface{}
+		assert.Nil(t, json.Unmarshal([]byte(response), &document))
+
+		mutateJSON(document, func() {
+			content, err := json.Marshal(document)
+			assert.Nil(t, err)
+			decodeResponse(t, content, newOutput())
+		})
+		for i := 0; i < len(response); i += 7 {
+			decodeResponse(t, []byte(response[:i]), newOutput())
+		}
+	}
+}
+
+// mutateJSON replaces each value of the document with each mutation in turn, and calls f.
+func mutateJSON(document interface{}, f func()) {
+	switch v := document.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			for _, mutation := range decodeTestMutations {
+				v[key] = mutation
+				f()
+			}
+			v[key] = value
+			mutateJSON(value, f)
+		}
+	case []interface{}:
+		for index, value := range v {
+			for _, mutation := range decodeTestMutations {
+				v[index] = mutation
+				f()
+			}
+			v[index] = value
+			mutateJSON(value, f)
+		}
+	}
+}
+
+// decodeResponse unpacks the content into the output and uses the decoded items,
+// it fails the test on panic but not on decode errors.
+func decodeResponse(t *testing.T, content []byte, output interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("decode %s panic: %v", reflect.TypeOf(output), r)
+		}
+	}()
+
+	httpResponse := &http.Response{Header: http.Header{}, StatusCode: 200}
+	httpResponse.Header.Set("Content-Type", "application/json")
+	httpResponse.Body = ioutil.NopCloser(bytes.NewReader(content))
+	outputValue := reflect.ValueOf(output)
+	unpacker := request.Unpacker{}
+	if err := unpacker.UnpackHTTPRequest(&data.Operation{}, httpResponse, &outputValue); err != nil {
+		return
+	}
+
+	switch x := output.(type) {
+	case *DescribeInstancesOutput:
+		for _, instance := range x.InstanceSet {
+			instance.Validate()
+			for _, vxnet := range instance.VxNets {
+				StringValue(vxnet.PrivateIP)
+			}
+			for _, tag := range instance.Tags {
+				StringValue(tag.TagID)
+			}
+		}
+	case *DescribeClustersOutput:
+		for _, cluster := range x.ClusterSet {
+			cluster.Validate()
+			cluster.HasRole("master")
+			cluster.HealthCheckEnabled("master")
+			for _, node := range cluster.Nodes {
+				StringValue(node.Role)
+				node.IsReady()
+			}
+		}
+	case *DescribeLoadBalancerListenersOutput:
+		for _, listener := range x.LoadBalancerListenerSet {
+			listener.Validate()
+			ParseHealthyCheckOption(StringValue(listener.HealthyCheckOption))
+			for _, backend := range listener.Backends {
+				strconv.Itoa(IntValue(backend.Port))
+			}
+		}
+	case *DescribeRouterStaticsOutput:
+		for _, static := range x.RouterStaticSet {
+			static.Validate()
+			ParseGRETunnelStatic(static)
+			ParseDNSStatic(static)
+		}
+	case *DescribeJobsOutput:
+		for _, job := range x.JobSet {
+			job.Validate()
+		}
+	}
+}
+
+func TestDecodeInt64Fields(t *testing.T) {
+	output := &DescribeSnapshotExportsOutput{}
+	err := json.Unmarshal([]byte(`{"action":"DescribeSnapshotExportsResponse","ret_code":0,
+		"snapshot_export_set":[{"snapshot_export_id":"se-abcdefgh","size":9007199254740993}]}`), output)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9007199254740993), Int64Value(output.SnapshotExportSet[0].Size))
+}
@@ -0,0 +1,80 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+func TestDescribeInstancesOutputVerbosity(t *testing.T) {
+	// verbose=0
+	output := &DescribeInstancesOutput{}
+	_, err := utils.JSONDecode([]byte(`{
+		"action": "DescribeInstancesResponse",
+		"instance_set": [{
+			"instance_id": "i-abcdefgh",
+			"instance_name": "web",
+			"status": "running",
+			"vxnets": [{"vxnet_id": "vxnet-0", "nic_id": "52:54:a9:6a:be:32", "private_ip": "10.0.0.2"}],
+			"volume_ids": ["vol-abcdefgh"],
+			"create_time": "2017-03-21T15:32:49Z"
+		}],
+		"total_count": 1,
+		"ret_code": 0
+	}`), output)
+	assert.Nil(t, err)
+	assert.Equal(t, "i-abcdefgh", StringValue(output.InstanceSet[0].InstanceID))
+	assert.Equal(t, []string{"vol-abcdefgh"}, StringValueSlice(output.InstanceSet[0].VolumeIDs))
+	assert.Nil(t, output.InstanceSet[0].EIP)
+
+	// verbose=1 returns the related resources as objects
+	output = &DescribeInstancesOutput{}
+	_, err = utils.JSONDecode([]byte(`{
+		"action": "DescribeInstancesResponse",
+		"instance_set": [{
+			"instance_id": "i-abcdefgh",
+			"status": "running",
+			"eip": {"eip_id": "eip-abcdefgh", "eip_addr": "139.198.0.1"},
+			"security_group": {"security_group_id": "sg-abcdefgh", "security_group_name": "default"},
+			"volumes": [{"volume_id": "vol-abcdefgh", "size": 20}],
+			"keypair_ids": ["kp-abcdefgh"]
+		}],
+		"total_count": 1,
+		"ret_code": 0
+	}`), output)
+	assert.Nil(t, err)
+	assert.Equal(t, "eip-abcdefgh", StringValue(output.InstanceSet[0].EIP.EIPID))
+	assert.Equal(t, "sg-abcdefgh", StringValue(output.InstanceSet[0].SecurityGroup.SecurityGroupID))
+	assert.Equal(t, 20, IntValue(output.InstanceSet[0].Volumes[0].Size))
+
+	// fields projection returns only the selected fields
+	output = &DescribeInstancesOutput{}
+	_, err = utils.JSONDecode([]byte(`{
+		"action": "DescribeInstancesResponse",
+		"instance_set": [{"instance_id": "i-abcdefgh", "status": "running"}],
+		"total_count": 1,
+		"ret_code": 0
+	}`), output)
+	assert.Nil(t, err)
+	assert.Equal(t, "running", StringValue(output.InstanceSet[0].Status))
+	assert.Nil(t, output.InstanceSet[0].InstanceName)
+	assert.Nil(t, output.InstanceSet[0].CreateTime)
+	assert.Nil(t, output.InstanceSet[0].Validate())
+}
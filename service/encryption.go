@@ -0,0 +1,101 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+)
+
+// Cipher algorithms of the encrypted disks.
+const (
+	CipherAlgAES256 = "aes256"
+	CipherAlgSM4    = "sm4"
+)
+
+// EncryptionOptions is the typed form of the disk encryption parameters.
+type EncryptionOptions struct {
+	// CipherAlg is left to the server default if empty.
+	CipherAlg string
+	// CmkID selects the customer master key of the volumes, the system key
+	// is used if empty. It is not supported by RunInstances.
+	CmkID string
+}
+
+// Validate validates the EncryptionOptions.
+func (v *EncryptionOptions) Validate() error {
+	switch v.CipherAlg {
+	case "", CipherAlgAES256, CipherAlgSM4:
+		return nil
+	}
+	return fmt.Errorf(`"CipherAlg" should be one of "%s" and "%s", got "%s"`, CipherAlgAES256, CipherAlgSM4, v.CipherAlg)
+}
+
+// ApplyToVolumes enables the encryption of the volumes to create.
+func (v *EncryptionOptions) ApplyToVolumes(input *CreateVolumesInput) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	input.Encryption = String("1")
+	if v.CipherAlg != "" {
+		input.CipherAlg = String(v.CipherAlg)
+	}
+	if v.CmkID != "" {
+		input.CmkID = String(v.CmkID)
+	}
+	return nil
+}
+
+// ApplyToInstances enables the encryption of the OS disks of the instances
+// to run, and of their auto created volumes if withVolumes.
+func (v *EncryptionOptions) ApplyToInstances(input *RunInstancesInput, withVolumes bool) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	if v.CmkID != "" {
+		return fmt.Errorf(`"CmkID" is not supported by RunInstances`)
+	}
+	input.OsDiskEncryption = Int(1)
+	if withVolumes {
+		input.VolumeEncryption = String("1")
+	}
+	if v.CipherAlg != "" {
+		input.CipherAlg = String(v.CipherAlg)
+	}
+	return nil
+}
+
+// IsEncrypted checks whether the volume is encrypted.
+func (v *Volume) IsEncrypted() bool {
+	return IntValue(v.Encryption) == 1
+}
+
+// IsOSDiskEncrypted checks whether the OS disk of the instance is encrypted.
+func (v *Instance) IsOSDiskEncrypted() bool {
+	return IntValue(v.OSDiskEncryption) == 1
+}
+
+// UnencryptedVolumes returns the volumes which are not encrypted, for the
+// compliance reporting.
+func UnencryptedVolumes(volumes []*Volume) []*Volume {
+	unencrypted := []*Volume{}
+	for _, volume := range volumes {
+		if volume != nil && !volume.IsEncrypted() {
+			unencrypted = append(unencrypted, volume)
+		}
+	}
+	return unencrypted
+}
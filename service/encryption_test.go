@@ -0,0 +1,56 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+func TestEncryptionOptionsApply(t *testing.T) {
+	volumeInput := &CreateVolumesInput{Size: Int(10)}
+	options := &EncryptionOptions{CipherAlg: CipherAlgSM4, CmkID: "cmk-abcdefgh"}
+	assert.Nil(t, options.ApplyToVolumes(volumeInput))
+	assert.Equal(t, "1", StringValue(volumeInput.Encryption))
+	assert.Equal(t, CipherAlgSM4, StringValue(volumeInput.CipherAlg))
+	assert.Equal(t, "cmk-abcdefgh", StringValue(volumeInput.CmkID))
+
+	instanceInput := &RunInstancesInput{}
+	assert.NotNil(t, options.ApplyToInstances(instanceInput, true))
+	assert.Nil(t, (&EncryptionOptions{}).ApplyToInstances(instanceInput, true))
+	assert.Equal(t, 1, IntValue(instanceInput.OsDiskEncryption))
+	assert.Equal(t, "1", StringValue(instanceInput.VolumeEncryption))
+	assert.Nil(t, instanceInput.CipherAlg)
+
+	assert.NotNil(t, (&EncryptionOptions{CipherAlg: "des"}).ApplyToVolumes(&CreateVolumesInput{}))
+}
+
+func TestDecodeEncryptionStatus(t *testing.T) {
+	output := &DescribeVolumesOutput{}
+	_, err := utils.JSONDecode([]byte(`{"volume_set": [
+		{"volume_id": "vol-a", "encryption": 1, "cipher_alg": "aes256", "instances": [{"instance_id": "i-a", "os_disk_encryption": 1}]},
+		{"volume_id": "vol-b", "encryption": 0}
+	]}`), output)
+	assert.Nil(t, err)
+	assert.True(t, output.VolumeSet[0].IsEncrypted())
+	assert.True(t, output.VolumeSet[0].Instances[0].IsOSDiskEncrypted())
+	unencrypted := UnencryptedVolumes(output.VolumeSet)
+	assert.Equal(t, 1, len(unencrypted))
+	assert.Equal(t, "vol-b", StringValue(unencrypted[0].VolumeID))
+}
@@ -0,0 +1,153 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// A Filter stores the server side filters shared by Describe inputs.
+type Filter struct {
+	// Tags filters resources attached all of the tag IDs.
+	Tags []string
+	// Status filters resources in one of the status.
+	Status []string
+	// SearchWord filters resources by the keyword of ID or name.
+	SearchWord string
+	// Owner filters resources owned by the user ID.
+	Owner string
+	// Console filters resources of the console ID, it is accepted only with admin or console keys.
+	Console string
+	// ProjectID filters resources belong to the project.
+	ProjectID string
+	// SortKey sorts resources by the key, e.g. "create_time".
+	SortKey string
+	// Reverse sorts resources in descending order of SortKey.
+	Reverse bool
+	// Verbose returns the related resources of each resource.
+	Verbose bool
+	// Fields returns only the given fields of each resource, to cut the payload size.
+	Fields []string
+}
+
+// TagFilter returns a Filter with the given tag IDs.
+func TagFilter(tags ...string) *Filter {
+	return &Filter{Tags: tags}
+}
+
+// WithStatus combines the status filter into the Filter.
+func (f *Filter) WithStatus(status ...string) *Filter {
+	f.Status = append(f.Status, status...)
+	return f
+}
+
+// Apply sets the filters into the given Describe input.
+// It returns error if the input does not support a filter which is set.
+func (f *Filter) Apply(input interface{}) error {
+	value := reflect.ValueOf(input)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("filter can not be applied to %T", input)
+	}
+
+	if err := setFilterField(value.Elem(), "tags", f.Tags); err != nil {
+		return err
+	}
+	if err := setFilterField(value.Elem(), "status", f.Status); err != nil {
+		return err
+	}
+	if err := setFilterField(value.Elem(), "search_word", optionalValue(f.SearchWord)); err != nil {
+		return err
+	}
+	if err := setFilterField(value.Elem(), "owner", optionalValue(f.Owner)); err != nil {
+		return err
+	}
+	if err := setFilterField(value.Elem(), "console", optionalValue(f.Console)); err != nil {
+		return err
+	}
+	if err := setFilterField(value.Elem(), "project_id", optionalValue(f.ProjectID)); err != nil {
+		return err
+	}
+	if err := setFilterField(value.Elem(), "sort_key", optionalValue(f.SortKey)); err != nil {
+		return err
+	}
+	if f.Reverse {
+		if err := setFilterField(value.Elem(), "reverse", []string{"1"}); err != nil {
+			return err
+		}
+	}
+	if f.Verbose {
+		if err := setFilterField(value.Elem(), "verbose", []string{"1"}); err != nil {
+			return err
+		}
+	}
+	if err := setFilterField(value.Elem(), "fields", f.Fields); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func optionalValue(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return []string{v}
+}
+
+func setFilterField(input reflect.Value, name string, values []string) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	for i := 0; i < input.NumField(); i++ {
+		if input.Type().Field(i).Tag.Get("name") != name {
+			continue
+		}
+
+		field := input.Field(i)
+		switch field.Interface().(type) {
+		case []*string:
+			field.Set(reflect.ValueOf(StringSlice(values)))
+		case *string:
+			if len(values) > 1 {
+				return fmt.Errorf(
+					`"%s" of %s accepts only one value, got "%s"`,
+					name, input.Type().Name(), strings.Join(values, ","))
+			}
+			field.Set(reflect.ValueOf(String(values[0])))
+		case *int:
+			if len(values) > 1 {
+				return fmt.Errorf(
+					`"%s" of %s accepts only one value, got "%s"`,
+					name, input.Type().Name(), strings.Join(values, ","))
+			}
+			number, err := strconv.Atoi(values[0])
+			if err != nil {
+				return fmt.Errorf(`"%s" of %s accepts only integer, got "%s"`, name, input.Type().Name(), values[0])
+			}
+			field.Set(reflect.ValueOf(Int(number)))
+		default:
+			return fmt.Errorf(`"%s" of %s has unsupported type %s`, name, input.Type().Name(), field.Type())
+		}
+		return nil
+	}
+
+	return fmt.Errorf(`"%s" filter is not supported by %s`, name, input.Type().Name())
+}
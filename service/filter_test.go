@@ -0,0 +1,126 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterApply(t *testing.T) {
+	instances := &DescribeInstancesInput{}
+	err := TagFilter("tag-1", "tag-2").WithStatus("running", "stopped").Apply(instances)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tag-1", "tag-2"}, StringValueSlice(instances.Tags))
+	assert.Equal(t, []string{"running", "stopped"}, StringValueSlice(instances.Status))
+
+	clusters := &DescribeClustersInput{}
+	err = TagFilter("tag-1").WithStatus("active").Apply(clusters)
+	assert.Nil(t, err)
+	assert.Equal(t, "active", StringValue(clusters.Status))
+
+	err = TagFilter().WithStatus("active", "stopped").Apply(clusters)
+	assert.NotNil(t, err)
+
+	err = (&Filter{Fields: []string{"job_id"}}).Apply(&DescribeJobsInput{})
+	assert.NotNil(t, err)
+
+	err = TagFilter("tag-1").Apply(DescribeInstancesInput{})
+	assert.NotNil(t, err)
+
+	volumes := &DescribeVolumesInput{}
+	err = (&Filter{SearchWord: "data", Owner: "usr-1", ProjectID: "pro-1"}).Apply(volumes)
+	assert.Nil(t, err)
+	assert.Equal(t, "data", StringValue(volumes.SearchWord))
+	assert.Equal(t, "usr-1", StringValue(volumes.Owner))
+	assert.Equal(t, "pro-1", StringValue(volumes.ProjectID))
+	assert.Nil(t, volumes.Tags)
+
+	eips := &DescribeEIPsInput{}
+	err = (&Filter{SortKey: "create_time", Reverse: true}).Apply(eips)
+	assert.Nil(t, err)
+	assert.Equal(t, "create_time", StringValue(eips.SortKey))
+	assert.Equal(t, 1, IntValue(eips.Reverse))
+
+	instances = &DescribeInstancesInput{}
+	err = (&Filter{SortKey: "create_time", Reverse: true}).Apply(instances)
+	assert.Nil(t, err)
+	assert.Equal(t, "1", StringValue(instances.Reverse))
+
+	instances = &DescribeInstancesInput{}
+	err = (&Filter{Verbose: true, Fields: []string{"instance_id", "status"}}).Apply(instances)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, IntValue(instances.Verbose))
+	assert.Equal(t, []string{"instance_id", "status"}, StringValueSlice(instances.Fields))
+
+	err = (&Filter{Fields: []string{"job_id"}}).Apply(&DescribeJobsInput{})
+	assert.NotNil(t, err)
+
+	vips := &DescribeVIPsInput{}
+	err = (&Filter{Owner: "usr-1", Console: "qingcloud"}).Apply(vips)
+	assert.Nil(t, err)
+	assert.Equal(t, "usr-1", StringValue(vips.Owner))
+	assert.Equal(t, "qingcloud", StringValue(vips.Console))
+
+	err = (&Filter{Console: "qingcloud"}).Apply(&DescribeZonesInput{})
+	assert.NotNil(t, err)
+}
+
+// describeInputFields parses the package and returns the field names of every Describe input.
+func describeInputFields(t *testing.T) map[string]map[string]bool {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), ".", nil, 0)
+	assert.Nil(t, err)
+	inputs := map[string]map[string]bool{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(node ast.Node) bool {
+				spec, ok := node.(*ast.TypeSpec)
+				if !ok || !strings.HasPrefix(spec.Name.Name, "Describe") || !strings.HasSuffix(spec.Name.Name, "Input") {
+					return true
+				}
+				structType, ok := spec.Type.(*ast.StructType)
+				if !ok {
+					return true
+				}
+				fields := map[string]bool{}
+				for _, field := range structType.Fields.List {
+					for _, name := range field.Names {
+						fields[name.Name] = true
+					}
+				}
+				inputs[spec.Name.Name] = fields
+				return true
+			})
+		}
+	}
+	return inputs
+}
+
+func TestDescribeInputsFilters(t *testing.T) {
+	inputs := describeInputFields(t)
+	assert.NotEmpty(t, inputs)
+	for name, fields := range inputs {
+		for _, filter := range []string{"Tags", "SearchWord", "Owner", "ProjectID", "Status"} {
+			assert.True(t, fields[filter], "%s has no %s filter", name, filter)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hostnameLabelPattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// ValidateHostname checks the hostname is a valid RFC 1123 hostname.
+// Windows instances only accept a single label up to 15 characters.
+func ValidateHostname(hostname string, platform string) error {
+	if len(hostname) == 0 || len(hostname) > 253 {
+		return fmt.Errorf(`"Hostname" should have 1 to 253 characters, got %d`, len(hostname))
+	}
+	labels := strings.Split(hostname, ".")
+	for _, label := range labels {
+		if !hostnameLabelPattern.MatchString(label) {
+			return fmt.Errorf(`"Hostname" label "%s" is invalid`, label)
+		}
+	}
+	if platform == PlatformWindows && (len(labels) > 1 || len(hostname) > 15) {
+		return fmt.Errorf(`"Hostname" of Windows should be a single label up to 15 characters, got "%s"`, hostname)
+	}
+	return nil
+}
+
+// SequentialHostnames generates count hostnames from the pattern, "{n}" in
+// the pattern is replaced by the sequence number from start, "{0n}" pads it
+// with zeros to the width of the last number. The number is appended after a
+// "-" if the pattern has no placeholder.
+func SequentialHostnames(pattern string, start int, count int) ([]string, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("hostname count should be positive, got %d", count)
+	}
+	if !strings.Contains(pattern, "{n}") && !strings.Contains(pattern, "{0n}") {
+		pattern += "-{n}"
+	}
+	width := len(strconv.Itoa(start + count - 1))
+	hostnames := make([]string, 0, count)
+	for i := start; i < start+count; i++ {
+		hostname := strings.Replace(pattern, "{n}", strconv.Itoa(i), -1)
+		hostname = strings.Replace(hostname, "{0n}", fmt.Sprintf("%0*d", width, i), -1)
+		if err := ValidateHostname(hostname, ""); err != nil {
+			return nil, err
+		}
+		hostnames = append(hostnames, hostname)
+	}
+	return hostnames, nil
+}
+
+// InstanceDNS overrides the resolver of an instance instead of the one of its vxnet.
+type InstanceDNS struct {
+	// Servers are the IP addresses of the name servers, up to 3.
+	Servers []string
+	// SearchDomains are the domains appended to the names without dot, up to 6.
+	SearchDomains []string
+}
+
+// Validate validates the InstanceDNS.
+func (v *InstanceDNS) Validate() error {
+	if len(v.Servers) > 3 {
+		return fmt.Errorf(`"DNSServers" should have at most 3 servers, got %d`, len(v.Servers))
+	}
+	for _, server := range v.Servers {
+		if net.ParseIP(server) == nil {
+			return fmt.Errorf(`"DNSServers" should be IP addresses, got "%s"`, server)
+		}
+	}
+	if len(v.SearchDomains) > 6 {
+		return fmt.Errorf(`"DNSSearchDomains" should have at most 6 domains, got %d`, len(v.SearchDomains))
+	}
+	for _, domain := range v.SearchDomains {
+		if err := ValidateHostname(domain, ""); err != nil {
+			return fmt.Errorf(`"DNSSearchDomains" domain "%s" is invalid`, domain)
+		}
+	}
+	return nil
+}
+
+// ApplyTo sets the DNS parameters of the RunInstancesInput.
+func (v *InstanceDNS) ApplyTo(input *RunInstancesInput) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	input.DNSServers = nil
+	if len(v.Servers) > 0 {
+		input.DNSServers = StringSlice(v.Servers)
+	}
+	input.DNSSearchDomains = nil
+	if len(v.SearchDomains) > 0 {
+		input.DNSSearchDomains = StringSlice(v.SearchDomains)
+	}
+	return nil
+}
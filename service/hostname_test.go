@@ -0,0 +1,70 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateHostname(t *testing.T) {
+	assert.Nil(t, ValidateHostname("web-1", PlatformLinux))
+	assert.Nil(t, ValidateHostname("web-1.pek3a.internal", PlatformLinux))
+	assert.NotNil(t, ValidateHostname("", PlatformLinux))
+	assert.NotNil(t, ValidateHostname("-web", PlatformLinux))
+	assert.NotNil(t, ValidateHostname("web_1", PlatformLinux))
+	assert.NotNil(t, ValidateHostname("web..internal", PlatformLinux))
+	assert.Nil(t, ValidateHostname("WIN-DC01", PlatformWindows))
+	assert.NotNil(t, ValidateHostname("win.internal", PlatformWindows))
+	assert.NotNil(t, ValidateHostname("windows-server-01", PlatformWindows))
+}
+
+func TestSequentialHostnames(t *testing.T) {
+	hostnames, err := SequentialHostnames("web", 1, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"web-1", "web-2", "web-3"}, hostnames)
+
+	hostnames, err = SequentialHostnames("node{0n}.pek3a", 8, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"node08.pek3a", "node09.pek3a", "node10.pek3a"}, hostnames)
+
+	hostnames, err = SequentialHostnames("db-{n}-a", 0, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"db-0-a"}, hostnames)
+
+	_, err = SequentialHostnames("web", 1, 0)
+	assert.NotNil(t, err)
+	_, err = SequentialHostnames("web_{n}", 1, 2)
+	assert.NotNil(t, err)
+}
+
+func TestInstanceDNSApplyTo(t *testing.T) {
+	input := &RunInstancesInput{DNSServers: StringSlice([]string{"10.0.0.1"})}
+	dns := &InstanceDNS{SearchDomains: []string{"pek3a.internal"}}
+	assert.Nil(t, dns.ApplyTo(input))
+	assert.Nil(t, input.DNSServers)
+	assert.Equal(t, []string{"pek3a.internal"}, StringValueSlice(input.DNSSearchDomains))
+
+	dns = &InstanceDNS{Servers: []string{"10.0.0.2", "2001:db8::1"}}
+	assert.Nil(t, dns.ApplyTo(input))
+	assert.Equal(t, []string{"10.0.0.2", "2001:db8::1"}, StringValueSlice(input.DNSServers))
+
+	assert.NotNil(t, (&InstanceDNS{Servers: []string{"dns.example.com"}}).Validate())
+	assert.NotNil(t, (&InstanceDNS{Servers: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"}}).Validate())
+	assert.NotNil(t, (&InstanceDNS{SearchDomains: []string{"bad_domain"}}).Validate())
+}
@@ -174,9 +174,14 @@ func (s *ImageService) DescribeImageUsers(i *DescribeImageUsersInput) (*Describe
 }
 
 type DescribeImageUsersInput struct {
-	ImageID *string `json:"image_id" name:"image_id" location:"params"` // Required
-	Limit   *int    `json:"limit" name:"limit" default:"20" location:"params"`
-	Offset  *int    `json:"offset" name:"offset" default:"0" location:"params"`
+	ImageID    *string   `json:"image_id" name:"image_id" location:"params"` // Required
+	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
+	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
 }
 
 func (v *DescribeImageUsersInput) Validate() error {
@@ -226,6 +231,8 @@ func (s *ImageService) DescribeImages(i *DescribeImagesInput) (*DescribeImagesOu
 }
 
 type DescribeImagesInput struct {
+	Console  *string   `json:"console" name:"console" location:"params"`
+	Fields   []*string `json:"fields" name:"fields" location:"params"`
 	Images   []*string `json:"images" name:"images" location:"params"`
 	Limit    *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset   *int      `json:"offset" name:"offset" default:"0" location:"params"`
@@ -236,7 +243,9 @@ type DescribeImagesInput struct {
 	ProjectID     *string `json:"project_id" name:"project_id" location:"params"`
 	// Provider's available values: system, self
 	Provider   *string   `json:"provider" name:"provider" location:"params"`
+	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
 	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
 	Status     []*string `json:"status" name:"status" location:"params"`
 	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	// Verbose's available values: 0
@@ -421,6 +430,8 @@ func (s *ImageService) ModifyImageAttributes(i *ModifyImageAttributesInput) (*Mo
 }
 
 type ModifyImageAttributesInput struct {
+	// Deprecated's available values: 0, 1
+	Deprecated  *int    `json:"deprecated" name:"deprecated" location:"params"`
 	Description *string `json:"description" name:"description" location:"params"`
 	Image       *string `json:"image" name:"image" location:"params"` // Required
 	ImageName   *string `json:"image_name" name:"image_name" location:"params"`
@@ -428,6 +439,26 @@ type ModifyImageAttributesInput struct {
 
 func (v *ModifyImageAttributesInput) Validate() error {
 
+	if v.Deprecated != nil {
+		deprecatedValidValues := []string{"0", "1"}
+		deprecatedParameterValue := fmt.Sprint(*v.Deprecated)
+
+		deprecatedIsValid := false
+		for _, value := range deprecatedValidValues {
+			if value == deprecatedParameterValue {
+				deprecatedIsValid = true
+			}
+		}
+
+		if !deprecatedIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Deprecated",
+				ParameterValue: deprecatedParameterValue,
+				AllowedValues:  deprecatedValidValues,
+			}
+		}
+	}
+
 	if v.Image == nil {
 		return errors.ParameterRequiredError{
 			ParameterName: "Image",
@@ -126,6 +126,11 @@ type DescribeInstanceTypesInput struct {
 	Baremetal *int `json:"baremetal" name:"baremetal" location:"params"`
 	// 指定查询的云服务器类型
 	InstanceTypes []*string `json:"instance_types" name:"instance_types" location:"params"`
+	Owner         *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID     *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord    *string   `json:"search_word" name:"search_word" location:"params"`
+	Status        []*string `json:"status" name:"status" location:"params"`
+	Tags          []*string `json:"tags" name:"tags" location:"params"`
 }
 
 func (v *DescribeInstanceTypesInput) Validate() error {
@@ -168,6 +173,8 @@ func (s *InstanceService) DescribeInstances(i *DescribeInstancesInput) (*Describ
 }
 
 type DescribeInstancesInput struct {
+	Console *string   `json:"console" name:"console" location:"params"`
+	Fields  []*string `json:"fields" name:"fields" location:"params"`
 	ImageID []*string `json:"image_id" name:"image_id" location:"params"`
 	// InstanceClass's available values: 0, 1
 	InstanceClass *int      `json:"instance_class" name:"instance_class" location:"params"`
@@ -668,9 +675,13 @@ type RunInstancesInput struct {
 	CPUMax *int `json:"cpu_max" name:"cpu_max" location:"params"`
 	// CPUModel's available values: Westmere, SandyBridge, IvyBridge, Haswell, Broadwell
 	CPUModel *string `json:"cpu_model" name:"cpu_model" default:"Westmere" location:"params"`
-	Gpu      *int    `json:"gpu" name:"gpu" default:"0" location:"params"`
-	Hostname *string `json:"hostname" name:"hostname" location:"params"`
-	ImageID  *string `json:"image_id" name:"image_id" location:"params"` // Required
+	// DNSSearchDomains override the search domains of the instance resolver
+	DNSSearchDomains []*string `json:"dns_search_domains" name:"dns_search_domains" location:"params"`
+	// DNSServers override the name servers of the instance resolver
+	DNSServers []*string `json:"dns_servers" name:"dns_servers" location:"params"`
+	Gpu        *int      `json:"gpu" name:"gpu" default:"0" location:"params"`
+	Hostname   *string   `json:"hostname" name:"hostname" location:"params"`
+	ImageID    *string   `json:"image_id" name:"image_id" location:"params"` // Required
 	// InstanceClass's available values: 0, 1, 2, 3, 4, 5, 6, 100, 101, 200, 201, 300, 301
 	InstanceClass *int    `json:"instance_class" name:"instance_class" location:"params"`
 	InstanceName  *string `json:"instance_name" name:"instance_name" location:"params"`
@@ -704,9 +715,13 @@ type RunInstancesInput struct {
 	ProcessorType    *string   `json:"processor_type" name:"processor_type" location:"params"`
 	DefaultUser      *string   `json:"default_user" name:"default_user" location:"params"`
 	DefaultPasswd    *string   `json:"default_passwd" name:"default_passwd" location:"params"`
-	Hypervisor       *string   `json:"hypervisor" name:"hypervisor" location:"params"`
-	GpuClass         *string   `json:"gpu_class" name:"gpu_class" location:"params"`
-	PlaceGroupID     *string   `json:"place_group_id" name:"place_group_id" location:"params"`
+	// KMSServer is the key management server activating Windows, as host or host:port
+	KMSServer *string `json:"kms_server" name:"kms_server" location:"params"`
+	// NeedActivation's available values: 0, 1
+	NeedActivation *int    `json:"need_activation" name:"need_activation" default:"0" location:"params"`
+	Hypervisor     *string `json:"hypervisor" name:"hypervisor" location:"params"`
+	GpuClass       *string `json:"gpu_class" name:"gpu_class" location:"params"`
+	PlaceGroupID   *string `json:"place_group_id" name:"place_group_id" location:"params"`
 
 	AutoRenew            *string `json:"auto_renew" name:"auto_renew" location:"params"`
 	AutoVolumes          *string `json:"auto_volumes" name:"auto_volumes" location:"params"`
@@ -959,6 +974,26 @@ func (v *RunInstancesInput) Validate() error {
 		}
 	}
 
+	if v.NeedActivation != nil {
+		needActivationValidValues := []string{"0", "1"}
+		needActivationParameterValue := fmt.Sprint(*v.NeedActivation)
+
+		needActivationIsValid := false
+		for _, value := range needActivationValidValues {
+			if value == needActivationParameterValue {
+				needActivationIsValid = true
+			}
+		}
+
+		if !needActivationIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "NeedActivation",
+				ParameterValue: needActivationParameterValue,
+				AllowedValues:  needActivationValidValues,
+			}
+		}
+	}
+
 	if v.NeedUserdata != nil {
 		needUserdataValidValues := []string{"0", "1"}
 		needUserdataParameterValue := fmt.Sprint(*v.NeedUserdata)
@@ -1516,7 +1551,8 @@ type DescribeInstanceGroupsInput struct {
 	RootUserID *string `json:"root_user_id" name:"root_user_id" location:"params"`
 	SearchWord *string `json:"search_word" name:"search_word" location:"params"`
 	// sort key
-	SortKey *string `json:"sort_key" name:"sort_key" location:"params"`
+	SortKey *string   `json:"sort_key" name:"sort_key" location:"params"`
+	Status  []*string `json:"status" name:"status" location:"params"`
 	// filter by tags
 	Tags []*string `json:"tags" name:"tags" location:"params"`
 	// the number to specify the verbose level
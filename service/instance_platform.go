@@ -0,0 +1,164 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Platforms of images and instances.
+const (
+	PlatformLinux   = "linux"
+	PlatformWindows = "windows"
+)
+
+// Login modes of instances, Windows instances only support the password.
+const (
+	LoginModeKeyPair = "keypair"
+	LoginModePasswd  = "passwd"
+)
+
+// WindowsOptions is the typed form of the RunInstances parameters of Windows instances.
+type WindowsOptions struct {
+	// AdminUser renames the default administrator, it is kept if empty.
+	AdminUser string
+	// AdminPasswd is the password of the administrator.
+	AdminPasswd string
+	// NewSID regenerates the security identifier of the instances, which is
+	// needed when the instances join the same domain.
+	NewSID bool
+	// ResetPasswd forces the password to be changed on the first login.
+	ResetPasswd bool
+	// Activate activates Windows on the first boot.
+	Activate bool
+	// KMSServer is the key management server activating Windows, as host or host:port,
+	// the server of the platform is used if empty. It implies Activate.
+	KMSServer string
+}
+
+// Validate validates the WindowsOptions.
+func (v *WindowsOptions) Validate() error {
+	if err := ValidateLoginPasswd(v.AdminPasswd); err != nil {
+		return err
+	}
+	if strings.ContainsAny(v.AdminUser, `"/\[]:;|=,+*?<>@`) {
+		return fmt.Errorf(`"AdminUser" of WindowsOptions contains invalid characters, got "%s"`, v.AdminUser)
+	}
+	if v.KMSServer != "" {
+		return ValidateKMSServer(v.KMSServer)
+	}
+	return nil
+}
+
+// ValidateKMSServer checks the key management server is a host or host:port.
+func ValidateKMSServer(server string) error {
+	host, port := server, ""
+	if strings.Contains(server, ":") {
+		var err error
+		if host, port, err = net.SplitHostPort(server); err != nil {
+			return fmt.Errorf(`"KMSServer" should be host or host:port, got "%s"`, server)
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf(`"KMSServer" port should be between 1 and 65535, got "%s"`, port)
+		}
+	}
+	if host == "" || strings.ContainsAny(host, " /\\\t") {
+		return fmt.Errorf(`"KMSServer" should be host or host:port, got "%s"`, server)
+	}
+	return nil
+}
+
+// ApplyTo sets the Windows parameters of the RunInstancesInput.
+func (v *WindowsOptions) ApplyTo(input *RunInstancesInput) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	input.Platform = String(PlatformWindows)
+	input.LoginMode = String(LoginModePasswd)
+	input.LoginPasswd = String(v.AdminPasswd)
+	input.LoginKeyPair = nil
+	if v.AdminUser != "" {
+		input.DefaultUser = String(v.AdminUser)
+		input.DefaultPasswd = String(v.AdminPasswd)
+	}
+	input.NeedNewSID = Int(0)
+	if v.NewSID {
+		input.NeedNewSID = Int(1)
+	}
+	if v.ResetPasswd {
+		input.FResetpwd = Int(1)
+	}
+	input.NeedActivation = Int(0)
+	if v.Activate || v.KMSServer != "" {
+		input.NeedActivation = Int(1)
+	}
+	if v.KMSServer != "" {
+		input.KMSServer = String(v.KMSServer)
+	}
+	return nil
+}
+
+// ValidateLoginPasswd checks the login password has 8 to 14 characters
+// including upper case letters, lower case letters and digits.
+func ValidateLoginPasswd(passwd string) error {
+	if len(passwd) < 8 || len(passwd) > 14 {
+		return fmt.Errorf(`"LoginPasswd" should have 8 to 14 characters, got %d`, len(passwd))
+	}
+	var upper, lower, digit bool
+	for _, r := range passwd {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case r > unicode.MaxASCII || !unicode.IsPrint(r):
+			return fmt.Errorf(`"LoginPasswd" should only contain printable ASCII characters`)
+		}
+	}
+	if !upper || !lower || !digit {
+		return fmt.Errorf(`"LoginPasswd" should include upper case letters, lower case letters and digits`)
+	}
+	return nil
+}
+
+// InstancePlatform returns the platform of the instance, it falls back to
+// the platform of its image for the responses without the platform.
+func (v *Instance) InstancePlatform() string {
+	if platform := StringValue(v.Platform); platform != "" {
+		return platform
+	}
+	if v.Image != nil {
+		if platform := StringValue(v.Image.Platform); platform != "" {
+			return platform
+		}
+		if strings.EqualFold(StringValue(v.Image.OSFamily), PlatformWindows) {
+			return PlatformWindows
+		}
+	}
+	return PlatformLinux
+}
+
+// IsWindows checks whether the instance runs Windows.
+func (v *Instance) IsWindows() bool {
+	return v.InstancePlatform() == PlatformWindows
+}
@@ -0,0 +1,106 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+)
+
+func TestValidateLoginPasswd(t *testing.T) {
+	assert.Nil(t, ValidateLoginPasswd("Passw0rd"))
+	assert.Nil(t, ValidateLoginPasswd("Pa$$w0rd-2017"))
+	assert.NotNil(t, ValidateLoginPasswd("Pa55"))
+	assert.NotNil(t, ValidateLoginPasswd("Passw0rdPassw0rd"))
+	assert.NotNil(t, ValidateLoginPasswd("password1"))
+	assert.NotNil(t, ValidateLoginPasswd("Password"))
+	assert.NotNil(t, ValidateLoginPasswd("Passw0rd密码"))
+}
+
+func TestWindowsOptionsApplyTo(t *testing.T) {
+	input := &RunInstancesInput{
+		ImageID:      String("win2016x64a"),
+		LoginMode:    String(LoginModeKeyPair),
+		LoginKeyPair: String("kp-abcdefgh"),
+	}
+	options := &WindowsOptions{AdminUser: "ops", AdminPasswd: "Passw0rd", NewSID: true}
+	assert.Nil(t, options.ApplyTo(input))
+	assert.Equal(t, PlatformWindows, StringValue(input.Platform))
+	assert.Equal(t, LoginModePasswd, StringValue(input.LoginMode))
+	assert.Equal(t, "Passw0rd", StringValue(input.LoginPasswd))
+	assert.Nil(t, input.LoginKeyPair)
+	assert.Equal(t, "ops", StringValue(input.DefaultUser))
+	assert.Equal(t, 1, IntValue(input.NeedNewSID))
+	assert.Nil(t, input.FResetpwd)
+	assert.Equal(t, 0, IntValue(input.NeedActivation))
+	assert.Nil(t, input.KMSServer)
+	assert.Nil(t, input.Validate())
+
+	options = &WindowsOptions{AdminPasswd: "Passw0rd", KMSServer: "kms.example.com:1688"}
+	assert.Nil(t, options.ApplyTo(input))
+	assert.Equal(t, 1, IntValue(input.NeedActivation))
+	assert.Equal(t, "kms.example.com:1688", StringValue(input.KMSServer))
+	assert.NotNil(t, (&WindowsOptions{AdminPasswd: "Passw0rd", KMSServer: "kms.example.com:0"}).ApplyTo(&RunInstancesInput{}))
+
+	assert.NotNil(t, (&WindowsOptions{AdminPasswd: "weak"}).ApplyTo(&RunInstancesInput{}))
+	assert.NotNil(t, (&WindowsOptions{AdminUser: "a/b", AdminPasswd: "Passw0rd"}).ApplyTo(&RunInstancesInput{}))
+}
+
+func TestValidateKMSServer(t *testing.T) {
+	assert.Nil(t, ValidateKMSServer("kms.example.com"))
+	assert.Nil(t, ValidateKMSServer("10.0.0.2:1688"))
+	assert.NotNil(t, ValidateKMSServer(":1688"))
+	assert.NotNil(t, ValidateKMSServer("kms.example.com:kms"))
+	assert.NotNil(t, ValidateKMSServer("kms example.com"))
+}
+
+func TestRunInstancesWindowsActivation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "RunInstances", r.FormValue("action"))
+		assert.Equal(t, "1", r.FormValue("need_activation"))
+		assert.Equal(t, "kms.example.com", r.FormValue("kms_server"))
+		assert.Equal(t, PlatformWindows, r.FormValue("platform"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ret_code":0,"instances":["i-abcdefgh"],"job_id":"j-abcdefgh"}`))
+	}))
+	defer server.Close()
+
+	conf, err := config.NewWithEndpoint("AccessKeyID", "SecretAccessKey", server.URL+"/iaas")
+	assert.Nil(t, err)
+	qcService, err := Init(conf)
+	assert.Nil(t, err)
+	instanceService, err := qcService.Instance("pek3a")
+	assert.Nil(t, err)
+
+	input := &RunInstancesInput{ImageID: String("win2016x64a")}
+	assert.Nil(t, (&WindowsOptions{AdminPasswd: "Passw0rd", KMSServer: "kms.example.com"}).ApplyTo(input))
+	output, err := instanceService.RunInstances(input)
+	assert.Nil(t, err)
+	assert.Equal(t, "i-abcdefgh", StringValue(output.Instances[0]))
+}
+
+func TestInstancePlatform(t *testing.T) {
+	assert.Equal(t, PlatformWindows, (&Instance{Platform: String(PlatformWindows)}).InstancePlatform())
+	assert.True(t, (&Instance{Image: &Image{Platform: String(PlatformWindows)}}).IsWindows())
+	assert.True(t, (&Instance{Image: &Image{OSFamily: String("Windows")}}).IsWindows())
+	assert.False(t, (&Instance{Image: &Image{OSFamily: String("centos")}}).IsWindows())
+	assert.Equal(t, PlatformLinux, (&Instance{}).InstancePlatform())
+}
@@ -0,0 +1,216 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// MonitorUtilizationScale converts the values of the cpu and memory meters,
+// which are in per mille, to percent.
+const MonitorUtilizationScale = 10.0
+
+// Resize actions of the ResizeRecommendation.
+const (
+	ResizeActionNone = "none"
+	ResizeActionUp   = "up"
+	ResizeActionDown = "down"
+)
+
+// InstanceUsage is the cpu and memory utilization series of an instance in
+// percent, the points without data are skipped.
+type InstanceUsage struct {
+	InstanceID string
+	Step       time.Duration
+	CPU        []float64
+	Memory     []float64
+}
+
+// CPUPercentile returns the cpu utilization that p percent of the points are not above.
+func (u *InstanceUsage) CPUPercentile(p float64) float64 {
+	return percentile(append([]float64{}, u.CPU...), p)
+}
+
+// MemoryPercentile returns the memory utilization that p percent of the points are not above.
+func (u *InstanceUsage) MemoryPercentile(p float64) float64 {
+	return percentile(append([]float64{}, u.Memory...), p)
+}
+
+// GetInstanceUsage gets the cpu and memory utilization of the instance from start to end.
+func (s *MonitorService) GetInstanceUsage(instanceID string, start, end time.Time, step string) (*InstanceUsage, error) {
+	duration, ok := MonitorSteps[step]
+	if !ok {
+		return nil, fmt.Errorf("monitor step %s is not available", step)
+	}
+	output, err := s.GetMonitor(&GetMonitorInput{
+		Resource:  String(instanceID),
+		Meters:    StringSlice([]string{"cpu", "memory"}),
+		StartTime: Time(start),
+		EndTime:   Time(end),
+		Step:      String(step),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseInstanceUsage(instanceID, duration, output)
+}
+
+func parseInstanceUsage(instanceID string, step time.Duration, output *GetMonitorOutput) (*InstanceUsage, error) {
+	usage := &InstanceUsage{InstanceID: instanceID, Step: step, CPU: []float64{}, Memory: []float64{}}
+	for _, meter := range output.MeterSet {
+		var values *[]float64
+		switch StringValue(meter.MeterID) {
+		case "cpu":
+			values = &usage.CPU
+		case "memory":
+			values = &usage.Memory
+		default:
+			continue
+		}
+		points, err := ParseMonitorSeries(meter, step)
+		if err != nil {
+			return nil, err
+		}
+		for _, point := range points {
+			if len(point.Values) == 1 {
+				*values = append(*values, point.Values[0]/MonitorUtilizationScale)
+			}
+		}
+	}
+	return usage, nil
+}
+
+// InstanceSize is the cpu cores and memory (MB) of an instance.
+type InstanceSize struct {
+	CPU    int
+	Memory int
+}
+
+func (s InstanceSize) String() string {
+	return fmt.Sprintf("%d cores %d MB", s.CPU, s.Memory)
+}
+
+// ResizePolicy decides when to resize an instance.
+type ResizePolicy struct {
+	// Sizes are the sizes the instance can be resized to, from the smallest to the largest.
+	Sizes []InstanceSize
+	// Percentile of the utilization compared with the thresholds, e.g. 95.
+	Percentile float64
+	// ScaleUpAbove proposes the next larger size if the cpu or memory utilization is above it.
+	ScaleUpAbove float64
+	// ScaleDownBelow proposes the next smaller size if both the cpu and memory utilization are below it.
+	ScaleDownBelow float64
+	// MinSamples is the minimum points of both series to make a proposal.
+	MinSamples int
+}
+
+// Validate validates the ResizePolicy.
+func (p *ResizePolicy) Validate() error {
+	if len(p.Sizes) == 0 {
+		return fmt.Errorf(`"Sizes" of ResizePolicy should not be empty`)
+	}
+	if p.Percentile <= 0 || p.Percentile > 100 {
+		return fmt.Errorf(`"Percentile" of ResizePolicy should be in (0, 100], got %v`, p.Percentile)
+	}
+	if p.ScaleDownBelow >= p.ScaleUpAbove {
+		return fmt.Errorf(`"ScaleDownBelow" of ResizePolicy should be less than "ScaleUpAbove"`)
+	}
+	return nil
+}
+
+// ResizeRecommendation is the result of EvaluateInstanceResize, Proposed is
+// the same as Current if Action is ResizeActionNone.
+type ResizeRecommendation struct {
+	InstanceID  string
+	Current     InstanceSize
+	Proposed    InstanceSize
+	CPUUsage    float64
+	MemoryUsage float64
+	Action      string
+	Reason      string
+}
+
+// EvaluateInstanceResize compares the utilization of the instance with the
+// policy and proposes a new size, it does not resize the instance.
+func EvaluateInstanceResize(instance *Instance, usage *InstanceUsage, policy *ResizePolicy) (*ResizeRecommendation, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	current := InstanceSize{CPU: IntValue(instance.VCPUsCurrent), Memory: IntValue(instance.MemoryCurrent)}
+	r := &ResizeRecommendation{
+		InstanceID:  StringValue(instance.InstanceID),
+		Current:     current,
+		Proposed:    current,
+		CPUUsage:    usage.CPUPercentile(policy.Percentile),
+		MemoryUsage: usage.MemoryPercentile(policy.Percentile),
+		Action:      ResizeActionNone,
+	}
+
+	index := -1
+	for i, size := range policy.Sizes {
+		if size == current {
+			index = i
+		}
+	}
+	switch {
+	case index == -1:
+		r.Reason = fmt.Sprintf("current size %s is not in the policy", current)
+	case len(usage.CPU) < policy.MinSamples || len(usage.Memory) < policy.MinSamples:
+		r.Reason = fmt.Sprintf("not enough samples, %d cpu and %d memory points", len(usage.CPU), len(usage.Memory))
+	case r.CPUUsage > policy.ScaleUpAbove || r.MemoryUsage > policy.ScaleUpAbove:
+		if index == len(policy.Sizes)-1 {
+			r.Reason = fmt.Sprintf("utilization is above %v%% but %s is the largest size", policy.ScaleUpAbove, current)
+			break
+		}
+		r.Action, r.Proposed = ResizeActionUp, policy.Sizes[index+1]
+		r.Reason = fmt.Sprintf("cpu %.1f%% or memory %.1f%% is above %v%%", r.CPUUsage, r.MemoryUsage, policy.ScaleUpAbove)
+	case r.CPUUsage < policy.ScaleDownBelow && r.MemoryUsage < policy.ScaleDownBelow:
+		if index == 0 {
+			r.Reason = fmt.Sprintf("utilization is below %v%% but %s is the smallest size", policy.ScaleDownBelow, current)
+			break
+		}
+		r.Action, r.Proposed = ResizeActionDown, policy.Sizes[index-1]
+		r.Reason = fmt.Sprintf("cpu %.1f%% and memory %.1f%% are below %v%%", r.CPUUsage, r.MemoryUsage, policy.ScaleDownBelow)
+	default:
+		r.Reason = "utilization is within the thresholds"
+	}
+	return r, nil
+}
+
+// CanResizeOnline checks whether the running instance can be resized to cpu cores and
+// memory (MB) without stopping it, which needs the platform to hot-add vCPU and memory up to
+// the VCPUsMax and MemoryMax the instance is created with. vCPU and memory can not be hot-removed.
+func (i *Instance) CanResizeOnline(cpu int, memory int) bool {
+	if StringValue(i.Status) != "running" || i.VCPUsMax == nil || i.MemoryMax == nil {
+		return false
+	}
+	if cpu < IntValue(i.VCPUsCurrent) || cpu > *i.VCPUsMax {
+		return false
+	}
+	return memory >= IntValue(i.MemoryCurrent) && memory <= *i.MemoryMax
+}
+
+// OnlineResizeInstanceInput returns the input to hot-add vCPU and memory (MB) of the running instance.
+func OnlineResizeInstanceInput(instanceID string, cpu int, memory int) *ResizeInstancesInput {
+	return &ResizeInstancesInput{
+		Instances:     []*string{String(instanceID)},
+		VCPUsCurrent:  String(strconv.Itoa(cpu)),
+		MemoryCurrent: String(strconv.Itoa(memory)),
+	}
+}
@@ -0,0 +1,108 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testResizePolicy = &ResizePolicy{
+	Sizes:          []InstanceSize{{1, 1024}, {1, 2048}, {2, 4096}, {4, 8192}},
+	Percentile:     95,
+	ScaleUpAbove:   80,
+	ScaleDownBelow: 20,
+	MinSamples:     3,
+}
+
+func TestParseInstanceUsage(t *testing.T) {
+	output := &GetMonitorOutput{}
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"meter_set": [
+			{"meter_id": "cpu", "data": [[1490000000, 900], 850, "NA", 950]},
+			{"meter_id": "memory", "data": [[1490000000, 300], 400, 500, 600]}
+		],
+		"ret_code": 0
+	}`), output))
+
+	usage, err := parseInstanceUsage("i-abcdefgh", 5*time.Minute, output)
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{90, 85, 95}, usage.CPU)
+	assert.Equal(t, 4, len(usage.Memory))
+	assert.Equal(t, float64(95), usage.CPUPercentile(95))
+	assert.Equal(t, float64(60), usage.MemoryPercentile(100))
+}
+
+func TestEvaluateInstanceResize(t *testing.T) {
+	instance := &Instance{InstanceID: String("i-abcdefgh"), VCPUsCurrent: Int(1), MemoryCurrent: Int(2048)}
+
+	r, err := EvaluateInstanceResize(instance, &InstanceUsage{CPU: []float64{50, 90, 85}, Memory: []float64{30, 30, 30}}, testResizePolicy)
+	assert.Nil(t, err)
+	assert.Equal(t, ResizeActionUp, r.Action)
+	assert.Equal(t, InstanceSize{2, 4096}, r.Proposed)
+
+	r, err = EvaluateInstanceResize(instance, &InstanceUsage{CPU: []float64{5, 10, 15}, Memory: []float64{10, 10, 10}}, testResizePolicy)
+	assert.Nil(t, err)
+	assert.Equal(t, ResizeActionDown, r.Action)
+	assert.Equal(t, InstanceSize{1, 1024}, r.Proposed)
+
+	r, err = EvaluateInstanceResize(instance, &InstanceUsage{CPU: []float64{50, 50, 50}, Memory: []float64{10, 10, 10}}, testResizePolicy)
+	assert.Nil(t, err)
+	assert.Equal(t, ResizeActionNone, r.Action)
+	assert.Equal(t, r.Current, r.Proposed)
+
+	r, err = EvaluateInstanceResize(instance, &InstanceUsage{CPU: []float64{90}, Memory: []float64{90}}, testResizePolicy)
+	assert.Nil(t, err)
+	assert.Equal(t, ResizeActionNone, r.Action)
+
+	largest := &Instance{VCPUsCurrent: Int(4), MemoryCurrent: Int(8192)}
+	r, err = EvaluateInstanceResize(largest, &InstanceUsage{CPU: []float64{90, 90, 90}, Memory: []float64{90, 90, 90}}, testResizePolicy)
+	assert.Nil(t, err)
+	assert.Equal(t, ResizeActionNone, r.Action)
+
+	_, err = EvaluateInstanceResize(instance, &InstanceUsage{}, &ResizePolicy{Sizes: testResizePolicy.Sizes, Percentile: 95, ScaleUpAbove: 10, ScaleDownBelow: 20})
+	assert.NotNil(t, err)
+}
+
+func TestInstanceCanResizeOnline(t *testing.T) {
+	instance := &Instance{
+		Status:        String("running"),
+		VCPUsCurrent:  Int(2),
+		VCPUsMax:      Int(8),
+		MemoryCurrent: Int(4096),
+		MemoryMax:     Int(16384),
+	}
+	assert.True(t, instance.CanResizeOnline(4, 8192))
+	assert.True(t, instance.CanResizeOnline(2, 4096))
+	assert.False(t, instance.CanResizeOnline(16, 8192))
+	assert.False(t, instance.CanResizeOnline(4, 32768))
+	assert.False(t, instance.CanResizeOnline(1, 4096))
+	assert.False(t, instance.CanResizeOnline(2, 2048))
+
+	instance.Status = String("stopped")
+	assert.False(t, instance.CanResizeOnline(4, 8192))
+	assert.False(t, (&Instance{Status: String("running"), VCPUsCurrent: Int(2), MemoryCurrent: Int(4096)}).CanResizeOnline(4, 8192))
+
+	input := OnlineResizeInstanceInput("i-abcdefgh", 4, 8192)
+	assert.Nil(t, input.Validate())
+	assert.Equal(t, "4", StringValue(input.VCPUsCurrent))
+	assert.Equal(t, "8192", StringValue(input.MemoryCurrent))
+	assert.Nil(t, input.CPU)
+}
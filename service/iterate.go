@@ -0,0 +1,174 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+// SnapshotIterator iterates a large listing of a Describe API page by page
+// ordered by creation time, so that the resources created during the iteration
+// are appended after the snapshot and skipped, and the pages overlap so that
+// the resources deleted during the iteration do not make others missed.
+type SnapshotIterator struct {
+	// PageSize is the limit of each page, default to 100.
+	PageSize int
+	// Overlap is the number of resources re-read from the previous page,
+	// which is the number of deletions tolerated between two pages, default to 10.
+	Overlap int
+	// SnapshotTime is the time of the snapshot, the resources created after it are skipped,
+	// default to the time of Clock when the iteration starts. Set it to a time of the server,
+	// e.g. the Date of a response, if the clock of the client is not in sync.
+	SnapshotTime time.Time
+	// Clock is the clock of the default SnapshotTime, default to utils.DefaultClock,
+	// it is usually the Clock of the config of the service.
+	Clock utils.Clock
+}
+
+// Iterate calls describe page by page with the offset, limit and sort key set into the input,
+// and calls fn with each resource in the snapshot once.
+// The describe function should call the Describe API with the input and return its output.
+func (it *SnapshotIterator) Iterate(input interface{}, describe func() (interface{}, error), fn func(item interface{}) error) error {
+	value := reflect.ValueOf(input)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("snapshot iteration can not be applied to %T", input)
+	}
+	inputValue := value.Elem()
+	offset := inputValue.FieldByName("Offset")
+	limit := inputValue.FieldByName("Limit")
+	if !offset.IsValid() || !limit.IsValid() {
+		return fmt.Errorf("%s does not support pagination", inputValue.Type().Name())
+	}
+	if sortKey := inputValue.FieldByName("SortKey"); sortKey.IsValid() {
+		sortKey.Set(reflect.ValueOf(String("create_time")))
+	}
+	if reverse := inputValue.FieldByName("Reverse"); reverse.IsValid() {
+		switch reverse.Interface().(type) {
+		case *int:
+			reverse.Set(reflect.ValueOf(Int(0)))
+		case *string:
+			reverse.Set(reflect.ValueOf(String("0")))
+		}
+	}
+
+	pageSize, overlap := it.PageSize, it.Overlap
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	if overlap <= 0 {
+		overlap = 10
+	}
+	if overlap >= pageSize {
+		overlap = pageSize / 2
+	}
+	limit.Set(reflect.ValueOf(Int(pageSize)))
+
+	snapshotTime := it.SnapshotTime
+	if snapshotTime.IsZero() {
+		clock := it.Clock
+		if clock == nil {
+			clock = utils.DefaultClock
+		}
+		snapshotTime = clock.Now()
+	}
+	visited := map[string]bool{}
+	for start := 0; ; start += pageSize - overlap {
+		offset.Set(reflect.ValueOf(Int(start)))
+		output, err := describe()
+		if err != nil {
+			return err
+		}
+		items, total, err := pageItems(output)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < items.Len(); i++ {
+			item := items.Index(i)
+			id, createTime := itemIdentity(item)
+			if createTime != nil && createTime.After(snapshotTime) {
+				continue
+			}
+			if id != "" {
+				if visited[id] {
+					continue
+				}
+				visited[id] = true
+			}
+			if err := fn(item.Interface()); err != nil {
+				return err
+			}
+		}
+		if items.Len() < pageSize || (total >= 0 && start+items.Len() >= total) {
+			return nil
+		}
+	}
+}
+
+// Export iterates like Iterate and streams each resource to the encoder, e.g.
+// utils.NewCSVEncoder, without buffering the whole listing, and flushes it at the end.
+func (it *SnapshotIterator) Export(input interface{}, describe func() (interface{}, error), encoder utils.RecordEncoder) error {
+	if err := it.Iterate(input, describe, encoder.Encode); err != nil {
+		return err
+	}
+	return encoder.Flush()
+}
+
+// pageItems returns the resource set and the total count of a Describe output, total is -1 if absent.
+func pageItems(output interface{}) (reflect.Value, int, error) {
+	value := reflect.ValueOf(output)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, 0, fmt.Errorf("describe output %T is invalid", output)
+	}
+	value = value.Elem()
+	total := -1
+	if field := value.FieldByName("TotalCount"); field.IsValid() {
+		if totalCount, ok := field.Interface().(*int); ok && totalCount != nil {
+			total = *totalCount
+		}
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Ptr &&
+			field.Type().Elem().Elem().Kind() == reflect.Struct {
+			return field, total, nil
+		}
+	}
+	return reflect.Value{}, 0, fmt.Errorf("describe output %s has no resource set", value.Type().Name())
+}
+
+// itemIdentity returns the ID, e.g. InstanceID of Instance, and the create time of a resource.
+func itemIdentity(item reflect.Value) (string, *time.Time) {
+	if item.IsNil() {
+		return "", nil
+	}
+	item = item.Elem()
+	id := ""
+	if field := item.FieldByName(item.Type().Name() + "ID"); field.IsValid() {
+		if v, ok := field.Interface().(*string); ok {
+			id = StringValue(v)
+		}
+	}
+	var createTime *time.Time
+	if field := item.FieldByName("CreateTime"); field.IsValid() {
+		createTime, _ = field.Interface().(*time.Time)
+	}
+	return id, createTime
+}
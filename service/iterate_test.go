@@ -0,0 +1,118 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+func TestSnapshotIterator(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	all := []*Instance{}
+	for i := 0; i < 7; i++ {
+		all = append(all, &Instance{
+			InstanceID: String(fmt.Sprintf("i-%d", i)),
+			CreateTime: &created,
+		})
+	}
+
+	input := &DescribeInstancesInput{}
+	calls := 0
+	visited := []string{}
+	it := &SnapshotIterator{PageSize: 3, Overlap: 1}
+	err := it.Iterate(input, func() (interface{}, error) {
+		calls++
+		assert.Equal(t, "create_time", StringValue(input.SortKey))
+		assert.Equal(t, "0", StringValue(input.Reverse))
+		assert.Equal(t, 3, IntValue(input.Limit))
+
+		if calls == 2 {
+			// i-0 is deleted and i-7 is created after the first page
+			future := time.Now().Add(time.Hour)
+			all = append(all[1:], &Instance{InstanceID: String("i-7"), CreateTime: &future})
+		}
+		offset := IntValue(input.Offset)
+		end := offset + IntValue(input.Limit)
+		if end > len(all) {
+			end = len(all)
+		}
+		return &DescribeInstancesOutput{
+			InstanceSet: all[offset:end],
+			TotalCount:  Int(len(all)),
+		}, nil
+	}, func(item interface{}) error {
+		visited = append(visited, StringValue(item.(*Instance).InstanceID))
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"i-0", "i-1", "i-2", "i-3", "i-4", "i-5", "i-6"}, visited)
+
+	err = it.Iterate(&DescribeInstancesInput{}, func() (interface{}, error) {
+		return nil, fmt.Errorf("describe error")
+	}, func(item interface{}) error { return nil })
+	assert.NotNil(t, err)
+
+	err = it.Iterate(&DescribeZonesInput{}, nil, nil)
+	assert.NotNil(t, err)
+}
+
+func TestSnapshotIteratorSnapshotTime(t *testing.T) {
+	now := time.Now()
+	early, late := now.Add(-2*time.Hour), now.Add(-time.Minute)
+	instances := []*Instance{
+		{InstanceID: String("i-0"), CreateTime: &early},
+		{InstanceID: String("i-1"), CreateTime: &late},
+	}
+	iterate := func(it *SnapshotIterator) []string {
+		visited := []string{}
+		err := it.Iterate(&DescribeInstancesInput{}, func() (interface{}, error) {
+			return &DescribeInstancesOutput{InstanceSet: instances, TotalCount: Int(len(instances))}, nil
+		}, func(item interface{}) error {
+			visited = append(visited, StringValue(item.(*Instance).InstanceID))
+			return nil
+		})
+		assert.Nil(t, err)
+		return visited
+	}
+
+	assert.Equal(t, []string{"i-0", "i-1"}, iterate(&SnapshotIterator{}))
+	assert.Equal(t, []string{"i-0"}, iterate(&SnapshotIterator{Clock: utils.NewFakeClock(now.Add(-time.Hour))}))
+	assert.Equal(t, []string{"i-0"}, iterate(&SnapshotIterator{SnapshotTime: now.Add(-time.Hour)}))
+
+	defer utils.SetDefaultClock(utils.NewFakeClock(now.Add(-3 * time.Hour)))()
+	assert.Equal(t, []string{}, iterate(&SnapshotIterator{}))
+}
+
+func TestSnapshotIteratorExport(t *testing.T) {
+	instances := []*Instance{
+		{InstanceID: String("i-0"), InstanceName: String("web")},
+		{InstanceID: String("i-1")},
+	}
+	buffer := &bytes.Buffer{}
+	it := &SnapshotIterator{}
+	err := it.Export(&DescribeInstancesInput{}, func() (interface{}, error) {
+		return &DescribeInstancesOutput{InstanceSet: instances, TotalCount: Int(len(instances))}, nil
+	}, utils.NewCSVEncoder(buffer, []string{"instance_id", "instance_name"}, false))
+	assert.Nil(t, err)
+	assert.Equal(t, "instance_id,instance_name\ni-0,web\ni-1,\n", buffer.String())
+}
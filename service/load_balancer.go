@@ -888,12 +888,17 @@ func (s *LoadBalancerService) DescribeLoadBalancerBackends(i *DescribeLoadBalanc
 }
 
 type DescribeLoadBalancerBackendsInput struct {
+	Console              *string   `json:"console" name:"console" location:"params"`
 	Limit                *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	LoadBalancer         *string   `json:"loadbalancer" name:"loadbalancer" location:"params"`
 	LoadBalancerBackends []*string `json:"loadbalancer_backends" name:"loadbalancer_backends" location:"params"`
 	LoadBalancerListener *string   `json:"loadbalancer_listener" name:"loadbalancer_listener" location:"params"`
 	Offset               *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner                *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID            *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord           *string   `json:"search_word" name:"search_word" location:"params"`
+	Status               []*string `json:"status" name:"status" location:"params"`
+	Tags                 []*string `json:"tags" name:"tags" location:"params"`
 	Verbose              *int      `json:"verbose" name:"verbose" location:"params"`
 }
 
@@ -936,11 +941,16 @@ func (s *LoadBalancerService) DescribeLoadBalancerListeners(i *DescribeLoadBalan
 }
 
 type DescribeLoadBalancerListenersInput struct {
+	Console               *string   `json:"console" name:"console" location:"params"`
 	Limit                 *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	LoadBalancer          *string   `json:"loadbalancer" name:"loadbalancer" location:"params"`
 	LoadBalancerListeners []*string `json:"loadbalancer_listeners" name:"loadbalancer_listeners" location:"params"`
 	Offset                *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner                 *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID             *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord            *string   `json:"search_word" name:"search_word" location:"params"`
+	Status                []*string `json:"status" name:"status" location:"params"`
+	Tags                  []*string `json:"tags" name:"tags" location:"params"`
 	Verbose               *int      `json:"verbose" name:"verbose" location:"params"`
 }
 
@@ -984,10 +994,15 @@ func (s *LoadBalancerService) DescribeLoadBalancerPolicies(i *DescribeLoadBalanc
 }
 
 type DescribeLoadBalancerPoliciesInput struct {
+	Console              *string   `json:"console" name:"console" location:"params"`
 	Limit                *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	LoadBalancerPolicies []*string `json:"loadbalancer_policies" name:"loadbalancer_policies" location:"params"`
 	Offset               *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner                *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID            *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord           *string   `json:"search_word" name:"search_word" location:"params"`
+	Status               []*string `json:"status" name:"status" location:"params"`
+	Tags                 []*string `json:"tags" name:"tags" location:"params"`
 	Verbose              *int      `json:"verbose" name:"verbose" location:"params"`
 }
 
@@ -1031,11 +1046,16 @@ func (s *LoadBalancerService) DescribeLoadBalancerPolicyRules(i *DescribeLoadBal
 }
 
 type DescribeLoadBalancerPolicyRulesInput struct {
+	Console                 *string   `json:"console" name:"console" location:"params"`
 	Limit                   *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	LoadBalancerPolicy      *string   `json:"loadbalancer_policy" name:"loadbalancer_policy" location:"params"`
 	LoadBalancerPolicyRules []*string `json:"loadbalancer_policy_rules" name:"loadbalancer_policy_rules" location:"params"`
 	Offset                  *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner                   *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID               *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord              *string   `json:"search_word" name:"search_word" location:"params"`
+	Status                  []*string `json:"status" name:"status" location:"params"`
+	Tags                    []*string `json:"tags" name:"tags" location:"params"`
 }
 
 func (v *DescribeLoadBalancerPolicyRulesInput) Validate() error {
@@ -1078,11 +1098,16 @@ func (s *LoadBalancerService) DescribeLoadBalancers(i *DescribeLoadBalancersInpu
 }
 
 type DescribeLoadBalancersInput struct {
+	Console       *string   `json:"console" name:"console" location:"params"`
+	Fields        []*string `json:"fields" name:"fields" location:"params"`
 	Limit         *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	LoadBalancers []*string `json:"loadbalancers" name:"loadbalancers" location:"params"`
 	Offset        *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner         *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID     *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse       *int      `json:"reverse" name:"reverse" location:"params"`
 	SearchWord    *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey       *string   `json:"sort_key" name:"sort_key" location:"params"`
 	Status        []*string `json:"status" name:"status" location:"params"`
 	Tags          []*string `json:"tags" name:"tags" location:"params"`
 	Verbose       *int      `json:"verbose" name:"verbose" default:"0" location:"params"`
@@ -1127,11 +1152,17 @@ func (s *LoadBalancerService) DescribeServerCertificates(i *DescribeServerCertif
 }
 
 type DescribeServerCertificatesInput struct {
+	Console            *string   `json:"console" name:"console" location:"params"`
 	Limit              *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset             *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner              *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID          *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse            *int      `json:"reverse" name:"reverse" location:"params"`
 	SearchWord         *string   `json:"search_word" name:"search_word" location:"params"`
 	ServerCertificates []*string `json:"server_certificates" name:"server_certificates" location:"params"`
+	SortKey            *string   `json:"sort_key" name:"sort_key" location:"params"`
+	Status             []*string `json:"status" name:"status" location:"params"`
+	Tags               []*string `json:"tags" name:"tags" location:"params"`
 	Verbose            *int      `json:"verbose" name:"verbose" default:"0" location:"params"`
 }
 
@@ -0,0 +1,267 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+)
+
+// Healthy check protocols of load balancer listeners.
+const (
+	HealthyCheckProtocolTCP  = "tcp"
+	HealthyCheckProtocolHTTP = "http"
+)
+
+// Session sticky modes of load balancer listeners.
+const (
+	SessionStickyModeInsert = "insert"
+	SessionStickyModePrefix = "prefix"
+)
+
+// HealthyCheckMethod is the typed form of listener healthy_check_method,
+// which is encoded as "tcp" or "http|<uri>|<host>".
+type HealthyCheckMethod struct {
+	Protocol string
+	URI      string
+	Host     string
+}
+
+// Validate validates the HealthyCheckMethod.
+func (v *HealthyCheckMethod) Validate() error {
+	switch v.Protocol {
+	case HealthyCheckProtocolTCP:
+	case HealthyCheckProtocolHTTP:
+		if !strings.HasPrefix(v.URI, "/") {
+			return fmt.Errorf(`"URI" of HealthyCheckMethod should start with "/", got "%s"`, v.URI)
+		}
+		if strings.Contains(v.URI, "|") || strings.Contains(v.Host, "|") {
+			return fmt.Errorf(`"URI" and "Host" of HealthyCheckMethod can not contain "|"`)
+		}
+	default:
+		return errors.ParameterValueNotAllowedError{
+			ParameterName:  "Protocol",
+			ParameterValue: v.Protocol,
+			AllowedValues:  []string{HealthyCheckProtocolTCP, HealthyCheckProtocolHTTP},
+		}
+	}
+	return nil
+}
+
+// String encodes the HealthyCheckMethod.
+func (v *HealthyCheckMethod) String() string {
+	if v.Protocol == HealthyCheckProtocolHTTP {
+		return strings.Join([]string{v.Protocol, v.URI, v.Host}, "|")
+	}
+	return v.Protocol
+}
+
+// ParseHealthyCheckMethod decodes the listener healthy_check_method.
+func ParseHealthyCheckMethod(s string) (*HealthyCheckMethod, error) {
+	parts := strings.Split(s, "|")
+	v := &HealthyCheckMethod{Protocol: parts[0]}
+	if v.Protocol == HealthyCheckProtocolHTTP {
+		if len(parts) > 1 {
+			v.URI = parts[1]
+		}
+		if len(parts) > 2 {
+			v.Host = parts[2]
+		}
+	} else if len(parts) > 1 {
+		return nil, fmt.Errorf(`healthy check method "%s" is invalid`, s)
+	}
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// HealthyCheckOption is the typed form of listener healthy_check_option,
+// which is encoded as "<interval>|<timeout>|<unhealthy threshold>|<healthy threshold>".
+type HealthyCheckOption struct {
+	// CheckInterval in seconds, between 2 and 60.
+	CheckInterval int
+	// Timeout in seconds, between 5 and 300.
+	Timeout int
+	// UnhealthyThreshold between 2 and 10.
+	UnhealthyThreshold int
+	// HealthyThreshold between 2 and 10.
+	HealthyThreshold int
+}
+
+// DefaultHealthyCheckOption returns the default healthy check option "10|5|2|5".
+func DefaultHealthyCheckOption() *HealthyCheckOption {
+	return &HealthyCheckOption{
+		CheckInterval:      10,
+		Timeout:            5,
+		UnhealthyThreshold: 2,
+		HealthyThreshold:   5,
+	}
+}
+
+// Validate validates the HealthyCheckOption.
+func (v *HealthyCheckOption) Validate() error {
+	if err := checkRange("CheckInterval", v.CheckInterval, 2, 60); err != nil {
+		return err
+	}
+	if err := checkRange("Timeout", v.Timeout, 5, 300); err != nil {
+		return err
+	}
+	if err := checkRange("UnhealthyThreshold", v.UnhealthyThreshold, 2, 10); err != nil {
+		return err
+	}
+	return checkRange("HealthyThreshold", v.HealthyThreshold, 2, 10)
+}
+
+// String encodes the HealthyCheckOption.
+func (v *HealthyCheckOption) String() string {
+	return fmt.Sprintf("%d|%d|%d|%d", v.CheckInterval, v.Timeout, v.UnhealthyThreshold, v.HealthyThreshold)
+}
+
+// ParseHealthyCheckOption decodes the listener healthy_check_option.
+func ParseHealthyCheckOption(s string) (*HealthyCheckOption, error) {
+	parts := strings.Split(s, "|")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf(`healthy check option "%s" is invalid`, s)
+	}
+	values := make([]int, len(parts))
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf(`healthy check option "%s" is invalid`, s)
+		}
+		values[i] = value
+	}
+	v := &HealthyCheckOption{
+		CheckInterval:      values[0],
+		Timeout:            values[1],
+		UnhealthyThreshold: values[2],
+		HealthyThreshold:   values[3],
+	}
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// SessionSticky is the typed form of listener session_sticky,
+// which is encoded as "insert|<cookie timeout>" or "prefix|<cookie name>".
+// Session sticky is disabled when Mode is empty.
+type SessionSticky struct {
+	Mode string
+	// CookieTimeout in seconds of the inserted cookie, 0 means never expire.
+	CookieTimeout int
+	// CookieName of the backend cookie to be prefixed.
+	CookieName string
+}
+
+// Validate validates the SessionSticky.
+func (v *SessionSticky) Validate() error {
+	switch v.Mode {
+	case "":
+	case SessionStickyModeInsert:
+		if v.CookieTimeout < 0 {
+			return fmt.Errorf(`"CookieTimeout" of SessionSticky can not be negative`)
+		}
+	case SessionStickyModePrefix:
+		if v.CookieName == "" {
+			return errors.ParameterRequiredError{
+				ParameterName: "CookieName",
+				ParentName:    "SessionSticky",
+			}
+		}
+	default:
+		return errors.ParameterValueNotAllowedError{
+			ParameterName:  "Mode",
+			ParameterValue: v.Mode,
+			AllowedValues:  []string{SessionStickyModeInsert, SessionStickyModePrefix},
+		}
+	}
+	return nil
+}
+
+// String encodes the SessionSticky.
+func (v *SessionSticky) String() string {
+	switch v.Mode {
+	case SessionStickyModeInsert:
+		return fmt.Sprintf("%s|%d", v.Mode, v.CookieTimeout)
+	case SessionStickyModePrefix:
+		return fmt.Sprintf("%s|%s", v.Mode, v.CookieName)
+	}
+	return ""
+}
+
+// ParseSessionSticky decodes the listener session_sticky.
+func ParseSessionSticky(s string) (*SessionSticky, error) {
+	if s == "" {
+		return &SessionSticky{}, nil
+	}
+	parts := strings.SplitN(s, "|", 2)
+	v := &SessionSticky{Mode: parts[0]}
+	if len(parts) == 2 {
+		switch v.Mode {
+		case SessionStickyModeInsert:
+			timeout, err := strconv.Atoi(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf(`session sticky "%s" is invalid`, s)
+			}
+			v.CookieTimeout = timeout
+		case SessionStickyModePrefix:
+			v.CookieName = parts[1]
+		}
+	}
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// SetHealthyCheck sets the typed healthy check method and option into the listener.
+func (v *LoadBalancerListener) SetHealthyCheck(method *HealthyCheckMethod, option *HealthyCheckOption) error {
+	if method != nil {
+		if err := method.Validate(); err != nil {
+			return err
+		}
+		v.HealthyCheckMethod = String(method.String())
+	}
+	if option != nil {
+		if err := option.Validate(); err != nil {
+			return err
+		}
+		v.HealthyCheckOption = String(option.String())
+	}
+	return nil
+}
+
+// SetSessionSticky sets the typed session sticky into the listener.
+func (v *LoadBalancerListener) SetSessionSticky(sticky *SessionSticky) error {
+	if err := sticky.Validate(); err != nil {
+		return err
+	}
+	v.SessionSticky = String(sticky.String())
+	return nil
+}
+
+func checkRange(name string, value, min, max int) error {
+	if value < min || value > max {
+		return fmt.Errorf(`"%s" value %d should be between %d and %d`, name, value, min, max)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthyCheckMethod(t *testing.T) {
+	method, err := ParseHealthyCheckMethod("http|/healthz|www.example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, &HealthyCheckMethod{Protocol: "http", URI: "/healthz", Host: "www.example.com"}, method)
+	assert.Equal(t, "http|/healthz|www.example.com", method.String())
+
+	method, err = ParseHealthyCheckMethod("tcp")
+	assert.Nil(t, err)
+	assert.Equal(t, "tcp", method.String())
+
+	_, err = ParseHealthyCheckMethod("udp")
+	assert.NotNil(t, err)
+	_, err = ParseHealthyCheckMethod("http|healthz")
+	assert.NotNil(t, err)
+}
+
+func TestHealthyCheckOption(t *testing.T) {
+	option, err := ParseHealthyCheckOption("10|5|2|5")
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultHealthyCheckOption(), option)
+	assert.Equal(t, "10|5|2|5", option.String())
+
+	_, err = ParseHealthyCheckOption("10|5|2")
+	assert.NotNil(t, err)
+	_, err = ParseHealthyCheckOption("1|5|2|5")
+	assert.NotNil(t, err)
+	_, err = ParseHealthyCheckOption("10|a|2|5")
+	assert.NotNil(t, err)
+}
+
+func TestSessionSticky(t *testing.T) {
+	sticky, err := ParseSessionSticky("insert|3600")
+	assert.Nil(t, err)
+	assert.Equal(t, &SessionSticky{Mode: "insert", CookieTimeout: 3600}, sticky)
+	assert.Equal(t, "insert|3600", sticky.String())
+
+	sticky, err = ParseSessionSticky("prefix|SESSIONID")
+	assert.Nil(t, err)
+	assert.Equal(t, "SESSIONID", sticky.CookieName)
+
+	sticky, err = ParseSessionSticky("")
+	assert.Nil(t, err)
+	assert.Equal(t, "", sticky.String())
+
+	_, err = ParseSessionSticky("prefix|")
+	assert.NotNil(t, err)
+	_, err = ParseSessionSticky("rewrite|SESSIONID")
+	assert.NotNil(t, err)
+}
+
+func TestLoadBalancerListenerSetOptions(t *testing.T) {
+	listener := &LoadBalancerListener{}
+	err := listener.SetHealthyCheck(
+		&HealthyCheckMethod{Protocol: "http", URI: "/", Host: "example.com"},
+		&HealthyCheckOption{CheckInterval: 5, Timeout: 10, UnhealthyThreshold: 3, HealthyThreshold: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, "http|/|example.com", StringValue(listener.HealthyCheckMethod))
+	assert.Equal(t, "5|10|3|3", StringValue(listener.HealthyCheckOption))
+
+	err = listener.SetSessionSticky(&SessionSticky{Mode: "insert"})
+	assert.Nil(t, err)
+	assert.Equal(t, "insert|0", StringValue(listener.SessionSticky))
+
+	err = listener.SetHealthyCheck(nil, &HealthyCheckOption{})
+	assert.NotNil(t, err)
+}
@@ -377,10 +377,14 @@ func (s *MongoService) DescribeMongoNodes(i *DescribeMongoNodesInput) (*Describe
 }
 
 type DescribeMongoNodesInput struct {
-	Limit  *int      `json:"limit" name:"limit" location:"params"`
-	Mongo  *string   `json:"mongo" name:"mongo" location:"params"` // Required
-	Offset *int      `json:"offset" name:"offset" location:"params"`
-	Status []*string `json:"status" name:"status" location:"params"`
+	Limit      *int      `json:"limit" name:"limit" location:"params"`
+	Mongo      *string   `json:"mongo" name:"mongo" location:"params"` // Required
+	Offset     *int      `json:"offset" name:"offset" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
 }
 
 func (v *DescribeMongoNodesInput) Validate() error {
@@ -430,9 +434,14 @@ func (s *MongoService) DescribeMongoParameters(i *DescribeMongoParametersInput)
 }
 
 type DescribeMongoParametersInput struct {
-	Limit  *int    `json:"limit" name:"limit" default:"20" location:"params"`
-	Mongo  *string `json:"mongo" name:"mongo" location:"params"` // Required
-	Offset *int    `json:"offset" name:"offset" default:"0" location:"params"`
+	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
+	Mongo      *string   `json:"mongo" name:"mongo" location:"params"` // Required
+	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
 }
 
 func (v *DescribeMongoParametersInput) Validate() error {
@@ -482,14 +491,19 @@ func (s *MongoService) DescribeMongos(i *DescribeMongosInput) (*DescribeMongosOu
 }
 
 type DescribeMongosInput struct {
-	Limit     *int      `json:"limit" name:"limit" default:"20" location:"params"`
-	MongoName *string   `json:"mongo_name" name:"mongo_name" location:"params"`
-	Mongos    []*string `json:"mongos" name:"mongos" location:"params"`
-	Offset    *int      `json:"offset" name:"offset" default:"0" location:"params"`
-	ProjectID *string   `json:"project_id" name:"project_id" location:"params"`
-	Status    []*string `json:"status" name:"status" location:"params"`
-	Tags      []*string `json:"tags" name:"tags" location:"params"`
-	Verbose   *int      `json:"verbose" name:"verbose" location:"params"`
+	Console    *string   `json:"console" name:"console" location:"params"`
+	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
+	MongoName  *string   `json:"mongo_name" name:"mongo_name" location:"params"`
+	Mongos     []*string `json:"mongos" name:"mongos" location:"params"`
+	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
+	Verbose    *int      `json:"verbose" name:"verbose" location:"params"`
 }
 
 func (v *DescribeMongosInput) Validate() error {
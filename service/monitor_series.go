@@ -0,0 +1,256 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// MonitorSteps are the durations of the available monitor steps.
+var MonitorSteps = map[string]time.Duration{
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"2h":  2 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// MonitorPoint is a point of a monitor series, Values is nil if the point has no data.
+// Integers are the exact values if all of them are integers, like the byte counters,
+// which keep their precision above 2^53 only if the response is decoded with UseJSONNumber.
+type MonitorPoint struct {
+	Time     time.Time
+	Values   []float64
+	Integers []int64
+}
+
+// ParseMonitorSeries decodes the compressed data of the meter into points.
+// The first item of the data is [timestamp, value], each of the following
+// items is the value of the next step, and "NA" means no data.
+func ParseMonitorSeries(meter *Meter, step time.Duration) ([]*MonitorPoint, error) {
+	if len(meter.Data) == 0 {
+		return []*MonitorPoint{}, nil
+	}
+	first, ok := meter.Data[0].([]interface{})
+	if !ok || len(first) != 2 {
+		return nil, fmt.Errorf("monitor data of meter %s does not start with [timestamp, value]", StringValue(meter.MeterID))
+	}
+	timestamp, ok := integerValue(first[0])
+	if !ok {
+		return nil, fmt.Errorf("monitor data of meter %s has invalid timestamp %v", StringValue(meter.MeterID), first[0])
+	}
+
+	start := time.Unix(timestamp, 0).UTC()
+	points := make([]*MonitorPoint, 0, len(meter.Data))
+	for i, item := range meter.Data {
+		if i == 0 {
+			item = first[1]
+		}
+		values, err := monitorValues(item)
+		if err != nil {
+			return nil, fmt.Errorf("monitor data of meter %s item %d: %s", StringValue(meter.MeterID), i, err)
+		}
+		point := &MonitorPoint{Time: start.Add(time.Duration(i) * step), Values: values}
+		if values != nil {
+			point.Integers = monitorIntegers(item)
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+func monitorValues(item interface{}) ([]float64, error) {
+	if number, ok := numberValue(item); ok {
+		return []float64{number}, nil
+	}
+	switch v := item.(type) {
+	case string:
+		if v == "NA" {
+			return nil, nil
+		}
+	case []interface{}:
+		values := make([]float64, 0, len(v))
+		for _, value := range v {
+			number, ok := numberValue(value)
+			if !ok {
+				return nil, nil
+			}
+			values = append(values, number)
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("invalid value %v", item)
+}
+
+// monitorIntegers returns the exact values of the item of monitor data, or nil if any of them is not an integer.
+func monitorIntegers(item interface{}) []int64 {
+	items, ok := item.([]interface{})
+	if !ok {
+		items = []interface{}{item}
+	}
+	integers := make([]int64, 0, len(items))
+	for _, value := range items {
+		integer, ok := integerValue(value)
+		if !ok {
+			return nil
+		}
+		integers = append(integers, integer)
+	}
+	return integers
+}
+
+// integerValue returns the exact value of a decoded json integer, a json.Number is parsed as int64
+// so the values above 2^53 keep their precision, while a float64 is exact only up to 2^53.
+func integerValue(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return 0, false
+		}
+		return int64(v), true
+	case json.Number:
+		integer, err := v.Int64()
+		return integer, err == nil
+	}
+	return 0, false
+}
+
+// numberValue returns the value of a decoded json number, which is a float64,
+// or a json.Number if the numbers are decoded with UseJSONNumber.
+func numberValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		number, err := v.Float64()
+		return number, err == nil
+	}
+	return 0, false
+}
+
+// EIPTrafficPoint is the traffic bandwidth of an EIP in bits per second at a time.
+type EIPTrafficPoint struct {
+	Time   time.Time
+	InBPS  float64
+	OutBPS float64
+}
+
+// EIPTrafficStats is the traffic series of an EIP, the points without data are skipped.
+type EIPTrafficStats struct {
+	EIPID  string
+	Step   time.Duration
+	Points []*EIPTrafficPoint
+}
+
+// Bytes returns the total bytes transferred in and out in the series.
+func (s *EIPTrafficStats) Bytes() (in float64, out float64) {
+	for _, point := range s.Points {
+		in += point.InBPS * s.Step.Seconds() / 8
+		out += point.OutBPS * s.Step.Seconds() / 8
+	}
+	return
+}
+
+// Peak returns the max bandwidth in and out in bits per second.
+func (s *EIPTrafficStats) Peak() (in float64, out float64) {
+	for _, point := range s.Points {
+		if point.InBPS > in {
+			in = point.InBPS
+		}
+		if point.OutBPS > out {
+			out = point.OutBPS
+		}
+	}
+	return
+}
+
+// Percentile returns the bandwidth in and out in bits per second that p
+// percent of the points are not above, like the 95th percentile billing.
+func (s *EIPTrafficStats) Percentile(p float64) (in float64, out float64) {
+	if len(s.Points) == 0 {
+		return 0, 0
+	}
+	ins := make([]float64, len(s.Points))
+	outs := make([]float64, len(s.Points))
+	for i, point := range s.Points {
+		ins[i], outs[i] = point.InBPS, point.OutBPS
+	}
+	return percentile(ins, p), percentile(outs, p)
+}
+
+// percentile returns the value that p percent of the values are not above,
+// the values are sorted in place.
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Float64s(values)
+	index := int(float64(len(values))*p/100+0.5) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(values) {
+		index = len(values) - 1
+	}
+	return values[index]
+}
+
+// GetEIPTrafficStats gets the traffic series of the EIP between start and end with the step.
+func (s *MonitorService) GetEIPTrafficStats(eipID string, start, end time.Time, step string) (*EIPTrafficStats, error) {
+	duration, ok := MonitorSteps[step]
+	if !ok {
+		return nil, fmt.Errorf("monitor step %s is not available", step)
+	}
+	output, err := s.GetMonitor(&GetMonitorInput{
+		Resource:  String(eipID),
+		Meters:    StringSlice([]string{"traffic"}),
+		StartTime: Time(start),
+		EndTime:   Time(end),
+		Step:      String(step),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseEIPTrafficStats(eipID, duration, output)
+}
+
+func parseEIPTrafficStats(eipID string, step time.Duration, output *GetMonitorOutput) (*EIPTrafficStats, error) {
+	stats := &EIPTrafficStats{EIPID: eipID, Step: step, Points: []*EIPTrafficPoint{}}
+	for _, meter := range output.MeterSet {
+		if StringValue(meter.MeterID) != "traffic" {
+			continue
+		}
+		points, err := ParseMonitorSeries(meter, step)
+		if err != nil {
+			return nil, err
+		}
+		for _, point := range points {
+			if len(point.Values) != 2 {
+				continue
+			}
+			stats.Points = append(stats.Points, &EIPTrafficPoint{
+				Time:   point.Time,
+				InBPS:  point.Values[0],
+				OutBPS: point.Values[1],
+			})
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,99 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMonitorSeries(t *testing.T) {
+	meter := &Meter{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"meter_id":"cpu","data":[[1490000000,10],20,"NA",30]}`), meter))
+	points, err := ParseMonitorSeries(meter, 5*time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(points))
+	assert.Equal(t, time.Unix(1490000000, 0).UTC(), points[0].Time)
+	assert.Equal(t, []float64{10}, points[0].Values)
+	assert.Equal(t, time.Unix(1490000000+600, 0).UTC(), points[2].Time)
+	assert.Nil(t, points[2].Values)
+	assert.Equal(t, []float64{30}, points[3].Values)
+
+	points, err = ParseMonitorSeries(&Meter{}, 5*time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(points))
+
+	meter = &Meter{Data: []interface{}{[]interface{}{json.Number("1490000000"), json.Number("10")}, []interface{}{json.Number("8"), "NA"}}}
+	points, err = ParseMonitorSeries(meter, 5*time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Unix(1490000000, 0).UTC(), points[0].Time)
+	assert.Equal(t, []float64{10}, points[0].Values)
+	assert.Equal(t, []int64{10}, points[0].Integers)
+	assert.Nil(t, points[1].Values)
+
+	// the counters above 2^53 keep their precision with json.Number
+	meter = &Meter{Data: []interface{}{[]interface{}{json.Number("1490000000"), []interface{}{json.Number("9007199254740993"), json.Number("1.5")}}, json.Number("9007199254740993")}}
+	points, err = ParseMonitorSeries(meter, 5*time.Minute)
+	assert.Nil(t, err)
+	assert.Nil(t, points[0].Integers)
+	assert.Equal(t, []int64{9007199254740993}, points[1].Integers)
+
+	_, err = ParseMonitorSeries(&Meter{Data: []interface{}{float64(1)}}, 5*time.Minute)
+	assert.NotNil(t, err)
+	_, err = ParseMonitorSeries(&Meter{Data: []interface{}{[]interface{}{float64(1), float64(1)}, true}}, 5*time.Minute)
+	assert.NotNil(t, err)
+}
+
+func TestEIPTrafficStats(t *testing.T) {
+	output := &GetMonitorOutput{}
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"meter_set": [{
+			"meter_id": "traffic",
+			"data": [[1490000000, [8000, 16000]], [800, 1600], "NA", [80000, 160000], [8, 16]]
+		}],
+		"resource_id": "eip-abcdefgh",
+		"ret_code": 0
+	}`), output))
+
+	stats, err := parseEIPTrafficStats("eip-abcdefgh", 5*time.Minute, output)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(stats.Points))
+	assert.Equal(t, time.Unix(1490000000+900, 0).UTC(), stats.Points[2].Time)
+
+	in, out := stats.Bytes()
+	assert.Equal(t, float64(8000+800+80000+8)*300/8, in)
+	assert.Equal(t, float64(16000+1600+160000+16)*300/8, out)
+
+	in, out = stats.Peak()
+	assert.Equal(t, float64(80000), in)
+	assert.Equal(t, float64(160000), out)
+
+	in, out = stats.Percentile(50)
+	assert.Equal(t, float64(800), in)
+	assert.Equal(t, float64(1600), out)
+	in, _ = stats.Percentile(95)
+	assert.Equal(t, float64(80000), in)
+
+	in, out = (&EIPTrafficStats{}).Percentile(95)
+	assert.Equal(t, float64(0), in+out)
+
+	_, err = (&MonitorService{}).GetEIPTrafficStats("eip-abcdefgh", time.Now(), time.Now(), "1h")
+	assert.NotNil(t, err)
+}
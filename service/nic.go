@@ -47,6 +47,58 @@ func (s *QingCloudService) Nic(zone string) (*NicService, error) {
 	return &NicService{Config: s.Config, Properties: properties}, nil
 }
 
+// Documentation URL: https://docs.qingcloud.com/api/nic/assign_nic_ips.html
+func (s *NicService) AssignNicIPs(i *AssignNicIPsInput) (*AssignNicIPsOutput, error) {
+	if i == nil {
+		i = &AssignNicIPsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "AssignNicIps",
+		RequestMethod: "GET",
+	}
+
+	x := &AssignNicIPsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type AssignNicIPsInput struct {
+	Count      *int      `json:"count" name:"count" location:"params"`
+	Nic        *string   `json:"nic" name:"nic" location:"params"` // Required
+	PrivateIPs []*string `json:"private_ips" name:"private_ips" location:"params"`
+}
+
+func (v *AssignNicIPsInput) Validate() error {
+
+	if v.Nic == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "Nic",
+			ParentName:    "AssignNicIPsInput",
+		}
+	}
+
+	return nil
+}
+
+type AssignNicIPsOutput struct {
+	Message    *string   `json:"message" name:"message"`
+	Action     *string   `json:"action" name:"action" location:"elements"`
+	JobID      *string   `json:"job_id" name:"job_id" location:"elements"`
+	PrivateIPs []*string `json:"private_ips" name:"private_ips" location:"elements"`
+	RetCode    *int      `json:"ret_code" name:"ret_code" location:"elements"`
+}
+
 // Documentation URL: https://docs.qingcloud.com/api/nic/attach_nics.html
 func (s *NicService) AttachNics(i *AttachNicsInput) (*AttachNicsOutput, error) {
 	if i == nil {
@@ -232,15 +284,22 @@ func (s *NicService) DescribeNics(i *DescribeNicsInput) (*DescribeNicsOutput, er
 }
 
 type DescribeNicsInput struct {
-	Instances []*string `json:"instances" name:"instances" location:"params"`
-	Limit     *int      `json:"limit" name:"limit" default:"20" location:"params"`
-	NICName   *string   `json:"nic_name" name:"nic_name" location:"params"`
-	Nics      []*string `json:"nics" name:"nics" location:"params"`
-	Offset    *int      `json:"offset" name:"offset" default:"0" location:"params"`
-	Owner     *string   `json:"owner" name:"owner" location:"params"`
-	ProjectID *string   `json:"project_id" name:"project_id" location:"params"`
+	Console    *string   `json:"console" name:"console" location:"params"`
+	Fields     []*string `json:"fields" name:"fields" location:"params"`
+	Instances  []*string `json:"instances" name:"instances" location:"params"`
+	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
+	NICName    *string   `json:"nic_name" name:"nic_name" location:"params"`
+	Nics       []*string `json:"nics" name:"nics" location:"params"`
+	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
 	// Status's available values: available, in-use
 	Status    *string   `json:"status" name:"status" location:"params"`
+	Tags      []*string `json:"tags" name:"tags" location:"params"`
+	Verbose   *int      `json:"verbose" name:"verbose" location:"params"`
 	VxNetType []*int    `json:"vxnet_type" name:"vxnet_type" location:"params"`
 	VxNets    []*string `json:"vxnets" name:"vxnets" location:"params"`
 }
@@ -382,3 +441,60 @@ type ModifyNicAttributesOutput struct {
 	Action  *string `json:"action" name:"action" location:"elements"`
 	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
 }
+
+// Documentation URL: https://docs.qingcloud.com/api/nic/unassign_nic_ips.html
+func (s *NicService) UnassignNicIPs(i *UnassignNicIPsInput) (*UnassignNicIPsOutput, error) {
+	if i == nil {
+		i = &UnassignNicIPsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "UnassignNicIps",
+		RequestMethod: "GET",
+	}
+
+	x := &UnassignNicIPsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type UnassignNicIPsInput struct {
+	Nic        *string   `json:"nic" name:"nic" location:"params"`                 // Required
+	PrivateIPs []*string `json:"private_ips" name:"private_ips" location:"params"` // Required
+}
+
+func (v *UnassignNicIPsInput) Validate() error {
+
+	if v.Nic == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "Nic",
+			ParentName:    "UnassignNicIPsInput",
+		}
+	}
+
+	if len(v.PrivateIPs) == 0 {
+		return errors.ParameterRequiredError{
+			ParameterName: "PrivateIPs",
+			ParentName:    "UnassignNicIPsInput",
+		}
+	}
+
+	return nil
+}
+
+type UnassignNicIPsOutput struct {
+	Message *string `json:"message" name:"message"`
+	Action  *string `json:"action" name:"action" location:"elements"`
+	JobID   *string `json:"job_id" name:"job_id" location:"elements"`
+	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
+}
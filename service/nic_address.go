@@ -0,0 +1,75 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// MAC returns the MAC address of the NIC, which is its ID, in lower case.
+func (v *NIC) MAC() string {
+	return strings.ToLower(StringValue(v.NICID))
+}
+
+// MatchAddress checks whether the address is the MAC address or one of the
+// private IPs of the NIC. The MAC address can be in any form accepted by net.ParseMAC.
+func (v *NIC) MatchAddress(address string) bool {
+	if mac, err := net.ParseMAC(address); err == nil {
+		return mac.String() == v.MAC()
+	}
+	return v.HasIP(address)
+}
+
+// IPs returns the primary private IP of the NIC followed by its secondary IPs.
+func (v *NIC) IPs() []string {
+	ips := []string{}
+	if ip := StringValue(v.PrivateIP); ip != "" {
+		ips = append(ips, ip)
+	}
+	for _, ip := range v.SecondaryIPs {
+		if StringValue(ip) != "" {
+			ips = append(ips, *ip)
+		}
+	}
+	return ips
+}
+
+// HasIP checks whether the ip is the primary or a secondary private IP of the NIC.
+func (v *NIC) HasIP(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, nicIP := range v.IPs() {
+		if parsed.Equal(net.ParseIP(nicIP)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateNicIPs checks the ips to assign to or unassign from a NIC are valid IP addresses.
+func ValidateNicIPs(ips []string) error {
+	for _, ip := range ips {
+		if net.ParseIP(ip) == nil {
+			return fmt.Errorf("\"%s\" is not a valid IP address", ip)
+		}
+	}
+	return nil
+}
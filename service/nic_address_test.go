@@ -0,0 +1,52 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNICMatchAddress(t *testing.T) {
+	nic := &NIC{NICID: String("52:54:9E:01:02:03"), PrivateIP: String("192.168.0.10")}
+	assert.Equal(t, "52:54:9e:01:02:03", nic.MAC())
+	assert.True(t, nic.MatchAddress("52:54:9e:01:02:03"))
+	assert.True(t, nic.MatchAddress("52-54-9E-01-02-03"))
+	assert.True(t, nic.MatchAddress("192.168.0.10"))
+	assert.False(t, nic.MatchAddress("192.168.0.1"))
+	assert.False(t, nic.MatchAddress("52:54:9e:01:02:04"))
+	assert.False(t, nic.MatchAddress("vxnet-abcdefgh"))
+}
+
+func TestNICSecondaryIPs(t *testing.T) {
+	nic := &NIC{
+		NICID:        String("52:54:9e:01:02:03"),
+		PrivateIP:    String("192.168.0.10"),
+		SecondaryIPs: StringSlice([]string{"192.168.0.20", "", "fd00::20"}),
+	}
+	assert.Equal(t, []string{"192.168.0.10", "192.168.0.20", "fd00::20"}, nic.IPs())
+	assert.True(t, nic.HasIP("192.168.0.20"))
+	assert.True(t, nic.HasIP("fd00:0::20"))
+	assert.True(t, nic.MatchAddress("192.168.0.20"))
+	assert.False(t, nic.HasIP("192.168.0.30"))
+	assert.False(t, nic.HasIP(""))
+	assert.Equal(t, []string{}, (&NIC{}).IPs())
+
+	assert.Nil(t, ValidateNicIPs([]string{"192.168.0.20", "fd00::20"}))
+	assert.NotNil(t, ValidateNicIPs([]string{"192.168.0.256"}))
+}
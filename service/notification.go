@@ -47,6 +47,78 @@ func (s *QingCloudService) Notification(zone string) (*NotificationService, erro
 	return &NotificationService{Config: s.Config, Properties: properties}, nil
 }
 
+func (s *NotificationService) DescribeNotificationItems(i *DescribeNotificationItemsInput) (*DescribeNotificationItemsOutput, error) {
+	if i == nil {
+		i = &DescribeNotificationItemsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "DescribeNotificationItems",
+		RequestMethod: "GET",
+	}
+
+	x := &DescribeNotificationItemsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type DescribeNotificationItemsInput struct {
+	Console           *string   `json:"console" name:"console" location:"params"`
+	Limit             *int      `json:"limit" name:"limit" default:"20" location:"params"`
+	NotificationItems []*string `json:"notification_items" name:"notification_items" location:"params"`
+	// NotificationItemType's available values: email, phone, webhook
+	NotificationItemType *string   `json:"notification_item_type" name:"notification_item_type" location:"params"`
+	Offset               *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner                *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID            *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord           *string   `json:"search_word" name:"search_word" location:"params"`
+	Status               []*string `json:"status" name:"status" location:"params"`
+	Tags                 []*string `json:"tags" name:"tags" location:"params"`
+}
+
+func (v *DescribeNotificationItemsInput) Validate() error {
+
+	if v.NotificationItemType != nil {
+		notificationItemTypeValidValues := []string{"email", "phone", "webhook"}
+		notificationItemTypeParameterValue := fmt.Sprint(*v.NotificationItemType)
+
+		notificationItemTypeIsValid := false
+		for _, value := range notificationItemTypeValidValues {
+			if value == notificationItemTypeParameterValue {
+				notificationItemTypeIsValid = true
+			}
+		}
+
+		if !notificationItemTypeIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "NotificationItemType",
+				ParameterValue: notificationItemTypeParameterValue,
+				AllowedValues:  notificationItemTypeValidValues,
+			}
+		}
+	}
+
+	return nil
+}
+
+type DescribeNotificationItemsOutput struct {
+	Message             *string                 `json:"message" name:"message"`
+	Action              *string                 `json:"action" name:"action" location:"elements"`
+	NotificationItemSet []*NotificationListItem `json:"notification_item_set" name:"notification_item_set" location:"elements"`
+	RetCode             *int                    `json:"ret_code" name:"ret_code" location:"elements"`
+	TotalCount          *int                    `json:"total_count" name:"total_count" location:"elements"`
+}
+
 func (s *NotificationService) DescribeNotificationLists(i *DescribeNotificationListsInput) (*DescribeNotificationListsOutput, error) {
 	if i == nil {
 		i = &DescribeNotificationListsInput{}
@@ -73,10 +145,15 @@ func (s *NotificationService) DescribeNotificationLists(i *DescribeNotificationL
 }
 
 type DescribeNotificationListsInput struct {
+	Console           *string   `json:"console" name:"console" location:"params"`
 	Limit             *int      `json:"limit" name:"limit" default:"10" location:"params"`
 	NotificationLists []*string `json:"notification_lists" name:"notification_lists" location:"params"` // Required
 	Offset            *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner             *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID         *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord        *string   `json:"search_word" name:"search_word" location:"params"`
+	Status            []*string `json:"status" name:"status" location:"params"`
+	Tags              []*string `json:"tags" name:"tags" location:"params"`
 }
 
 func (v *DescribeNotificationListsInput) Validate() error {
@@ -99,6 +176,101 @@ type DescribeNotificationListsOutput struct {
 	TotalCount          *int                `json:"total_count" name:"total_count" location:"elements"`
 }
 
+func (s *NotificationService) DescribeNotificationsSendHistory(i *DescribeNotificationsSendHistoryInput) (*DescribeNotificationsSendHistoryOutput, error) {
+	if i == nil {
+		i = &DescribeNotificationsSendHistoryInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "DescribeNotificationsSendHistory",
+		RequestMethod: "GET",
+	}
+
+	x := &DescribeNotificationsSendHistoryOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type DescribeNotificationsSendHistoryInput struct {
+	EndTime *time.Time `json:"end_time" name:"end_time" format:"ISO 8601" location:"params"`
+	Limit   *int       `json:"limit" name:"limit" default:"20" location:"params"`
+	// NotificationItemType's available values: email, phone, webhook
+	NotificationItemType *string    `json:"notification_item_type" name:"notification_item_type" location:"params"`
+	NotificationListID   *string    `json:"notification_list_id" name:"notification_list_id" location:"params"`
+	Offset               *int       `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner                *string    `json:"owner" name:"owner" location:"params"`
+	ProjectID            *string    `json:"project_id" name:"project_id" location:"params"`
+	ResourceID           *string    `json:"resource_id" name:"resource_id" location:"params"`
+	SearchWord           *string    `json:"search_word" name:"search_word" location:"params"`
+	StartTime            *time.Time `json:"start_time" name:"start_time" format:"ISO 8601" location:"params"`
+	// Status's available values: successful, failed
+	Status *string   `json:"status" name:"status" location:"params"`
+	Tags   []*string `json:"tags" name:"tags" location:"params"`
+}
+
+func (v *DescribeNotificationsSendHistoryInput) Validate() error {
+
+	if v.NotificationItemType != nil {
+		notificationItemTypeValidValues := []string{"email", "phone", "webhook"}
+		notificationItemTypeParameterValue := fmt.Sprint(*v.NotificationItemType)
+
+		notificationItemTypeIsValid := false
+		for _, value := range notificationItemTypeValidValues {
+			if value == notificationItemTypeParameterValue {
+				notificationItemTypeIsValid = true
+			}
+		}
+
+		if !notificationItemTypeIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "NotificationItemType",
+				ParameterValue: notificationItemTypeParameterValue,
+				AllowedValues:  notificationItemTypeValidValues,
+			}
+		}
+	}
+
+	if v.Status != nil {
+		statusValidValues := []string{"successful", "failed"}
+		statusParameterValue := fmt.Sprint(*v.Status)
+
+		statusIsValid := false
+		for _, value := range statusValidValues {
+			if value == statusParameterValue {
+				statusIsValid = true
+			}
+		}
+
+		if !statusIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Status",
+				ParameterValue: statusParameterValue,
+				AllowedValues:  statusValidValues,
+			}
+		}
+	}
+
+	return nil
+}
+
+type DescribeNotificationsSendHistoryOutput struct {
+	Message                    *string                    `json:"message" name:"message"`
+	Action                     *string                    `json:"action" name:"action" location:"elements"`
+	NotificationSendHistorySet []*NotificationSendHistory `json:"notification_send_history_set" name:"notification_send_history_set" location:"elements"`
+	RetCode                    *int                       `json:"ret_code" name:"ret_code" location:"elements"`
+	TotalCount                 *int                       `json:"total_count" name:"total_count" location:"elements"`
+}
+
 func (s *NotificationService) SendAlarmNotification(i *SendAlarmNotificationInput) (*SendAlarmNotificationOutput, error) {
 	if i == nil {
 		i = &SendAlarmNotificationInput{}
@@ -172,3 +344,58 @@ type SendAlarmNotificationOutput struct {
 	Action  *string `json:"action" name:"action" location:"elements"`
 	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
 }
+
+func (s *NotificationService) VerifyNotificationItem(i *VerifyNotificationItemInput) (*VerifyNotificationItemOutput, error) {
+	if i == nil {
+		i = &VerifyNotificationItemInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "VerifyNotificationItem",
+		RequestMethod: "GET",
+	}
+
+	x := &VerifyNotificationItemOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type VerifyNotificationItemInput struct {
+	NotificationItemContent *string `json:"notification_item_content" name:"notification_item_content" location:"params"` // Required
+	VerificationCode        *string `json:"verification_code" name:"verification_code" location:"params"`                 // Required
+}
+
+func (v *VerifyNotificationItemInput) Validate() error {
+
+	if v.NotificationItemContent == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "NotificationItemContent",
+			ParentName:    "VerifyNotificationItemInput",
+		}
+	}
+
+	if v.VerificationCode == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "VerificationCode",
+			ParentName:    "VerifyNotificationItemInput",
+		}
+	}
+
+	return nil
+}
+
+type VerifyNotificationItemOutput struct {
+	Message *string `json:"message" name:"message"`
+	Action  *string `json:"action" name:"action" location:"elements"`
+	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
+}
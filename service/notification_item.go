@@ -0,0 +1,66 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Types of the notification items.
+const (
+	NotificationItemTypeEmail   = "email"
+	NotificationItemTypePhone   = "phone"
+	NotificationItemTypeWebhook = "webhook"
+)
+
+// ValidateWebhookURL checks the webhook receiver is an absolute http or https URL.
+func ValidateWebhookURL(webhook string) error {
+	u, err := url.Parse(webhook)
+	if err != nil {
+		return fmt.Errorf(`webhook "%s" is invalid: %s`, webhook, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf(`webhook "%s" should use http or https`, webhook)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf(`webhook "%s" has no host`, webhook)
+	}
+	if u.User != nil {
+		return fmt.Errorf(`webhook "%s" should not contain the credentials`, webhook)
+	}
+	return nil
+}
+
+// IsVerified checks whether the receiver of the notification item is verified.
+func (v *NotificationListItem) IsVerified() bool {
+	return IntValue(v.Verified) == 1
+}
+
+// Check checks the notification item is verified and its webhook, if
+// any, is valid, so the notifications can reach it.
+func (v *NotificationListItem) Check() error {
+	if StringValue(v.NotificationItemType) == NotificationItemTypeWebhook {
+		if err := ValidateWebhookURL(StringValue(v.Content)); err != nil {
+			return err
+		}
+	}
+	if !v.IsVerified() {
+		return fmt.Errorf("notification item %s is not verified", StringValue(v.NotificationItemID))
+	}
+	return nil
+}
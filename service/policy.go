@@ -0,0 +1,34 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"github.com/yunify/qingcloud-sdk-go/config"
+)
+
+// WithRequestPolicy returns a copy of the service whose requests matching the key use the policy,
+// the key is an action, a service or an action class, see config.Config.RequestPolicies.
+// The policies of the service are kept.
+func (s *QingCloudService) WithRequestPolicy(key string, policy *config.RequestPolicy) *QingCloudService {
+	conf := *s.Config
+	conf.RequestPolicies = map[string]*config.RequestPolicy{}
+	for k, p := range s.Config.RequestPolicies {
+		conf.RequestPolicies[k] = p
+	}
+	conf.RequestPolicies[key] = policy
+	return &QingCloudService{Config: &conf, Properties: s.Properties}
+}
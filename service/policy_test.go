@@ -0,0 +1,60 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+	"github.com/yunify/qingcloud-sdk-go/utils"
+)
+
+func TestQingCloudServiceWithRequestPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	conf, err := config.NewWithEndpoint("AccessKeyID", "SecretAccessKey", server.URL+"/iaas")
+	assert.Nil(t, err)
+	server.Close()
+	conf.ConnectionRetries = 2
+	conf.Clock = utils.NewFakeClock(time.Now())
+	qcService, err := Init(conf)
+	assert.Nil(t, err)
+
+	attempts := func(qcService *QingCloudService) int {
+		jobService, err := qcService.Job("pek3a")
+		assert.Nil(t, err)
+		_, err = jobService.DescribeJobs(nil)
+		requestErr, ok := err.(*errors.RequestError)
+		if !assert.True(t, ok) {
+			return 0
+		}
+		return len(requestErr.Attempts)
+	}
+
+	aggressive := qcService.WithRequestPolicy("Job", &config.RequestPolicy{Retries: Int(5)})
+	assert.Equal(t, 6, attempts(aggressive))
+	assert.Equal(t, 3, attempts(qcService))
+	assert.Nil(t, conf.RequestPolicies)
+
+	conservative := aggressive.WithRequestPolicy("DescribeJobs", &config.RequestPolicy{Retries: Int(0)})
+	assert.Equal(t, 1, attempts(conservative))
+	assert.Equal(t, 6, attempts(aggressive))
+}
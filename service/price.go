@@ -0,0 +1,32 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+// Total returns the total price of the resources, summed up from the prices of
+// the resources for the responses without the total price.
+func (v *GetPriceOutput) Total() Decimal {
+	if v.TotalPrice != nil {
+		return *v.TotalPrice
+	}
+	total := Decimal{}
+	for _, price := range v.PriceSet {
+		if price != nil && price.Price != nil {
+			total = total.Add(*price.Price)
+		}
+	}
+	return total
+}
@@ -189,15 +189,20 @@ func (s *ProjectService) DescribeProjectResourceItems(i *DescribeProjectResource
 }
 
 type DescribeProjectResourceItemsInput struct {
+	Console       *string   `json:"console" name:"console" location:"params"`
 	InGlobal      *int      `json:"in_global" name:"in_global" location:"params"`
 	Limit         *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset        *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner         *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID     *string   `json:"project_id" name:"project_id" location:"params"`
 	ProjectIDs    []*string `json:"project_ids" name:"project_ids" location:"params"`
 	Reserve       *int      `json:"reserve" name:"reserve" location:"params"`
 	ResourceTypes []*string `json:"resource_types" name:"resource_types" location:"params"`
 	Resources     []*string `json:"resources" name:"resources" location:"params"`
+	SearchWord    *string   `json:"search_word" name:"search_word" location:"params"`
 	SortKey       *string   `json:"sort_key" name:"sort_key" location:"params"`
+	Status        []*string `json:"status" name:"status" location:"params"`
+	Tags          []*string `json:"tags" name:"tags" location:"params"`
 	Verbose       *int      `json:"verbose" name:"verbose" location:"params"`
 }
 
@@ -240,12 +245,19 @@ func (s *ProjectService) DescribeProjects(i *DescribeProjectsInput) (*DescribePr
 }
 
 type DescribeProjectsInput struct {
+	Console    *string   `json:"console" name:"console" location:"params"`
 	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
 	ProjectIDs []*string `json:"project_ids" name:"project_ids" location:"params"`
+	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
 	Shared     *string   `json:"shared" name:"shared" default:"False" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
 	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
+	Verbose    *int      `json:"verbose" name:"verbose" location:"params"`
 }
 
 func (v *DescribeProjectsInput) Validate() error {
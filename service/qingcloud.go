@@ -66,8 +66,12 @@ func (s *QingCloudService) DescribeZones(i *DescribeZonesInput) (*DescribeZonesO
 }
 
 type DescribeZonesInput struct {
-	Status []*string `json:"status" name:"status" location:"params"`
-	Zones  []*string `json:"zones" name:"zones" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
+	Zones      []*string `json:"zones" name:"zones" location:"params"`
 }
 
 func (v *DescribeZonesInput) Validate() error {
@@ -655,10 +655,15 @@ func (s *RDBService) DescribeRDBParameters(i *DescribeRDBParametersInput) (*Desc
 }
 
 type DescribeRDBParametersInput struct {
-	Limit          *int    `json:"limit" name:"limit" location:"params"`
-	Offset         *int    `json:"offset" name:"offset" location:"params"`
-	ParameterGroup *string `json:"parameter_group" name:"parameter_group" location:"params"`
-	RDB            *string `json:"rdb" name:"rdb" location:"params"` // Required
+	Limit          *int      `json:"limit" name:"limit" location:"params"`
+	Offset         *int      `json:"offset" name:"offset" location:"params"`
+	Owner          *string   `json:"owner" name:"owner" location:"params"`
+	ParameterGroup *string   `json:"parameter_group" name:"parameter_group" location:"params"`
+	ProjectID      *string   `json:"project_id" name:"project_id" location:"params"`
+	RDB            *string   `json:"rdb" name:"rdb" location:"params"` // Required
+	SearchWord     *string   `json:"search_word" name:"search_word" location:"params"`
+	Status         []*string `json:"status" name:"status" location:"params"`
+	Tags           []*string `json:"tags" name:"tags" location:"params"`
 }
 
 func (v *DescribeRDBParametersInput) Validate() error {
@@ -708,13 +713,17 @@ func (s *RDBService) DescribeRDBs(i *DescribeRDBsInput) (*DescribeRDBsOutput, er
 }
 
 type DescribeRDBsInput struct {
+	Console    *string   `json:"console" name:"console" location:"params"`
 	Limit      *int      `json:"limit" name:"limit" location:"params"`
 	Offset     *int      `json:"offset" name:"offset" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
 	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
 	RDBEngine  *string   `json:"rdb_engine" name:"rdb_engine" location:"params"`
 	RDBName    *string   `json:"rdb_name" name:"rdb_name" location:"params"`
 	RDBs       []*string `json:"rdbs" name:"rdbs" location:"params"`
+	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
 	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
 	Status     []*string `json:"status" name:"status" location:"params"`
 	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	Verbose    *int      `json:"verbose" name:"verbose" location:"params"`
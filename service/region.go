@@ -0,0 +1,144 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Zone statuses.
+const (
+	ZoneStatusActive  = "active"
+	ZoneStatusFaulty  = "faulty"
+	ZoneStatusDefunct = "defunct"
+)
+
+// zonePattern matches the zones of a multi-zone region, which are the region
+// ID followed by a letter, like "pek3a" of "pek3".
+var zonePattern = regexp.MustCompile(`^([a-z]+[0-9]+)[a-z]$`)
+
+// A Region is a group of zones in the same location. Requests with the region
+// ID as the zone manage the region level resources, like the VPCs spanning
+// all zones of the region.
+type Region struct {
+	RegionID string
+	// Zones are the IDs of the zones in the region, sorted.
+	Zones []string
+}
+
+// RegionOfZone returns the ID of the region the zone belongs to, a zone
+// which is not part of a multi-zone region is a region by itself.
+func RegionOfZone(zone string) string {
+	if match := zonePattern.FindStringSubmatch(zone); match != nil {
+		return match[1]
+	}
+	return zone
+}
+
+// GroupZonesByRegion groups the zones by their regions. The region ID of a
+// multi-zone region in the zones is taken as the region and not as a zone.
+// Regions are sorted by ID.
+func GroupZonesByRegion(zones []string) []*Region {
+	regions := map[string]*Region{}
+	for _, zone := range zones {
+		regionID := RegionOfZone(zone)
+		region, ok := regions[regionID]
+		if !ok {
+			region = &Region{RegionID: regionID, Zones: []string{}}
+			regions[regionID] = region
+		}
+		if zone != regionID {
+			region.Zones = append(region.Zones, zone)
+		}
+	}
+	result := make([]*Region, 0, len(regions))
+	for _, region := range regions {
+		if len(region.Zones) == 0 {
+			region.Zones = append(region.Zones, region.RegionID)
+		}
+		sort.Strings(region.Zones)
+		result = append(result, region)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].RegionID < result[j].RegionID })
+	return result
+}
+
+// DescribeRegions describes the regions of the active zones accessible to the account.
+func (s *QingCloudService) DescribeRegions() ([]*Region, error) {
+	output, err := s.DescribeZones(&DescribeZonesInput{
+		Status: []*string{String(ZoneStatusActive)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	zones := make([]string, 0, len(output.ZoneSet))
+	for _, zone := range output.ZoneSet {
+		if StringValue(zone.ZoneID) != "" {
+			zones = append(zones, *zone.ZoneID)
+		}
+	}
+	return GroupZonesByRegion(zones), nil
+}
+
+// DescribeRegion describes the region with the ID and its active zones.
+func (s *QingCloudService) DescribeRegion(regionID string) (*Region, error) {
+	regions, err := s.DescribeRegions()
+	if err != nil {
+		return nil, err
+	}
+	for _, region := range regions {
+		if region.RegionID == regionID {
+			return region, nil
+		}
+	}
+	return nil, fmt.Errorf("region [%s] not exist or has no active zone", regionID)
+}
+
+// ZoneErrors are the errors of the zones failed in EachZone, indexed by the zone ID.
+type ZoneErrors map[string]error
+
+// Error returns the description of ZoneErrors.
+func (e ZoneErrors) Error() string {
+	zones := make([]string, 0, len(e))
+	for zone := range e {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	messages := make([]string, len(zones))
+	for i, zone := range zones {
+		messages[i] = fmt.Sprintf("%s: %s", zone, e[zone])
+	}
+	return "zones failed: " + strings.Join(messages, "; ")
+}
+
+// EachZone calls f with each zone of the region, the zones failed do not stop
+// the others and their errors are returned as ZoneErrors.
+func (r *Region) EachZone(f func(zone string) error) error {
+	errs := ZoneErrors{}
+	for _, zone := range r.Zones {
+		if err := f(zone); err != nil {
+			errs[zone] = err
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
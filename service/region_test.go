@@ -0,0 +1,73 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+)
+
+func TestRegionOfZone(t *testing.T) {
+	assert.Equal(t, "pek3", RegionOfZone("pek3a"))
+	assert.Equal(t, "pek3", RegionOfZone("pek3"))
+	assert.Equal(t, "gd1", RegionOfZone("gd1"))
+	assert.Equal(t, "sh1", RegionOfZone("sh1b"))
+
+	regions := GroupZonesByRegion([]string{"pek3b", "pek3", "gd1", "pek3a", "sh1a"})
+	assert.Equal(t, []*Region{
+		{RegionID: "gd1", Zones: []string{"gd1"}},
+		{RegionID: "pek3", Zones: []string{"pek3a", "pek3b"}},
+		{RegionID: "sh1", Zones: []string{"sh1a"}},
+	}, regions)
+}
+
+func TestDescribeRegion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "active", r.URL.Query().Get("status.1"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ret_code": 0, "zone_set": [{"zone_id": "pek3", "status": "active"},
+			{"zone_id": "pek3b", "status": "active"}, {"zone_id": "pek3a", "status": "active"}]}`))
+	}))
+	defer server.Close()
+
+	conf, err := config.NewWithEndpoint("AccessKeyID", "SecretAccessKey", server.URL+"/iaas")
+	assert.Nil(t, err)
+	qcService, err := Init(conf)
+	assert.Nil(t, err)
+
+	region, err := qcService.DescribeRegion("pek3")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"pek3a", "pek3b"}, region.Zones)
+	_, err = qcService.DescribeRegion("sh1")
+	assert.NotNil(t, err)
+
+	visited := []string{}
+	err = region.EachZone(func(zone string) error {
+		visited = append(visited, zone)
+		if zone == "pek3a" {
+			return fmt.Errorf("faulty")
+		}
+		return nil
+	})
+	assert.Equal(t, []string{"pek3a", "pek3b"}, visited)
+	assert.EqualError(t, err, "zones failed: pek3a: faulty")
+}
@@ -414,11 +414,16 @@ func (s *RouterService) DescribeRouterStaticEntries(i *DescribeRouterStaticEntri
 }
 
 type DescribeRouterStaticEntriesInput struct {
-	Limit               *int    `json:"limit" name:"limit" location:"params"`
-	Offset              *int    `json:"offset" name:"offset" location:"params"`
-	Owner               *string `json:"owner" name:"owner" location:"params"`
-	RouterStatic        *string `json:"router_static" name:"router_static" location:"params"`
-	RouterStaticEntries *string `json:"router_static_entries" name:"router_static_entries" location:"params"`
+	Console             *string   `json:"console" name:"console" location:"params"`
+	Limit               *int      `json:"limit" name:"limit" location:"params"`
+	Offset              *int      `json:"offset" name:"offset" location:"params"`
+	Owner               *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID           *string   `json:"project_id" name:"project_id" location:"params"`
+	RouterStatic        *string   `json:"router_static" name:"router_static" location:"params"`
+	RouterStaticEntries *string   `json:"router_static_entries" name:"router_static_entries" location:"params"`
+	SearchWord          *string   `json:"search_word" name:"search_word" location:"params"`
+	Status              []*string `json:"status" name:"status" location:"params"`
+	Tags                []*string `json:"tags" name:"tags" location:"params"`
 }
 
 func (v *DescribeRouterStaticEntriesInput) Validate() error {
@@ -461,13 +466,18 @@ func (s *RouterService) DescribeRouterStatics(i *DescribeRouterStaticsInput) (*D
 }
 
 type DescribeRouterStaticsInput struct {
+	Console       *string   `json:"console" name:"console" location:"params"`
 	Limit         *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset        *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner         *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID     *string   `json:"project_id" name:"project_id" location:"params"`
 	Router        *string   `json:"router" name:"router" location:"params"` // Required
 	RouterStatics []*string `json:"router_statics" name:"router_statics" location:"params"`
+	SearchWord    *string   `json:"search_word" name:"search_word" location:"params"`
 	// StaticType's available values: 1, 2, 3, 4, 5, 6, 7, 8
-	StaticType *int `json:"static_type" name:"static_type" location:"params"`
+	StaticType *int      `json:"static_type" name:"static_type" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	// Verbose's available values: 0, 1
 	Verbose *int    `json:"verbose" name:"verbose" location:"params"`
 	VxNet   *string `json:"vxnet" name:"vxnet" location:"params"`
@@ -560,9 +570,14 @@ func (s *RouterService) DescribeRouterVxNets(i *DescribeRouterVxNetsInput) (*Des
 }
 
 type DescribeRouterVxNetsInput struct {
-	Limit  *int    `json:"limit" name:"limit" default:"20" location:"params"`
-	Offset *int    `json:"offset" name:"offset" default:"0" location:"params"`
-	Router *string `json:"router" name:"router" location:"params"` // Required
+	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
+	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	Router     *string   `json:"router" name:"router" location:"params"` // Required
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	// Verbose's available values: 0, 1
 	Verbose *int    `json:"verbose" name:"verbose" location:"params"`
 	VxNet   *string `json:"vxnet" name:"vxnet" location:"params"`
@@ -635,12 +650,16 @@ func (s *RouterService) DescribeRouters(i *DescribeRoutersInput) (*DescribeRoute
 }
 
 type DescribeRoutersInput struct {
+	Console    *string   `json:"console" name:"console" location:"params"`
+	Fields     []*string `json:"fields" name:"fields" location:"params"`
 	Limit      *int      `json:"limit" name:"limit" location:"params"`
 	Offset     *int      `json:"offset" name:"offset" location:"params"`
 	Owner      *string   `json:"owner" name:"owner" location:"params"`
 	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
 	Routers    []*string `json:"routers" name:"routers" location:"params"`
 	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
 	Status     []*string `json:"status" name:"status" location:"params"`
 	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	// Verbose's available values: 0, 1
@@ -880,7 +899,7 @@ func (s *RouterService) JoinRouter(i *JoinRouterInput) (*JoinRouterOutput, error
 type JoinRouterInput struct {
 	DYNIPEnd   *string `json:"dyn_ip_end" name:"dyn_ip_end" location:"params"`
 	DYNIPStart *string `json:"dyn_ip_start" name:"dyn_ip_start" location:"params"`
-	// Features's available values: 1
+	// Features's available values: 0, 1
 	Features  *int    `json:"features" name:"features" default:"1" location:"params"`
 	IPNetwork *string `json:"ip_network" name:"ip_network" location:"params"` // Required
 	ManagerIP *string `json:"manager_ip" name:"manager_ip" location:"params"`
@@ -891,7 +910,7 @@ type JoinRouterInput struct {
 func (v *JoinRouterInput) Validate() error {
 
 	if v.Features != nil {
-		featuresValidValues := []string{"1"}
+		featuresValidValues := []string{"0", "1"}
 		featuresParameterValue := fmt.Sprint(*v.Features)
 
 		featuresIsValid := false
@@ -0,0 +1,147 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"time"
+)
+
+// Meters of routers.
+const (
+	RouterMeterTraffic  = "traffic"
+	RouterMeterSessions = "sessions"
+)
+
+// RouterTrafficPoint is the throughput of a router in bits per second at a time.
+type RouterTrafficPoint struct {
+	Time   time.Time
+	InBPS  float64
+	OutBPS float64
+}
+
+// RouterSessionPoint is the number of the sessions tracked by a router at a time.
+type RouterSessionPoint struct {
+	Time     time.Time
+	Sessions float64
+}
+
+// RouterStats is the throughput and session series of a router, the points without data are skipped.
+type RouterStats struct {
+	RouterID string
+	Step     time.Duration
+	Traffic  []*RouterTrafficPoint
+	Sessions []*RouterSessionPoint
+}
+
+// PeakTraffic returns the max throughput in and out in bits per second.
+func (s *RouterStats) PeakTraffic() (in float64, out float64) {
+	for _, point := range s.Traffic {
+		if point.InBPS > in {
+			in = point.InBPS
+		}
+		if point.OutBPS > out {
+			out = point.OutBPS
+		}
+	}
+	return
+}
+
+// PeakSessions returns the max number of sessions.
+func (s *RouterStats) PeakSessions() (sessions float64) {
+	for _, point := range s.Sessions {
+		if point.Sessions > sessions {
+			sessions = point.Sessions
+		}
+	}
+	return
+}
+
+// BandwidthUsage returns the ratios of the p-th percentile throughput in and out
+// to the bandwidth in bits per second, like 0.9 for 90% used.
+func (s *RouterStats) BandwidthUsage(bandwidthBPS float64, p float64) (in float64, out float64) {
+	if len(s.Traffic) == 0 || bandwidthBPS <= 0 {
+		return 0, 0
+	}
+	ins := make([]float64, len(s.Traffic))
+	outs := make([]float64, len(s.Traffic))
+	for i, point := range s.Traffic {
+		ins[i], outs[i] = point.InBPS, point.OutBPS
+	}
+	return percentile(ins, p) / bandwidthBPS, percentile(outs, p) / bandwidthBPS
+}
+
+// Saturated checks whether the p-th percentile throughput in or out reaches the
+// ratio of the bandwidth in bits per second, e.g. Saturated(bandwidth, 95, 0.9).
+func (s *RouterStats) Saturated(bandwidthBPS float64, p float64, ratio float64) bool {
+	in, out := s.BandwidthUsage(bandwidthBPS, p)
+	return bandwidthBPS > 0 && (in >= ratio || out >= ratio)
+}
+
+// GetRouterStats gets the throughput and session series of the router between start and end with the step.
+func (s *MonitorService) GetRouterStats(routerID string, start, end time.Time, step string) (*RouterStats, error) {
+	duration, ok := MonitorSteps[step]
+	if !ok {
+		return nil, fmt.Errorf("monitor step %s is not available", step)
+	}
+	output, err := s.GetMonitor(&GetMonitorInput{
+		Resource:  String(routerID),
+		Meters:    StringSlice([]string{RouterMeterTraffic, RouterMeterSessions}),
+		StartTime: Time(start),
+		EndTime:   Time(end),
+		Step:      String(step),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseRouterStats(routerID, duration, output)
+}
+
+func parseRouterStats(routerID string, step time.Duration, output *GetMonitorOutput) (*RouterStats, error) {
+	stats := &RouterStats{
+		RouterID: routerID,
+		Step:     step,
+		Traffic:  []*RouterTrafficPoint{},
+		Sessions: []*RouterSessionPoint{},
+	}
+	for _, meter := range output.MeterSet {
+		meterID := StringValue(meter.MeterID)
+		if meterID != RouterMeterTraffic && meterID != RouterMeterSessions {
+			continue
+		}
+		points, err := ParseMonitorSeries(meter, step)
+		if err != nil {
+			return nil, err
+		}
+		for _, point := range points {
+			switch {
+			case meterID == RouterMeterTraffic && len(point.Values) == 2:
+				stats.Traffic = append(stats.Traffic, &RouterTrafficPoint{
+					Time:   point.Time,
+					InBPS:  point.Values[0],
+					OutBPS: point.Values[1],
+				})
+			case meterID == RouterMeterSessions && len(point.Values) == 1:
+				stats.Sessions = append(stats.Sessions, &RouterSessionPoint{
+					Time:     point.Time,
+					Sessions: point.Values[0],
+				})
+			}
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,61 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouterStats(t *testing.T) {
+	output := &GetMonitorOutput{}
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"meter_set": [{
+			"meter_id": "traffic",
+			"data": [[1490000000, [9000000, 1000000]], [2000000, 500000], "NA", [9500000, 800000]]
+		}, {
+			"meter_id": "sessions",
+			"data": [[1490000000, 1200], 3400, "NA", 2100]
+		}],
+		"resource_id": "rtr-abcdefgh",
+		"ret_code": 0
+	}`), output))
+
+	stats, err := parseRouterStats("rtr-abcdefgh", 5*time.Minute, output)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(stats.Traffic))
+	assert.Equal(t, 3, len(stats.Sessions))
+	assert.Equal(t, time.Unix(1490000000+900, 0).UTC(), stats.Sessions[2].Time)
+
+	in, out := stats.PeakTraffic()
+	assert.Equal(t, float64(9500000), in)
+	assert.Equal(t, float64(1000000), out)
+	assert.Equal(t, float64(3400), stats.PeakSessions())
+
+	in, out = stats.BandwidthUsage(10000000, 50)
+	assert.Equal(t, 0.9, in)
+	assert.Equal(t, 0.08, out)
+	assert.True(t, stats.Saturated(10000000, 50, 0.9))
+	assert.False(t, stats.Saturated(20000000, 95, 0.5))
+	assert.False(t, stats.Saturated(0, 95, 0.5))
+
+	_, err = (&MonitorService{}).GetRouterStats("rtr-abcdefgh", time.Now(), time.Now(), "1h")
+	assert.NotNil(t, err)
+}
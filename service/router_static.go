@@ -0,0 +1,277 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+)
+
+// Router static types.
+const (
+	RouterStaticTypePortForwarding = 1
+	RouterStaticTypeVPN            = 2
+	RouterStaticTypeDHCP           = 3
+	RouterStaticTypeL2GRE          = 4
+	RouterStaticTypeFilter         = 5
+	RouterStaticTypeGRE            = 6
+	RouterStaticTypeIPsec          = 7
+	RouterStaticTypeDNS            = 8
+)
+
+const greTunnelProtocol = "gre"
+
+// GRETunnelStatic is the typed form of a GRE tunnel router static.
+//
+// It is encoded as:
+//
+//	val1: "gre|<remote ip>|<key>"
+//	val2: local point-to-point IP
+//	val3: peer point-to-point IP
+//	val4: target networks separated by "|"
+type GRETunnelStatic struct {
+	RouterStaticID   string
+	RouterStaticName string
+
+	RemoteIP       string
+	Key            string
+	LocalP2PIP     string
+	PeerP2PIP      string
+	TargetNetworks []string
+}
+
+// Validate validates the GRETunnelStatic.
+func (v *GRETunnelStatic) Validate() error {
+	if err := checkIP(v.RemoteIP, "RemoteIP", "GRETunnelStatic"); err != nil {
+		return err
+	}
+	if strings.Contains(v.Key, "|") {
+		return fmt.Errorf(`"Key" of GRETunnelStatic can not contain "|"`)
+	}
+	if err := checkIP(v.LocalP2PIP, "LocalP2PIP", "GRETunnelStatic"); err != nil {
+		return err
+	}
+	if err := checkIP(v.PeerP2PIP, "PeerP2PIP", "GRETunnelStatic"); err != nil {
+		return err
+	}
+	for _, network := range v.TargetNetworks {
+		if _, _, err := net.ParseCIDR(network); err != nil {
+			return fmt.Errorf(`"TargetNetworks" of GRETunnelStatic has invalid network "%s"`, network)
+		}
+	}
+	return nil
+}
+
+// checkIP returns ParameterRequiredError if the ip is empty and
+// ParameterValueNotAllowedError if it is not an IP address.
+func checkIP(ip string, name string, parent string) error {
+	if ip == "" {
+		return errors.ParameterRequiredError{
+			ParameterName: name,
+			ParentName:    parent,
+		}
+	}
+	if net.ParseIP(ip) == nil {
+		return errors.ParameterValueNotAllowedError{
+			ParameterName:  name,
+			ParameterValue: ip,
+			AllowedValues:  []string{"<IP address>"},
+		}
+	}
+	return nil
+}
+
+// RouterStatic encodes the GRETunnelStatic into a RouterStatic.
+func (v *GRETunnelStatic) RouterStatic() (*RouterStatic, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	s := &RouterStatic{
+		StaticType: Int(RouterStaticTypeGRE),
+		Val1:       String(strings.Join([]string{greTunnelProtocol, v.RemoteIP, v.Key}, "|")),
+		Val2:       String(v.LocalP2PIP),
+		Val3:       String(v.PeerP2PIP),
+		Val4:       String(strings.Join(v.TargetNetworks, "|")),
+	}
+	setRouterStaticNames(s, v.RouterStaticID, v.RouterStaticName)
+	return s, nil
+}
+
+// ModifyInput returns the input to modify the GRE tunnel router static in place.
+func (v *GRETunnelStatic) ModifyInput() (*ModifyRouterStaticAttributesInput, error) {
+	s, err := v.RouterStatic()
+	if err != nil {
+		return nil, err
+	}
+	return modifyRouterStaticInput(s)
+}
+
+// ParseGRETunnelStatic decodes a GRE tunnel RouterStatic into a GRETunnelStatic.
+func ParseGRETunnelStatic(s *RouterStatic) (*GRETunnelStatic, error) {
+	if err := checkRouterStaticType(s, "GRETunnelStatic", RouterStaticTypeGRE); err != nil {
+		return nil, err
+	}
+	parts := strings.Split(StringValue(s.Val1), "|")
+	if len(parts) != 3 || parts[0] != greTunnelProtocol {
+		return nil, errors.ParameterValueNotAllowedError{
+			ParameterName:  "Val1",
+			ParameterValue: StringValue(s.Val1),
+			AllowedValues:  []string{greTunnelProtocol + "|<remote ip>|<key>"},
+		}
+	}
+	v := &GRETunnelStatic{
+		RouterStaticID:   StringValue(s.RouterStaticID),
+		RouterStaticName: StringValue(s.RouterStaticName),
+		RemoteIP:         parts[1],
+		Key:              parts[2],
+		LocalP2PIP:       StringValue(s.Val2),
+		PeerP2PIP:        StringValue(s.Val3),
+		TargetNetworks:   splitNonEmpty(StringValue(s.Val4), "|"),
+	}
+	return v, nil
+}
+
+// DNSStatic is the typed form of a DNS router static.
+//
+// It is encoded as:
+//
+//	val1: domain name
+//	val2: IP addresses separated by ","
+type DNSStatic struct {
+	RouterStaticID   string
+	RouterStaticName string
+
+	Domain string
+	IPs    []string
+}
+
+// Validate validates the DNSStatic.
+func (v *DNSStatic) Validate() error {
+	if v.Domain == "" {
+		return errors.ParameterRequiredError{
+			ParameterName: "Domain",
+			ParentName:    "DNSStatic",
+		}
+	}
+	if len(v.IPs) == 0 {
+		return errors.ParameterRequiredError{
+			ParameterName: "IPs",
+			ParentName:    "DNSStatic",
+		}
+	}
+	for _, ip := range v.IPs {
+		if net.ParseIP(ip) == nil {
+			return fmt.Errorf(`"IPs" of DNSStatic has invalid IP "%s"`, ip)
+		}
+	}
+	return nil
+}
+
+// RouterStatic encodes the DNSStatic into a RouterStatic.
+func (v *DNSStatic) RouterStatic() (*RouterStatic, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	s := &RouterStatic{
+		StaticType: Int(RouterStaticTypeDNS),
+		Val1:       String(v.Domain),
+		Val2:       String(strings.Join(v.IPs, ",")),
+	}
+	setRouterStaticNames(s, v.RouterStaticID, v.RouterStaticName)
+	return s, nil
+}
+
+// ModifyInput returns the input to modify the DNS router static in place.
+func (v *DNSStatic) ModifyInput() (*ModifyRouterStaticAttributesInput, error) {
+	s, err := v.RouterStatic()
+	if err != nil {
+		return nil, err
+	}
+	return modifyRouterStaticInput(s)
+}
+
+// ParseDNSStatic decodes a DNS RouterStatic into a DNSStatic.
+func ParseDNSStatic(s *RouterStatic) (*DNSStatic, error) {
+	if err := checkRouterStaticType(s, "DNSStatic", RouterStaticTypeDNS); err != nil {
+		return nil, err
+	}
+	v := &DNSStatic{
+		RouterStaticID:   StringValue(s.RouterStaticID),
+		RouterStaticName: StringValue(s.RouterStaticName),
+		Domain:           StringValue(s.Val1),
+		IPs:              splitNonEmpty(StringValue(s.Val2), ","),
+	}
+	return v, nil
+}
+
+func checkRouterStaticType(s *RouterStatic, parentName string, staticType int) error {
+	if s == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "RouterStatic",
+			ParentName:    parentName,
+		}
+	}
+	if IntValue(s.StaticType) != staticType {
+		return errors.ParameterValueNotAllowedError{
+			ParameterName:  "StaticType",
+			ParameterValue: strconv.Itoa(IntValue(s.StaticType)),
+			AllowedValues:  []string{strconv.Itoa(staticType)},
+		}
+	}
+	return nil
+}
+
+func setRouterStaticNames(s *RouterStatic, id, name string) {
+	if id != "" {
+		s.RouterStaticID = String(id)
+	}
+	if name != "" {
+		s.RouterStaticName = String(name)
+	}
+}
+
+func modifyRouterStaticInput(s *RouterStatic) (*ModifyRouterStaticAttributesInput, error) {
+	if s.RouterStaticID == nil {
+		return nil, errors.ParameterRequiredError{
+			ParameterName: "RouterStaticID",
+			ParentName:    "RouterStatic",
+		}
+	}
+	return &ModifyRouterStaticAttributesInput{
+		RouterStatic:     s.RouterStaticID,
+		RouterStaticName: s.RouterStaticName,
+		Val1:             s.Val1,
+		Val2:             s.Val2,
+		Val3:             s.Val3,
+		Val4:             s.Val4,
+		Val5:             s.Val5,
+	}, nil
+}
+
+func splitNonEmpty(s, sep string) []string {
+	values := []string{}
+	for _, v := range strings.Split(s, sep) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,99 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+)
+
+func TestGRETunnelStatic(t *testing.T) {
+	gre := &GRETunnelStatic{
+		RouterStaticID: "rtrs-1",
+		RemoteIP:       "1.2.3.4",
+		Key:            "secret",
+		LocalP2PIP:     "10.254.0.1",
+		PeerP2PIP:      "10.254.0.2",
+		TargetNetworks: []string{"192.168.10.0/24", "192.168.20.0/24"},
+	}
+	s, err := gre.RouterStatic()
+	assert.Nil(t, err)
+	assert.Equal(t, RouterStaticTypeGRE, IntValue(s.StaticType))
+	assert.Equal(t, "gre|1.2.3.4|secret", StringValue(s.Val1))
+	assert.Equal(t, "192.168.10.0/24|192.168.20.0/24", StringValue(s.Val4))
+
+	parsed, err := ParseGRETunnelStatic(s)
+	assert.Nil(t, err)
+	assert.Equal(t, gre, parsed)
+
+	input, err := gre.ModifyInput()
+	assert.Nil(t, err)
+	assert.Equal(t, "rtrs-1", StringValue(input.RouterStatic))
+
+	_, err = ParseGRETunnelStatic(&RouterStatic{StaticType: Int(RouterStaticTypeGRE), Val1: String("1.2.3.4")})
+	assert.IsType(t, errors.ParameterValueNotAllowedError{}, err)
+	_, err = ParseGRETunnelStatic(&RouterStatic{StaticType: Int(RouterStaticTypeDNS)})
+	assert.EqualError(t, err, `"StaticType" value "8" is not allowed, should be one of "6"`)
+	_, err = ParseGRETunnelStatic(nil)
+	assert.IsType(t, errors.ParameterRequiredError{}, err)
+
+	gre.TargetNetworks = []string{"192.168.10.0"}
+	_, err = gre.RouterStatic()
+	assert.NotNil(t, err)
+
+	for _, invalid := range []GRETunnelStatic{
+		{RemoteIP: "1.2.3", LocalP2PIP: "10.254.0.1", PeerP2PIP: "10.254.0.2"},
+		{RemoteIP: "1.2.3.4", LocalP2PIP: "10.254.0.300", PeerP2PIP: "10.254.0.2"},
+		{RemoteIP: "1.2.3.4", LocalP2PIP: "10.254.0.1", PeerP2PIP: "peer"},
+	} {
+		assert.IsType(t, errors.ParameterValueNotAllowedError{}, invalid.Validate(), invalid)
+	}
+	for _, missing := range []GRETunnelStatic{
+		{LocalP2PIP: "10.254.0.1", PeerP2PIP: "10.254.0.2"},
+		{RemoteIP: "1.2.3.4", PeerP2PIP: "10.254.0.2"},
+		{RemoteIP: "1.2.3.4", LocalP2PIP: "10.254.0.1"},
+	} {
+		assert.IsType(t, errors.ParameterRequiredError{}, missing.Validate(), missing)
+	}
+	assert.EqualError(t, (&GRETunnelStatic{RemoteIP: "1.2.3"}).Validate(), `"RemoteIP" value "1.2.3" is not allowed, should be one of "<IP address>"`)
+}
+
+func TestDNSStatic(t *testing.T) {
+	dns := &DNSStatic{
+		RouterStaticName: "db",
+		Domain:           "db.internal",
+		IPs:              []string{"192.168.0.2", "192.168.0.3"},
+	}
+	s, err := dns.RouterStatic()
+	assert.Nil(t, err)
+	assert.Equal(t, RouterStaticTypeDNS, IntValue(s.StaticType))
+	assert.Equal(t, "192.168.0.2,192.168.0.3", StringValue(s.Val2))
+
+	parsed, err := ParseDNSStatic(s)
+	assert.Nil(t, err)
+	assert.Equal(t, dns, parsed)
+
+	_, err = dns.ModifyInput()
+	assert.NotNil(t, err)
+
+	_, err = ParseDNSStatic(&RouterStatic{StaticType: Int(RouterStaticTypeGRE)})
+	assert.IsType(t, errors.ParameterValueNotAllowedError{}, err)
+	_, err = ParseDNSStatic(nil)
+	assert.IsType(t, errors.ParameterRequiredError{}, err)
+}
@@ -0,0 +1,302 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+)
+
+var vpnUsernamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// VPNUser is a user account of an OpenVPN router static.
+//
+// It is encoded as a router static entry:
+//
+//	val1: user name
+//	val2: password
+type VPNUser struct {
+	RouterStaticEntryID string
+
+	Username string
+	Password string
+}
+
+// Validate validates the VPNUser.
+func (v *VPNUser) Validate() error {
+	if !vpnUsernamePattern.MatchString(v.Username) {
+		return fmt.Errorf(`"Username" of VPNUser should be 1 to 64 letters, digits, "_", "." or "-", got "%s"`, v.Username)
+	}
+	if len(v.Password) < 8 || strings.ContainsAny(v.Password, " \t\r\n") {
+		return fmt.Errorf(`"Password" of VPNUser should have at least 8 characters without spaces`)
+	}
+	return nil
+}
+
+// RouterStaticEntry encodes the VPNUser into a RouterStaticEntry.
+func (v *VPNUser) RouterStaticEntry() (*RouterStaticEntry, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	e := &RouterStaticEntry{
+		Val1: String(v.Username),
+		Val2: String(v.Password),
+	}
+	if v.RouterStaticEntryID != "" {
+		e.RouterStaticEntryID = String(v.RouterStaticEntryID)
+	}
+	return e, nil
+}
+
+// ParseVPNUser decodes a RouterStaticEntry of an OpenVPN router static into a VPNUser.
+func ParseVPNUser(e *RouterStaticEntry) (*VPNUser, error) {
+	if e == nil {
+		return nil, errors.ParameterRequiredError{
+			ParameterName: "RouterStaticEntry",
+			ParentName:    "VPNUser",
+		}
+	}
+	if StringValue(e.Val1) == "" {
+		return nil, errors.ParameterRequiredError{
+			ParameterName: "Val1",
+			ParentName:    "VPNUser",
+		}
+	}
+	return &VPNUser{
+		RouterStaticEntryID: StringValue(e.RouterStaticEntryID),
+		Username:            StringValue(e.Val1),
+		Password:            StringValue(e.Val2),
+	}, nil
+}
+
+// CreateVPNUsers adds the users to the OpenVPN router static, it returns the
+// IDs of the router static entries. The router should be updated to apply them.
+func (s *RouterService) CreateVPNUsers(routerStaticID string, users []*VPNUser) ([]string, error) {
+	entries := make([]*RouterStaticEntry, 0, len(users))
+	for _, user := range users {
+		e, err := user.RouterStaticEntry()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	output, err := s.AddRouterStaticEntries(&AddRouterStaticEntriesInput{
+		RouterStatic: String(routerStaticID),
+		Entries:      entries,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return StringValueSlice(output.RouterStaticEntries), nil
+}
+
+// DescribeVPNUsers lists the users of the OpenVPN router static.
+func (s *RouterService) DescribeVPNUsers(routerStaticID string) ([]*VPNUser, error) {
+	users := []*VPNUser{}
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := s.DescribeRouterStaticEntries(&DescribeRouterStaticEntriesInput{
+			RouterStatic: String(routerStaticID),
+			Limit:        Int(limit),
+			Offset:       Int(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range output.RouterStaticEntrySet {
+			user, err := ParseVPNUser(e)
+			if err != nil {
+				return nil, err
+			}
+			users = append(users, user)
+		}
+		if len(output.RouterStaticEntrySet) < limit {
+			return users, nil
+		}
+	}
+}
+
+// RemoveVPNUsers deletes the users with the user names from the OpenVPN router static.
+func (s *RouterService) RemoveVPNUsers(routerStaticID string, usernames []string) error {
+	entryIDs, err := s.vpnUserEntryIDs(routerStaticID, usernames)
+	if err != nil {
+		return err
+	}
+	_, err = s.DeleteRouterStaticEntries(&DeleteRouterStaticEntriesInput{
+		RouterStaticEntries: StringSlice(entryIDs),
+	})
+	return err
+}
+
+// ResetVPNUserPassword changes the password of the user of the OpenVPN router static.
+func (s *RouterService) ResetVPNUserPassword(routerStaticID string, username string, password string) error {
+	user := &VPNUser{Username: username, Password: password}
+	if err := user.Validate(); err != nil {
+		return err
+	}
+	entryIDs, err := s.vpnUserEntryIDs(routerStaticID, []string{username})
+	if err != nil {
+		return err
+	}
+	_, err = s.ModifyRouterStaticEntryAttributes(&ModifyRouterStaticEntryAttributesInput{
+		RouterStaticEntry: String(entryIDs[0]),
+		Val1:              String(username),
+		Val2:              String(password),
+	})
+	return err
+}
+
+func (s *RouterService) vpnUserEntryIDs(routerStaticID string, usernames []string) ([]string, error) {
+	users, err := s.DescribeVPNUsers(routerStaticID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]string, len(users))
+	for _, user := range users {
+		byName[user.Username] = user.RouterStaticEntryID
+	}
+	entryIDs := make([]string, 0, len(usernames))
+	for _, username := range usernames {
+		entryID, ok := byName[username]
+		if !ok {
+			return nil, fmt.Errorf("VPN user %s not found in router static %s", username, routerStaticID)
+		}
+		entryIDs = append(entryIDs, entryID)
+	}
+	return entryIDs, nil
+}
+
+// hasVPNUser checks whether the user is in a VPN router static of the router.
+func (s *RouterService) hasVPNUser(routerID string, username string) (bool, error) {
+	limit := 100
+	for offset := 0; ; offset += limit {
+		output, err := s.DescribeRouterStatics(&DescribeRouterStaticsInput{
+			Router:     String(routerID),
+			StaticType: Int(RouterStaticTypeVPN),
+			Limit:      Int(limit),
+			Offset:     Int(offset),
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, static := range output.RouterStaticSet {
+			users, err := s.DescribeVPNUsers(StringValue(static.RouterStaticID))
+			if err != nil {
+				return false, err
+			}
+			for _, user := range users {
+				if user.Username == username {
+					return true, nil
+				}
+			}
+		}
+		if len(output.RouterStaticSet) < limit {
+			return false, nil
+		}
+	}
+}
+
+// VPNClientConfig is the OpenVPN client configuration of a VPN user.
+type VPNClientConfig struct {
+	RouterID string
+	Username string
+	Platform string
+
+	Conf      string
+	CaCert    string
+	ClientCrt string
+	ClientKey string
+	StaticKey string
+}
+
+// GetVPNClientConfig gets the OpenVPN client configuration of the user for
+// the platform, one of "linux", "windows" and "mac". It fails if the user
+// is not in any VPN router static of the router.
+func (s *RouterService) GetVPNClientConfig(routerID string, username string, platform string) (*VPNClientConfig, error) {
+	if username == "" {
+		return nil, errors.ParameterRequiredError{
+			ParameterName: "Username",
+			ParentName:    "VPNClientConfig",
+		}
+	}
+	found, err := s.hasVPNUser(routerID, username)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("VPN user %s not found in router %s", username, routerID)
+	}
+	output, err := s.GetVPNCerts(&GetVPNCertsInput{
+		Router:   String(routerID),
+		Platform: String(platform),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newVPNClientConfig(routerID, username, platform, output)
+}
+
+func newVPNClientConfig(routerID string, username string, platform string, output *GetVPNCertsOutput) (*VPNClientConfig, error) {
+	c := &VPNClientConfig{
+		RouterID:  routerID,
+		Username:  username,
+		Platform:  platform,
+		CaCert:    StringValue(output.CaCert),
+		ClientCrt: StringValue(output.ClientCrt),
+		ClientKey: StringValue(output.ClientKey),
+		StaticKey: StringValue(output.StaticKey),
+	}
+	switch platform {
+	case "linux":
+		c.Conf = StringValue(output.LinuxConfSample)
+	case "windows":
+		c.Conf = StringValue(output.WindowsConfSample)
+	case "mac":
+		c.Conf = StringValue(output.MacConfSample)
+	}
+	if c.Conf == "" {
+		return nil, fmt.Errorf("VPN client configuration of router %s for platform %s not found", routerID, platform)
+	}
+	return c, nil
+}
+
+// Render returns the configuration with the certificates and keys inlined,
+// so it can be imported by the OpenVPN clients as a single file. The user
+// enters the user name and password when connecting.
+func (c *VPNClientConfig) Render() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "# OpenVPN client configuration of %s of router %s\n", c.Username, c.RouterID)
+	b.WriteString(strings.TrimRight(c.Conf, "\n"))
+	b.WriteString("\n")
+	if !strings.Contains(c.Conf, "auth-user-pass") {
+		b.WriteString("auth-user-pass\n")
+	}
+	for _, block := range []struct{ tag, content string }{
+		{"ca", c.CaCert},
+		{"cert", c.ClientCrt},
+		{"key", c.ClientKey},
+		{"tls-auth", c.StaticKey},
+	} {
+		if block.content != "" {
+			fmt.Fprintf(b, "<%s>\n%s\n</%s>\n", block.tag, strings.TrimRight(block.content, "\n"), block.tag)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,103 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+)
+
+func TestVPNUser(t *testing.T) {
+	user := &VPNUser{Username: "alice", Password: "Secret123"}
+	e, err := user.RouterStaticEntry()
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", StringValue(e.Val1))
+	assert.Equal(t, "Secret123", StringValue(e.Val2))
+	assert.Nil(t, e.RouterStaticEntryID)
+
+	e.RouterStaticEntryID = String("rtrse-1")
+	parsed, err := ParseVPNUser(e)
+	assert.Nil(t, err)
+	assert.Equal(t, &VPNUser{RouterStaticEntryID: "rtrse-1", Username: "alice", Password: "Secret123"}, parsed)
+
+	assert.NotNil(t, (&VPNUser{Username: "a b", Password: "Secret123"}).Validate())
+	assert.NotNil(t, (&VPNUser{Username: "alice", Password: "short"}).Validate())
+	assert.NotNil(t, (&VPNUser{Username: "alice", Password: "has space"}).Validate())
+	_, err = ParseVPNUser(&RouterStaticEntry{})
+	assert.NotNil(t, err)
+	_, err = ParseVPNUser(nil)
+	assert.NotNil(t, err)
+}
+
+func TestVPNClientConfig(t *testing.T) {
+	output := &GetVPNCertsOutput{
+		CaCert:          String("CA\n"),
+		ClientCrt:       String("CRT"),
+		ClientKey:       String("KEY"),
+		LinuxConfSample: String("client\nremote 1.2.3.4 1194\n"),
+	}
+	c, err := newVPNClientConfig("rtr-abcdefgh", "alice", "linux", output)
+	assert.Nil(t, err)
+	conf := c.Render()
+	assert.True(t, strings.Contains(conf, "remote 1.2.3.4 1194\nauth-user-pass\n"))
+	assert.True(t, strings.Contains(conf, "<ca>\nCA\n</ca>\n"))
+	assert.True(t, strings.Contains(conf, "<key>\nKEY\n</key>\n"))
+	assert.False(t, strings.Contains(conf, "<tls-auth>"))
+
+	_, err = newVPNClientConfig("rtr-abcdefgh", "alice", "windows", output)
+	assert.NotNil(t, err)
+}
+
+func TestGetVPNClientConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("action") {
+		case "DescribeRouterStatics":
+			assert.Equal(t, "rtr-abcdefgh", r.URL.Query().Get("router"))
+			assert.Equal(t, "2", r.URL.Query().Get("static_type"))
+			w.Write([]byte(`{"ret_code": 0, "total_count": 1, "router_static_set": [{"router_static_id": "rtrs-1"}]}`))
+		case "DescribeRouterStaticEntries":
+			w.Write([]byte(`{"ret_code": 0, "total_count": 1, "router_static_entry_set": [
+				{"router_static_entry_id": "rtrse-1", "val1": "alice", "val2": "Secret123"}]}`))
+		case "GetVPNCerts":
+			w.Write([]byte(`{"ret_code": 0, "ca_cert": "CA", "client_crt": "CRT", "client_key": "KEY",
+				"linux_conf_sample": "client\nremote 1.2.3.4 1194\n"}`))
+		default:
+			t.Errorf("unexpected action %s", r.URL.Query().Get("action"))
+		}
+	}))
+	defer server.Close()
+
+	conf, err := config.NewWithEndpoint("AccessKeyID", "SecretAccessKey", server.URL+"/iaas")
+	assert.Nil(t, err)
+	qcService, err := Init(conf)
+	assert.Nil(t, err)
+	routerService, err := qcService.Router("pek3a")
+	assert.Nil(t, err)
+
+	c, err := routerService.GetVPNClientConfig("rtr-abcdefgh", "alice", "linux")
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(c.Render(), "<key>\nKEY\n</key>\n"))
+
+	_, err = routerService.GetVPNClientConfig("rtr-abcdefgh", "bob", "linux")
+	assert.EqualError(t, err, "VPN user bob not found in router rtr-abcdefgh")
+}
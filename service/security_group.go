@@ -607,15 +607,19 @@ func (s *SecurityGroupService) DescribeSecurityGroupIPSets(i *DescribeSecurityGr
 }
 
 type DescribeSecurityGroupIPSetsInput struct {
-
+	Console *string `json:"console" name:"console" location:"params"`
 	// IPSetType's available values: 0, 1
 	IPSetType              *int      `json:"ipset_type" name:"ipset_type" location:"params"`
 	Limit                  *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset                 *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner                  *string   `json:"owner" name:"owner" location:"params"`
 	ProjectID              *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse                *int      `json:"reverse" name:"reverse" location:"params"`
+	SearchWord             *string   `json:"search_word" name:"search_word" location:"params"`
 	SecurityGroupIPSetName *string   `json:"security_group_ipset_name" name:"security_group_ipset_name" location:"params"`
 	SecurityGroupIPSets    []*string `json:"security_group_ipsets" name:"security_group_ipsets" location:"params"`
+	SortKey                *string   `json:"sort_key" name:"sort_key" location:"params"`
+	Status                 []*string `json:"status" name:"status" location:"params"`
 	Tags                   []*string `json:"tags" name:"tags" location:"params"`
 	Verbose                *int      `json:"verbose" name:"verbose" default:"0" location:"params"`
 }
@@ -680,14 +684,18 @@ func (s *SecurityGroupService) DescribeSecurityGroupRules(i *DescribeSecurityGro
 }
 
 type DescribeSecurityGroupRulesInput struct {
-
+	Console *string `json:"console" name:"console" location:"params"`
 	// Direction's available values: 0, 1
 	Direction          *int      `json:"direction" name:"direction" location:"params"`
 	Limit              *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset             *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner              *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID          *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord         *string   `json:"search_word" name:"search_word" location:"params"`
 	SecurityGroup      *string   `json:"security_group" name:"security_group" location:"params"`
 	SecurityGroupRules []*string `json:"security_group_rules" name:"security_group_rules" location:"params"`
+	Status             []*string `json:"status" name:"status" location:"params"`
+	Tags               []*string `json:"tags" name:"tags" location:"params"`
 }
 
 func (v *DescribeSecurityGroupRulesInput) Validate() error {
@@ -750,12 +758,17 @@ func (s *SecurityGroupService) DescribeSecurityGroupSnapshots(i *DescribeSecurit
 }
 
 type DescribeSecurityGroupSnapshotsInput struct {
+	Console                *string   `json:"console" name:"console" location:"params"`
 	Limit                  *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset                 *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner                  *string   `json:"owner" name:"owner" location:"params"`
 	ProjectID              *string   `json:"project_id" name:"project_id" location:"params"`
 	Reverse                *int      `json:"reverse" name:"reverse" default:"1" location:"params"`
+	SearchWord             *string   `json:"search_word" name:"search_word" location:"params"`
 	SecurityGroup          *string   `json:"security_group" name:"security_group" location:"params"` // Required
 	SecurityGroupSnapshots []*string `json:"security_group_snapshots" name:"security_group_snapshots" location:"params"`
+	Status                 []*string `json:"status" name:"status" location:"params"`
+	Tags                   []*string `json:"tags" name:"tags" location:"params"`
 }
 
 func (v *DescribeSecurityGroupSnapshotsInput) Validate() error {
@@ -805,12 +818,17 @@ func (s *SecurityGroupService) DescribeSecurityGroups(i *DescribeSecurityGroupsI
 }
 
 type DescribeSecurityGroupsInput struct {
+	Console        *string   `json:"console" name:"console" location:"params"`
+	Fields         []*string `json:"fields" name:"fields" location:"params"`
 	Limit          *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset         *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner          *string   `json:"owner" name:"owner" location:"params"`
 	ProjectID      *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse        *int      `json:"reverse" name:"reverse" location:"params"`
 	SearchWord     *string   `json:"search_word" name:"search_word" location:"params"`
 	SecurityGroups []*string `json:"security_groups" name:"security_groups" location:"params"`
+	SortKey        *string   `json:"sort_key" name:"sort_key" location:"params"`
+	Status         []*string `json:"status" name:"status" location:"params"`
 	Tags           []*string `json:"tags" name:"tags" location:"params"`
 	Verbose        *int      `json:"verbose" name:"verbose" default:"0" location:"params"`
 }
@@ -0,0 +1,49 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strings"
+)
+
+// ParseServerCertificate checks that the PEM encoded certificate chain and private key
+// are a matching pair and returns the leaf certificate.
+func ParseServerCertificate(certificateContent, privateKey string) (*x509.Certificate, error) {
+	pair, err := tls.X509KeyPair([]byte(certificateContent), []byte(privateKey))
+	if err != nil {
+		return nil, fmt.Errorf("server certificate is invalid: %s", err.Error())
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("server certificate is invalid: %s", err.Error())
+	}
+	return leaf, nil
+}
+
+// ServerCertificateName returns a name for the certificate made of its common name
+// and expiry date, e.g. "example.com-20170321", which stays unique across renewals.
+func ServerCertificateName(leaf *x509.Certificate) string {
+	name := leaf.Subject.CommonName
+	if name == "" && len(leaf.DNSNames) > 0 {
+		name = leaf.DNSNames[0]
+	}
+	name = strings.Replace(name, "*", "wildcard", -1)
+	return fmt.Sprintf("%s-%s", name, leaf.NotAfter.UTC().Format("20060102"))
+}
@@ -0,0 +1,67 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func generateTestCertificate(t *testing.T, commonName string, notAfter time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certificate), string(privateKey)
+}
+
+func TestParseServerCertificate(t *testing.T) {
+	notAfter := time.Date(2017, 3, 21, 0, 0, 0, 0, time.UTC)
+	certificate, privateKey := generateTestCertificate(t, "*.example.com", notAfter)
+
+	leaf, err := ParseServerCertificate(certificate, privateKey)
+	assert.Nil(t, err)
+	assert.Equal(t, "*.example.com", leaf.Subject.CommonName)
+	assert.Equal(t, "wildcard.example.com-20170321", ServerCertificateName(leaf))
+
+	_, otherKey := generateTestCertificate(t, "example.com", notAfter)
+	_, err = ParseServerCertificate(certificate, otherKey)
+	assert.NotNil(t, err)
+
+	_, err = ParseServerCertificate("certificate", "key")
+	assert.NotNil(t, err)
+}
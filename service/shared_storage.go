@@ -448,10 +448,15 @@ func (s *SharedStorageService) DescribeS2DefaultParameters(i *DescribeS2DefaultP
 }
 
 type DescribeS2DefaultParametersInput struct {
-	Limit  *int `json:"limit" name:"limit" default:"20" location:"params"`
-	Offset *int `json:"offset" name:"offset" default:"0" location:"params"`
+	Limit      *int    `json:"limit" name:"limit" default:"20" location:"params"`
+	Offset     *int    `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string `json:"project_id" name:"project_id" location:"params"`
+	SearchWord *string `json:"search_word" name:"search_word" location:"params"`
 	// ServiceType's available values: vsan
-	ServiceType *string `json:"service_type" name:"service_type" location:"params"`
+	ServiceType *string   `json:"service_type" name:"service_type" location:"params"`
+	Status      []*string `json:"status" name:"status" location:"params"`
+	Tags        []*string `json:"tags" name:"tags" location:"params"`
 	// TargetType's available values: ISCSI
 	TargetType *string `json:"target_type" name:"target_type" location:"params"`
 }
@@ -536,10 +541,15 @@ func (s *SharedStorageService) DescribeS2Servers(i *DescribeS2ServersInput) (*De
 }
 
 type DescribeS2ServersInput struct {
+	Console    *string   `json:"console" name:"console" location:"params"`
 	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
 	S2Servers  []*string `json:"s2_servers" name:"s2_servers" location:"params"`
 	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
 	Status     []*string `json:"status" name:"status" location:"params"`
 	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	Verbose    *int      `json:"verbose" name:"verbose" location:"params"`
@@ -587,9 +597,13 @@ func (s *SharedStorageService) DescribeS2SharedTargets(i *DescribeS2SharedTarget
 type DescribeS2SharedTargetsInput struct {
 	Limit         *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset        *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner         *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID     *string   `json:"project_id" name:"project_id" location:"params"`
 	S2ServerID    *string   `json:"s2_server_id" name:"s2_server_id" location:"params"`
 	SearchWord    *string   `json:"search_word" name:"search_word" location:"params"`
 	SharedTargets []*string `json:"shared_targets" name:"shared_targets" location:"params"`
+	Status        []*string `json:"status" name:"status" location:"params"`
+	Tags          []*string `json:"tags" name:"tags" location:"params"`
 	Verbose       *int      `json:"verbose" name:"verbose" location:"params"`
 }
 
@@ -323,6 +323,59 @@ type DeleteSnapshotsOutput struct {
 	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
 }
 
+// Documentation URL: https://docs.qingcloud.com/api/snapshot/describe_snapshot_exports.html
+func (s *SnapshotService) DescribeSnapshotExports(i *DescribeSnapshotExportsInput) (*DescribeSnapshotExportsOutput, error) {
+	if i == nil {
+		i = &DescribeSnapshotExportsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "DescribeSnapshotExports",
+		RequestMethod: "GET",
+	}
+
+	x := &DescribeSnapshotExportsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type DescribeSnapshotExportsInput struct {
+	Console         *string   `json:"console" name:"console" location:"params"`
+	Limit           *int      `json:"limit" name:"limit" location:"params"`
+	Offset          *int      `json:"offset" name:"offset" location:"params"`
+	Owner           *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID       *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord      *string   `json:"search_word" name:"search_word" location:"params"`
+	SnapshotExports []*string `json:"snapshot_exports" name:"snapshot_exports" location:"params"`
+	Snapshots       []*string `json:"snapshots" name:"snapshots" location:"params"`
+	Status          []*string `json:"status" name:"status" location:"params"`
+	Tags            []*string `json:"tags" name:"tags" location:"params"`
+	Verbose         *int      `json:"verbose" name:"verbose" location:"params"`
+}
+
+func (v *DescribeSnapshotExportsInput) Validate() error {
+
+	return nil
+}
+
+type DescribeSnapshotExportsOutput struct {
+	Message           *string           `json:"message" name:"message"`
+	Action            *string           `json:"action" name:"action" location:"elements"`
+	RetCode           *int              `json:"ret_code" name:"ret_code" location:"elements"`
+	SnapshotExportSet []*SnapshotExport `json:"snapshot_export_set" name:"snapshot_export_set" location:"elements"`
+	TotalCount        *int              `json:"total_count" name:"total_count" location:"elements"`
+}
+
 // Documentation URL: https://docs.qingcloud.com/api/snapshot/describe_snapshots.html
 func (s *SnapshotService) DescribeSnapshots(i *DescribeSnapshotsInput) (*DescribeSnapshotsOutput, error) {
 	if i == nil {
@@ -350,16 +403,20 @@ func (s *SnapshotService) DescribeSnapshots(i *DescribeSnapshotsInput) (*Describ
 }
 
 type DescribeSnapshotsInput struct {
-	Limit        *int    `json:"limit" name:"limit" default:"20" location:"params"`
-	Offset       *int    `json:"offset" name:"offset" default:"0" location:"params"`
-	Owner        *string `json:"owner" name:"owner" location:"params"`
-	ProjectID    *string `json:"project_id" name:"project_id" location:"params"`
-	ResourceID   *string `json:"resource_id" name:"resource_id" location:"params"`
-	SearchWord   *string `json:"search_word" name:"search_word" location:"params"`
-	SnapshotTime *string `json:"snapshot_time" name:"snapshot_time" location:"params"`
+	Console      *string   `json:"console" name:"console" location:"params"`
+	Fields       []*string `json:"fields" name:"fields" location:"params"`
+	Limit        *int      `json:"limit" name:"limit" default:"20" location:"params"`
+	Offset       *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner        *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID    *string   `json:"project_id" name:"project_id" location:"params"`
+	ResourceID   *string   `json:"resource_id" name:"resource_id" location:"params"`
+	Reverse      *int      `json:"reverse" name:"reverse" location:"params"`
+	SearchWord   *string   `json:"search_word" name:"search_word" location:"params"`
+	SnapshotTime *string   `json:"snapshot_time" name:"snapshot_time" location:"params"`
 	// SnapshotType's available values: 0, 1
 	SnapshotType *int      `json:"snapshot_type" name:"snapshot_type" location:"params"`
 	Snapshots    []*string `json:"snapshots" name:"snapshots" location:"params"`
+	SortKey      *string   `json:"sort_key" name:"sort_key" location:"params"`
 	Status       []*string `json:"status" name:"status" location:"params"`
 	Tags         []*string `json:"tags" name:"tags" location:"params"`
 	// Verbose's available values: 0, 1
@@ -420,6 +477,88 @@ type DescribeSnapshotsOutput struct {
 	TotalCount  *int        `json:"total_count" name:"total_count" location:"elements"`
 }
 
+// Documentation URL: https://docs.qingcloud.com/api/snapshot/export_snapshots.html
+func (s *SnapshotService) ExportSnapshots(i *ExportSnapshotsInput) (*ExportSnapshotsOutput, error) {
+	if i == nil {
+		i = &ExportSnapshotsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "ExportSnapshots",
+		RequestMethod: "GET",
+	}
+
+	x := &ExportSnapshotsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type ExportSnapshotsInput struct {
+	BaseSnapshot *string `json:"base_snapshot" name:"base_snapshot" location:"params"`
+	Bucket       *string `json:"bucket" name:"bucket" location:"params"` // Required
+	// Format's available values: raw, qcow2, vmdk
+	Format    *string `json:"format" name:"format" default:"raw" location:"params"`
+	ObjectKey *string `json:"object_key" name:"object_key" location:"params"`
+	Snapshot  *string `json:"snapshot" name:"snapshot" location:"params"` // Required
+}
+
+func (v *ExportSnapshotsInput) Validate() error {
+
+	if v.Bucket == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "Bucket",
+			ParentName:    "ExportSnapshotsInput",
+		}
+	}
+
+	if v.Format != nil {
+		formatValidValues := []string{"raw", "qcow2", "vmdk"}
+		formatParameterValue := fmt.Sprint(*v.Format)
+
+		formatIsValid := false
+		for _, value := range formatValidValues {
+			if value == formatParameterValue {
+				formatIsValid = true
+			}
+		}
+
+		if !formatIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Format",
+				ParameterValue: formatParameterValue,
+				AllowedValues:  formatValidValues,
+			}
+		}
+	}
+
+	if v.Snapshot == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "Snapshot",
+			ParentName:    "ExportSnapshotsInput",
+		}
+	}
+
+	return nil
+}
+
+type ExportSnapshotsOutput struct {
+	Message          *string `json:"message" name:"message"`
+	Action           *string `json:"action" name:"action" location:"elements"`
+	JobID            *string `json:"job_id" name:"job_id" location:"elements"`
+	RetCode          *int    `json:"ret_code" name:"ret_code" location:"elements"`
+	SnapshotExportID *string `json:"snapshot_export_id" name:"snapshot_export_id" location:"elements"`
+}
+
 // Documentation URL: https://docs.qingcloud.com/api/snapshot/modify_snapshot_attributes.html
 func (s *SnapshotService) ModifySnapshotAttributes(i *ModifySnapshotAttributesInput) (*ModifySnapshotAttributesOutput, error) {
 	if i == nil {
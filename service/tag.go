@@ -222,9 +222,16 @@ func (s *TagService) DescribeTags(i *DescribeTagsInput) (*DescribeTagsOutput, er
 }
 
 type DescribeTagsInput struct {
+	Console    *string   `json:"console" name:"console" location:"params"`
+	Fields     []*string `json:"fields" name:"fields" location:"params"`
 	Limit      *int      `json:"limit" name:"limit" default:"0" location:"params"`
 	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
 	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
 	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	// Verbose's available values: 0, 1
 	Verbose *int `json:"verbose" name:"verbose" default:"0" location:"params"`
@@ -91,18 +91,19 @@ func (v *AppVersion) Validate() error {
 }
 
 type AppVersionAttachment struct {
-	AttachmentID   *string    `json:"attachment_id" name:"attachment_id"`
-	AttachmentType *string    `json:"attachment_type" name:"attachment_type"`
-	Category       *string    `json:"category" name:"category"`
-	CreateTime     *time.Time `json:"create_time" name:"create_time" format:"ISO 8601"`
-	Filename       *string    `json:"filename" name:"filename"`
-	Filesize       *int       `json:"filesize" name:"filesize"`
-	Name           *string    `json:"name" name:"name"`
-	Owner          *string    `json:"owner" name:"owner"`
-	ResourceID     *string    `json:"resource_id" name:"resource_id"`
-	ResourceType   *string    `json:"resource_type" name:"resource_type"`
-	StatusTime     *time.Time `json:"status_time" name:"status_time" format:"ISO 8601"`
-	SubCategory    *string    `json:"sub_category" name:"sub_category"`
+	AttachmentContent map[string]*string `json:"attachment_content" name:"attachment_content"`
+	AttachmentID      *string            `json:"attachment_id" name:"attachment_id"`
+	AttachmentType    *string            `json:"attachment_type" name:"attachment_type"`
+	Category          *string            `json:"category" name:"category"`
+	CreateTime        *time.Time         `json:"create_time" name:"create_time" format:"ISO 8601"`
+	Filename          *string            `json:"filename" name:"filename"`
+	Filesize          *int               `json:"filesize" name:"filesize"`
+	Name              *string            `json:"name" name:"name"`
+	Owner             *string            `json:"owner" name:"owner"`
+	ResourceID        *string            `json:"resource_id" name:"resource_id"`
+	ResourceType      *string            `json:"resource_type" name:"resource_type"`
+	StatusTime        *time.Time         `json:"status_time" name:"status_time" format:"ISO 8601"`
+	SubCategory       *string            `json:"sub_category" name:"sub_category"`
 }
 
 func (v *AppVersionAttachment) Validate() error {
@@ -130,6 +131,7 @@ type Cache struct {
 	MaxMemory       *int         `json:"max_memory" name:"max_memory"`
 	NodeCount       *int         `json:"node_count" name:"node_count"`
 	Nodes           []*CacheNode `json:"nodes" name:"nodes"`
+	Owner           *string      `json:"owner" name:"owner"`
 	ReplicateCount  *int         `json:"replicate_count" name:"replicate_count"`
 	SecurityGroupID *string      `json:"security_group_id" name:"security_group_id"`
 	// Status's available values: pending, active, stopped, suspended, deleted, ceased
@@ -414,6 +416,7 @@ type CacheParameterGroup struct {
 	// IsApplied's available values: 0, 1
 	IsApplied *int        `json:"is_applied" name:"is_applied"`
 	IsDefault *int        `json:"is_default" name:"is_default"`
+	Owner     *string     `json:"owner" name:"owner"`
 	Resources []*Resource `json:"resources" name:"resources"`
 }
 
@@ -646,6 +649,7 @@ type DNSAlias struct {
 	DNSAliasID   *string    `json:"dns_alias_id" name:"dns_alias_id"`
 	DNSAliasName *string    `json:"dns_alias_name" name:"dns_alias_name"`
 	DomainName   *string    `json:"domain_name" name:"domain_name"`
+	Owner        *string    `json:"owner" name:"owner"`
 	ResourceID   *string    `json:"resource_id" name:"resource_id"`
 	Status       *string    `json:"status" name:"status"`
 }
@@ -669,6 +673,7 @@ type EIP struct {
 	EIPName     *string      `json:"eip_name" name:"eip_name"`
 	ICPCodes    *string      `json:"icp_codes" name:"icp_codes"`
 	NeedICP     *int         `json:"need_icp" name:"need_icp"`
+	Owner       *string      `json:"owner" name:"owner"`
 	Resource    *EIPResource `json:"resource" name:"resource"`
 	// Status's available values: pending, available, associated, suspended, released, ceased
 	Status     *string    `json:"status" name:"status"`
@@ -808,7 +813,7 @@ func (v *Extra) Validate() error {
 type File struct {
 	File       *string `json:"file" name:"file"`
 	LastModify *string `json:"last_modify" name:"last_modify"`
-	Size       *int    `json:"size" name:"size"`
+	Size       *int64  `json:"size" name:"size"`
 }
 
 func (v *File) Validate() error {
@@ -996,25 +1001,32 @@ func (v *ImageUser) Validate() error {
 }
 
 type Instance struct {
-	AlarmStatus      *string        `json:"alarm_status" name:"alarm_status"`
-	CPUTopology      *string        `json:"cpu_topology" name:"cpu_topology"`
-	CreateTime       *time.Time     `json:"create_time" name:"create_time" format:"ISO 8601"`
-	Description      *string        `json:"description" name:"description"`
-	Device           *string        `json:"device" name:"device"`
-	DNSAliases       []*DNSAlias    `json:"dns_aliases" name:"dns_aliases"`
-	EIP              *EIP           `json:"eip" name:"eip"`
-	Extra            *Extra         `json:"extra" name:"extra"`
-	GraphicsPasswd   *string        `json:"graphics_passwd" name:"graphics_passwd"`
-	GraphicsProtocol *string        `json:"graphics_protocol" name:"graphics_protocol"`
-	Image            *Image         `json:"image" name:"image"`
-	InstanceClass    *int           `json:"instance_class" name:"instance_class"`
-	InstanceID       *string        `json:"instance_id" name:"instance_id"`
-	InstanceName     *string        `json:"instance_name" name:"instance_name"`
-	InstanceType     *string        `json:"instance_type" name:"instance_type"`
-	KeyPairIDs       []*string      `json:"keypair_ids" name:"keypair_ids"`
-	MemoryCurrent    *int           `json:"memory_current" name:"memory_current"`
-	Repl             *string        `json:"repl" name:"repl"`
-	SecurityGroup    *SecurityGroup `json:"security_group" name:"security_group"`
+	AlarmStatus      *string     `json:"alarm_status" name:"alarm_status"`
+	CipherAlg        *string     `json:"cipher_alg" name:"cipher_alg"`
+	CPUTopology      *string     `json:"cpu_topology" name:"cpu_topology"`
+	CreateTime       *time.Time  `json:"create_time" name:"create_time" format:"ISO 8601"`
+	Description      *string     `json:"description" name:"description"`
+	Device           *string     `json:"device" name:"device"`
+	DNSAliases       []*DNSAlias `json:"dns_aliases" name:"dns_aliases"`
+	EIP              *EIP        `json:"eip" name:"eip"`
+	Extra            *Extra      `json:"extra" name:"extra"`
+	GraphicsPasswd   *string     `json:"graphics_passwd" name:"graphics_passwd"`
+	GraphicsProtocol *string     `json:"graphics_protocol" name:"graphics_protocol"`
+	Image            *Image      `json:"image" name:"image"`
+	InstanceClass    *int        `json:"instance_class" name:"instance_class"`
+	InstanceID       *string     `json:"instance_id" name:"instance_id"`
+	InstanceName     *string     `json:"instance_name" name:"instance_name"`
+	InstanceType     *string     `json:"instance_type" name:"instance_type"`
+	KeyPairIDs       []*string   `json:"keypair_ids" name:"keypair_ids"`
+	MemoryCurrent    *int        `json:"memory_current" name:"memory_current"`
+	MemoryMax        *int        `json:"memory_max" name:"memory_max"`
+	OSDiskEncryption *int        `json:"os_disk_encryption" name:"os_disk_encryption"`
+	OSFamily         *string     `json:"os_family" name:"os_family"`
+	Owner            *string     `json:"owner" name:"owner"`
+	// Platform's available values: linux, windows
+	Platform      *string        `json:"platform" name:"platform"`
+	Repl          *string        `json:"repl" name:"repl"`
+	SecurityGroup *SecurityGroup `json:"security_group" name:"security_group"`
 	// Status's available values: pending, running, stopped, suspended, terminated, ceased
 	Status     *string    `json:"status" name:"status"`
 	StatusTime *time.Time `json:"status_time" name:"status_time" format:"ISO 8601"`
@@ -1023,6 +1035,7 @@ type Instance struct {
 	// TransitionStatus's available values: creating, starting, stopping, restarting, suspending, resuming, terminating, recovering, resetting
 	TransitionStatus *string     `json:"transition_status" name:"transition_status"`
 	VCPUsCurrent     *int        `json:"vcpus_current" name:"vcpus_current"`
+	VCPUsMax         *int        `json:"vcpus_max" name:"vcpus_max"`
 	VolumeIDs        []*string   `json:"volume_ids" name:"volume_ids"`
 	Volumes          []*Volume   `json:"volumes" name:"volumes"`
 	VxNets           []*NICVxNet `json:"vxnets" name:"vxnets"`
@@ -1057,6 +1070,26 @@ func (v *Instance) Validate() error {
 		}
 	}
 
+	if v.Platform != nil {
+		platformValidValues := []string{"linux", "windows"}
+		platformParameterValue := fmt.Sprint(*v.Platform)
+
+		platformIsValid := false
+		for _, value := range platformValidValues {
+			if value == platformParameterValue {
+				platformIsValid = true
+			}
+		}
+
+		if !platformIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Platform",
+				ParameterValue: platformParameterValue,
+				AllowedValues:  platformValidValues,
+			}
+		}
+	}
+
 	if v.SecurityGroup != nil {
 		if err := v.SecurityGroup.Validate(); err != nil {
 			return err
@@ -1299,6 +1332,7 @@ type LoadBalancer struct {
 	// LoadBalancerType's available values: 0, 1, 2, 3, 4, 5
 	LoadBalancerType *int      `json:"loadbalancer_type" name:"loadbalancer_type"`
 	NodeCount        *int      `json:"node_count" name:"node_count"`
+	Owner            *string   `json:"owner" name:"owner"`
 	PrivateIPs       []*string `json:"private_ips" name:"private_ips"`
 	SecurityGroupID  *string   `json:"security_group_id" name:"security_group_id"`
 	// Status's available values: pending, active, stopped, suspended, deleted, ceased
@@ -1434,6 +1468,7 @@ type LoadBalancerBackend struct {
 	LoadBalancerID          *string    `json:"loadbalancer_id" name:"loadbalancer_id"`
 	LoadBalancerListenerID  *string    `json:"loadbalancer_listener_id" name:"loadbalancer_listener_id"`
 	LoadBalancerPolicyID    *string    `json:"loadbalancer_policy_id" name:"loadbalancer_policy_id"`
+	Owner                   *string    `json:"owner" name:"owner"`
 	Port                    *int       `json:"port" name:"port"`
 	ResourceID              *string    `json:"resource_id" name:"resource_id"`
 	Status                  *string    `json:"status" name:"status"`
@@ -1460,6 +1495,7 @@ type LoadBalancerListener struct {
 	LoadBalancerID           *string    `json:"loadbalancer_id" name:"loadbalancer_id"`
 	LoadBalancerListenerID   *string    `json:"loadbalancer_listener_id" name:"loadbalancer_listener_id"`
 	LoadBalancerListenerName *string    `json:"loadbalancer_listener_name" name:"loadbalancer_listener_name"`
+	Owner                    *string    `json:"owner" name:"owner"`
 	ServerCertificateID      []*string  `json:"server_certificate_id" name:"server_certificate_id"`
 	SessionSticky            *string    `json:"session_sticky" name:"session_sticky"`
 	Timeout                  *int       `json:"timeout" name:"timeout"`
@@ -1506,6 +1542,7 @@ type LoadBalancerPolicy struct {
 	LoadBalancerIDs        []*string `json:"loadbalancer_ids" name:"loadbalancer_ids"`
 	LoadBalancerPolicyID   *string   `json:"loadbalancer_policy_id" name:"loadbalancer_policy_id"`
 	LoadBalancerPolicyName *string   `json:"loadbalancer_policy_name" name:"loadbalancer_policy_name"`
+	Owner                  *string   `json:"owner" name:"owner"`
 }
 
 func (v *LoadBalancerPolicy) Validate() error {
@@ -1569,6 +1606,7 @@ type Mongo struct {
 	MongoName           *string    `json:"mongo_name" name:"mongo_name"`
 	MongoType           *int       `json:"mongo_type" name:"mongo_type"`
 	MongoVersion        *string    `json:"mongo_version" name:"mongo_version"`
+	Owner               *string    `json:"owner" name:"owner"`
 	// Status's available values: pending, active, stopped, deleted, suspended, ceased
 	Status      *string    `json:"status" name:"status"`
 	StatusTime  *time.Time `json:"status_time" name:"status_time" format:"ISO 8601"`
@@ -1770,6 +1808,7 @@ type NIC struct {
 	PrivateIP     *string    `json:"private_ip" name:"private_ip"`
 	Role          *int       `json:"role" name:"role"`
 	RootUserID    *string    `json:"root_user_id" name:"root_user_id"`
+	SecondaryIPs  []*string  `json:"secondary_ips" name:"secondary_ips"`
 	SecurityGroup *string    `json:"security_group" name:"security_group"`
 	Sequence      *int       `json:"sequence" name:"sequence"`
 	// Status's available values: available, in-use
@@ -1912,6 +1951,165 @@ func (v *NotificationListItem) Validate() error {
 	return nil
 }
 
+type NotificationSendHistory struct {
+	ErrorMessage            *string `json:"error_message" name:"error_message"`
+	NotificationItemContent *string `json:"notification_item_content" name:"notification_item_content"`
+	// NotificationItemType's available values: email, phone, webhook
+	NotificationItemType *string    `json:"notification_item_type" name:"notification_item_type"`
+	NotificationListID   *string    `json:"notification_list_id" name:"notification_list_id"`
+	ResourceID           *string    `json:"resource_id" name:"resource_id"`
+	SendTime             *time.Time `json:"send_time" name:"send_time" format:"ISO 8601"`
+	// Status's available values: successful, failed
+	Status *string `json:"status" name:"status"`
+}
+
+func (v *NotificationSendHistory) Validate() error {
+
+	if v.NotificationItemType != nil {
+		notificationItemTypeValidValues := []string{"email", "phone", "webhook"}
+		notificationItemTypeParameterValue := fmt.Sprint(*v.NotificationItemType)
+
+		notificationItemTypeIsValid := false
+		for _, value := range notificationItemTypeValidValues {
+			if value == notificationItemTypeParameterValue {
+				notificationItemTypeIsValid = true
+			}
+		}
+
+		if !notificationItemTypeIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "NotificationItemType",
+				ParameterValue: notificationItemTypeParameterValue,
+				AllowedValues:  notificationItemTypeValidValues,
+			}
+		}
+	}
+
+	if v.Status != nil {
+		statusValidValues := []string{"successful", "failed"}
+		statusParameterValue := fmt.Sprint(*v.Status)
+
+		statusIsValid := false
+		for _, value := range statusValidValues {
+			if value == statusParameterValue {
+				statusIsValid = true
+			}
+		}
+
+		if !statusIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Status",
+				ParameterValue: statusParameterValue,
+				AllowedValues:  statusValidValues,
+			}
+		}
+	}
+
+	return nil
+}
+
+type Price struct {
+	// ChargeMode's available values: elastic, monthly, yearly
+	ChargeMode   *string  `json:"charge_mode" name:"charge_mode"`
+	Price        *Decimal `json:"price" name:"price"`
+	ResourceType *string  `json:"resource_type" name:"resource_type"`
+	Sequence     *int     `json:"sequence" name:"sequence"`
+	Unit         *string  `json:"unit" name:"unit"`
+}
+
+func (v *Price) Validate() error {
+
+	if v.ChargeMode != nil {
+		chargeModeValidValues := []string{"elastic", "monthly", "yearly"}
+		chargeModeParameterValue := fmt.Sprint(*v.ChargeMode)
+
+		chargeModeIsValid := false
+		for _, value := range chargeModeValidValues {
+			if value == chargeModeParameterValue {
+				chargeModeIsValid = true
+			}
+		}
+
+		if !chargeModeIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "ChargeMode",
+				ParameterValue: chargeModeParameterValue,
+				AllowedValues:  chargeModeValidValues,
+			}
+		}
+	}
+
+	return nil
+}
+
+type PriceResource struct {
+	Bandwidth *int `json:"bandwidth" name:"bandwidth"`
+	// ChargeMode's available values: elastic, monthly, yearly
+	ChargeMode    *string `json:"charge_mode" name:"charge_mode"`
+	Count         *int    `json:"count" name:"count"`
+	CPU           *int    `json:"cpu" name:"cpu"`
+	ImageID       *string `json:"image_id" name:"image_id"`
+	InstanceClass *int    `json:"instance_class" name:"instance_class"`
+	Memory        *int    `json:"memory" name:"memory"`
+	Sequence      *int    `json:"sequence" name:"sequence"`
+	Size          *int    `json:"size" name:"size"`
+	// Type's available values: instance, volume, eip, router, load_balancer, snapshot
+	Type       *string `json:"type" name:"type"`
+	VolumeType *int    `json:"volume_type" name:"volume_type"`
+}
+
+func (v *PriceResource) Validate() error {
+
+	if v.ChargeMode != nil {
+		chargeModeValidValues := []string{"elastic", "monthly", "yearly"}
+		chargeModeParameterValue := fmt.Sprint(*v.ChargeMode)
+
+		chargeModeIsValid := false
+		for _, value := range chargeModeValidValues {
+			if value == chargeModeParameterValue {
+				chargeModeIsValid = true
+			}
+		}
+
+		if !chargeModeIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "ChargeMode",
+				ParameterValue: chargeModeParameterValue,
+				AllowedValues:  chargeModeValidValues,
+			}
+		}
+	}
+
+	if v.Type == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "Type",
+			ParentName:    "PriceResource",
+		}
+	}
+
+	if v.Type != nil {
+		typeValidValues := []string{"instance", "volume", "eip", "router", "load_balancer", "snapshot"}
+		typeParameterValue := fmt.Sprint(*v.Type)
+
+		typeIsValid := false
+		for _, value := range typeValidValues {
+			if value == typeParameterValue {
+				typeIsValid = true
+			}
+		}
+
+		if !typeIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Type",
+				ParameterValue: typeParameterValue,
+				AllowedValues:  typeValidValues,
+			}
+		}
+	}
+
+	return nil
+}
+
 type Project struct {
 	ConsoleID       *string `json:"console_id" name:"console_id"`
 	CreateTime      *string `json:"create_time" name:"create_time"`
@@ -1970,6 +2168,7 @@ type RDB struct {
 	EngineVersion       *string    `json:"engine_version" name:"engine_version"`
 	LatestSnapshotTime  *time.Time `json:"latest_snapshot_time" name:"latest_snapshot_time" format:"ISO 8601"`
 	MasterIP            *string    `json:"master_ip" name:"master_ip"`
+	Owner               *string    `json:"owner" name:"owner"`
 	RDBEngine           *string    `json:"rdb_engine" name:"rdb_engine"`
 	RDBID               *string    `json:"rdb_id" name:"rdb_id"`
 	RDBName             *string    `json:"rdb_name" name:"rdb_name"`
@@ -2357,6 +2556,7 @@ type Router struct {
 	IsApplied  *int    `json:"is_applied" name:"is_applied"`
 	ManagerIP  *string `json:"manager_ip" name:"manager_ip"`
 	Mode       *int    `json:"mode" name:"mode"`
+	Owner      *string `json:"owner" name:"owner"`
 	PrivateIP  *string `json:"private_ip" name:"private_ip"`
 	RouterID   *string `json:"router_id" name:"router_id"`
 	RouterName *string `json:"router_name" name:"router_name"`
@@ -2483,6 +2683,7 @@ func (v *Router) Validate() error {
 type RouterStatic struct {
 	CreateTime       *time.Time                 `json:"create_time" name:"create_time" format:"ISO 8601"`
 	EntrySet         []*RouterStaticEntrySimple `json:"entry_set" name:"entry_set"`
+	Owner            *string                    `json:"owner" name:"owner"`
 	RouterID         *string                    `json:"router_id" name:"router_id"`
 	RouterStaticID   *string                    `json:"router_static_id" name:"router_static_id"`
 	RouterStaticName *string                    `json:"router_static_name" name:"router_static_name"`
@@ -2593,6 +2794,7 @@ type S2Server struct {
 	// IsApplied's available values: 0, 1
 	IsApplied  *int    `json:"is_applied" name:"is_applied"`
 	Name       *string `json:"name" name:"name"`
+	Owner      *string `json:"owner" name:"owner"`
 	PrivateIP  *string `json:"private_ip" name:"private_ip"`
 	S2ServerID *string `json:"s2_server_id" name:"s2_server_id"`
 	// S2ServerType's available values: 0, 1, 2, 3
@@ -2768,6 +2970,7 @@ type SecurityGroup struct {
 	Description       *string     `json:"description" name:"description"`
 	IsApplied         *int        `json:"is_applied" name:"is_applied"`
 	IsDefault         *int        `json:"is_default" name:"is_default"`
+	Owner             *string     `json:"owner" name:"owner"`
 	Resources         []*Resource `json:"resources" name:"resources"`
 	SecurityGroupID   *string     `json:"security_group_id" name:"security_group_id"`
 	SecurityGroupName *string     `json:"security_group_name" name:"security_group_name"`
@@ -2800,6 +3003,7 @@ type SecurityGroupIPSet struct {
 	Description *string    `json:"description" name:"description"`
 	// IPSetType's available values: 0, 1
 	IPSetType              *int    `json:"ipset_type" name:"ipset_type"`
+	Owner                  *string `json:"owner" name:"owner"`
 	SecurityGroupIPSetID   *string `json:"security_group_ipset_id" name:"security_group_ipset_id"`
 	SecurityGroupIPSetName *string `json:"security_group_ipset_name" name:"security_group_ipset_name"`
 	Val                    *string `json:"val" name:"val"`
@@ -2891,7 +3095,10 @@ func (v *SecurityGroupRule) Validate() error {
 }
 
 type SecurityGroupSnapshot struct {
+	CreateTime              *time.Time           `json:"create_time" name:"create_time" format:"ISO 8601"`
 	GroupID                 *string              `json:"group_id" name:"group_id"`
+	Name                    *string              `json:"name" name:"name"`
+	Owner                   *string              `json:"owner" name:"owner"`
 	Rules                   []*SecurityGroupRule `json:"rules" name:"rules"`
 	SecurityGroupSnapshotID *string              `json:"security_group_snapshot_id" name:"security_group_snapshot_id"`
 }
@@ -2913,6 +3120,7 @@ type ServerCertificate struct {
 	CertificateContent    *string    `json:"certificate_content" name:"certificate_content"`
 	CreateTime            *time.Time `json:"create_time" name:"create_time" format:"ISO 8601"`
 	Description           *string    `json:"description" name:"description"`
+	Owner                 *string    `json:"owner" name:"owner"`
 	PrivateKey            *string    `json:"private_key" name:"private_key"`
 	ServerCertificateID   *string    `json:"server_certificate_id" name:"server_certificate_id"`
 	ServerCertificateName *string    `json:"server_certificate_name" name:"server_certificate_name"`
@@ -2932,6 +3140,7 @@ type Snapshot struct {
 	// IsTaken's available values: 0, 1
 	IsTaken            *int              `json:"is_taken" name:"is_taken"`
 	LatestSnapshotTime *time.Time        `json:"latest_snapshot_time" name:"latest_snapshot_time" format:"ISO 8601"`
+	Owner              *string           `json:"owner" name:"owner"`
 	ParentID           *string           `json:"parent_id" name:"parent_id"`
 	Provider           *string           `json:"provider" name:"provider"`
 	Resource           *Resource         `json:"resource" name:"resource"`
@@ -3081,6 +3290,68 @@ func (v *Snapshot) Validate() error {
 	return nil
 }
 
+type SnapshotExport struct {
+	BaseSnapshotID *string    `json:"base_snapshot_id" name:"base_snapshot_id"`
+	Bucket         *string    `json:"bucket" name:"bucket"`
+	CreateTime     *time.Time `json:"create_time" name:"create_time" format:"ISO 8601"`
+	// Format's available values: raw, qcow2, vmdk
+	Format           *string `json:"format" name:"format"`
+	ObjectKey        *string `json:"object_key" name:"object_key"`
+	Owner            *string `json:"owner" name:"owner"`
+	Progress         *int    `json:"progress" name:"progress"`
+	Size             *int64  `json:"size" name:"size"`
+	SnapshotExportID *string `json:"snapshot_export_id" name:"snapshot_export_id"`
+	SnapshotID       *string `json:"snapshot_id" name:"snapshot_id"`
+	// Status's available values: pending, working, successful, failed
+	Status     *string    `json:"status" name:"status"`
+	StatusTime *time.Time `json:"status_time" name:"status_time" format:"ISO 8601"`
+}
+
+func (v *SnapshotExport) Validate() error {
+
+	if v.Format != nil {
+		formatValidValues := []string{"raw", "qcow2", "vmdk"}
+		formatParameterValue := fmt.Sprint(*v.Format)
+
+		formatIsValid := false
+		for _, value := range formatValidValues {
+			if value == formatParameterValue {
+				formatIsValid = true
+			}
+		}
+
+		if !formatIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Format",
+				ParameterValue: formatParameterValue,
+				AllowedValues:  formatValidValues,
+			}
+		}
+	}
+
+	if v.Status != nil {
+		statusValidValues := []string{"pending", "working", "successful", "failed"}
+		statusParameterValue := fmt.Sprint(*v.Status)
+
+		statusIsValid := false
+		for _, value := range statusValidValues {
+			if value == statusParameterValue {
+				statusIsValid = true
+			}
+		}
+
+		if !statusIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Status",
+				ParameterValue: statusParameterValue,
+				AllowedValues:  statusValidValues,
+			}
+		}
+	}
+
+	return nil
+}
+
 type SnapshotResource struct {
 	Architecture *string `json:"architecture" name:"architecture"`
 	Filesystem   *string `json:"filesystem" name:"filesystem"`
@@ -3141,15 +3412,19 @@ func (v *User) Validate() error {
 }
 
 type Volume struct {
+	CipherAlg          *string     `json:"cipher_alg" name:"cipher_alg"`
+	CmkID              *string     `json:"cmk_id" name:"cmk_id"`
 	CreateTime         *time.Time  `json:"create_time" name:"create_time" format:"ISO 8601"`
 	Description        *string     `json:"description" name:"description"`
 	Device             *string     `json:"device" name:"device"`
+	Encryption         *int        `json:"encryption" name:"encryption"`
 	Instance           *Instance   `json:"instance" name:"instance"`
 	Instances          []*Instance `json:"instances" name:"instances"`
 	LatestSnapshotTime *time.Time  `json:"latest_snapshot_time" name:"latest_snapshot_time" format:"ISO 8601"`
 	Owner              *string     `json:"owner" name:"owner"`
 	PlaceGroupID       *string     `json:"place_group_id" name:"place_group_id"`
 	Repl               *string     `json:"repl" name:"repl"`
+	ResourceQos        *string     `json:"resource_qos" name:"resource_qos"`
 	Size               *int        `json:"size" name:"size"`
 	// Status's available values: pending, available, in-use, suspended, deleted, ceased
 	Status     *string    `json:"status" name:"status"`
@@ -3304,6 +3579,210 @@ func (v *VxNet) Validate() error {
 	return nil
 }
 
+type WAF struct {
+	CreateTime     *time.Time `json:"create_time" name:"create_time" format:"ISO 8601"`
+	Description    *string    `json:"description" name:"description"`
+	DomainCount    *int       `json:"domain_count" name:"domain_count"`
+	LoadBalancerID *string    `json:"loadbalancer_id" name:"loadbalancer_id"`
+	Owner          *string    `json:"owner" name:"owner"`
+	// Status's available values: pending, active, stopped, suspended, deleted, ceased
+	Status           *string    `json:"status" name:"status"`
+	StatusTime       *time.Time `json:"status_time" name:"status_time" format:"ISO 8601"`
+	Tags             []*Tag     `json:"tags" name:"tags"`
+	TransitionStatus *string    `json:"transition_status" name:"transition_status"`
+	WAFID            *string    `json:"waf_id" name:"waf_id"`
+	WAFName          *string    `json:"waf_name" name:"waf_name"`
+}
+
+func (v *WAF) Validate() error {
+
+	if v.Status != nil {
+		statusValidValues := []string{"pending", "active", "stopped", "suspended", "deleted", "ceased"}
+		statusParameterValue := fmt.Sprint(*v.Status)
+
+		statusIsValid := false
+		for _, value := range statusValidValues {
+			if value == statusParameterValue {
+				statusIsValid = true
+			}
+		}
+
+		if !statusIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Status",
+				ParameterValue: statusParameterValue,
+				AllowedValues:  statusValidValues,
+			}
+		}
+	}
+
+	if len(v.Tags) > 0 {
+		for _, property := range v.Tags {
+			if err := property.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type WAFAttackLog struct {
+	Action     *string    `json:"action" name:"action"`
+	AttackTime *time.Time `json:"attack_time" name:"attack_time" format:"ISO 8601"`
+	Category   *string    `json:"category" name:"category"`
+	ClientIP   *string    `json:"client_ip" name:"client_ip"`
+	Domain     *string    `json:"domain" name:"domain"`
+	LogID      *string    `json:"log_id" name:"log_id"`
+	Method     *string    `json:"method" name:"method"`
+	RuleID     *string    `json:"rule_id" name:"rule_id"`
+	URI        *string    `json:"uri" name:"uri"`
+	WAFID      *string    `json:"waf_id" name:"waf_id"`
+}
+
+func (v *WAFAttackLog) Validate() error {
+
+	return nil
+}
+
+type WAFDomain struct {
+	Backend    *string    `json:"backend" name:"backend"`
+	CreateTime *time.Time `json:"create_time" name:"create_time" format:"ISO 8601"`
+	Domain     *string    `json:"domain" name:"domain"`
+	// Mode's available values: block, monitor, off
+	Mode        *string `json:"mode" name:"mode"`
+	Owner       *string `json:"owner" name:"owner"`
+	RuleSetID   *string `json:"rule_set_id" name:"rule_set_id"`
+	Status      *string `json:"status" name:"status"`
+	WAFDomainID *string `json:"waf_domain_id" name:"waf_domain_id"`
+	WAFID       *string `json:"waf_id" name:"waf_id"`
+}
+
+func (v *WAFDomain) Validate() error {
+
+	if v.Mode != nil {
+		modeValidValues := []string{"block", "monitor", "off"}
+		modeParameterValue := fmt.Sprint(*v.Mode)
+
+		modeIsValid := false
+		for _, value := range modeValidValues {
+			if value == modeParameterValue {
+				modeIsValid = true
+			}
+		}
+
+		if !modeIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Mode",
+				ParameterValue: modeParameterValue,
+				AllowedValues:  modeValidValues,
+			}
+		}
+	}
+
+	return nil
+}
+
+type WAFRule struct {
+	// Action's available values: block, monitor, allow
+	Action *string `json:"action" name:"action"`
+	// Category's available values: sqli, xss, rfi, lfi, webshell, scanner, cc, custom
+	Category *string `json:"category" name:"category"`
+	// Enabled's available values: 0, 1
+	Enabled  *int    `json:"enabled" name:"enabled"`
+	Pattern  *string `json:"pattern" name:"pattern"`
+	Priority *int    `json:"priority" name:"priority"`
+	RuleID   *string `json:"rule_id" name:"rule_id"`
+	RuleName *string `json:"rule_name" name:"rule_name"`
+}
+
+func (v *WAFRule) Validate() error {
+
+	if v.Action != nil {
+		actionValidValues := []string{"block", "monitor", "allow"}
+		actionParameterValue := fmt.Sprint(*v.Action)
+
+		actionIsValid := false
+		for _, value := range actionValidValues {
+			if value == actionParameterValue {
+				actionIsValid = true
+			}
+		}
+
+		if !actionIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Action",
+				ParameterValue: actionParameterValue,
+				AllowedValues:  actionValidValues,
+			}
+		}
+	}
+
+	if v.Category != nil {
+		categoryValidValues := []string{"sqli", "xss", "rfi", "lfi", "webshell", "scanner", "cc", "custom"}
+		categoryParameterValue := fmt.Sprint(*v.Category)
+
+		categoryIsValid := false
+		for _, value := range categoryValidValues {
+			if value == categoryParameterValue {
+				categoryIsValid = true
+			}
+		}
+
+		if !categoryIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Category",
+				ParameterValue: categoryParameterValue,
+				AllowedValues:  categoryValidValues,
+			}
+		}
+	}
+
+	if v.Enabled != nil {
+		enabledValidValues := []string{"0", "1"}
+		enabledParameterValue := fmt.Sprint(*v.Enabled)
+
+		enabledIsValid := false
+		for _, value := range enabledValidValues {
+			if value == enabledParameterValue {
+				enabledIsValid = true
+			}
+		}
+
+		if !enabledIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Enabled",
+				ParameterValue: enabledParameterValue,
+				AllowedValues:  enabledValidValues,
+			}
+		}
+	}
+
+	return nil
+}
+
+type WAFRuleSet struct {
+	CreateTime  *time.Time `json:"create_time" name:"create_time" format:"ISO 8601"`
+	Description *string    `json:"description" name:"description"`
+	RuleCount   *int       `json:"rule_count" name:"rule_count"`
+	Rules       []*WAFRule `json:"rules" name:"rules"`
+	RuleSetID   *string    `json:"rule_set_id" name:"rule_set_id"`
+	RuleSetName *string    `json:"rule_set_name" name:"rule_set_name"`
+}
+
+func (v *WAFRuleSet) Validate() error {
+
+	if len(v.Rules) > 0 {
+		for _, property := range v.Rules {
+			if err := property.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 type Zone struct {
 	// Status's available values: active, faulty, defunct
 	Status *string `json:"status" name:"status"`
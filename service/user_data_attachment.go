@@ -0,0 +1,79 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Types of the user data of instances.
+const (
+	UserDataTypePlain = "plain"
+	UserDataTypeExec  = "exec"
+	UserDataTypeTar   = "tar"
+)
+
+// MaxUserDataAttachmentSize is the size limit in bytes of a user data attachment before encoding.
+const MaxUserDataAttachmentSize = 2 * 1024 * 1024
+
+// EncodeUserData reads the content from reader and encodes it in base64, it
+// fails if the content exceeds limit bytes. The content is encoded while
+// read, so only the encoded copy is kept in memory.
+func EncodeUserData(reader io.Reader, limit int64) (string, error) {
+	encoded := &strings.Builder{}
+	encoder := base64.NewEncoder(base64.StdEncoding, encoded)
+	n, err := io.Copy(encoder, io.LimitReader(reader, limit+1))
+	if err != nil {
+		return "", err
+	}
+	if n > limit {
+		return "", fmt.Errorf("user data exceeds the size limit of %d bytes", limit)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return encoded.String(), nil
+}
+
+// UploadUserDataAttachmentFrom uploads the content read from reader as a
+// user data attachment with the name, and returns the attachment ID.
+// The API takes the whole attachment in a single POST request, it has no
+// chunked upload, so the content is limited to MaxUserDataAttachmentSize.
+// The reader is read once, so the retries of the request resend the
+// encoded content instead of reading the reader again. A retry after a
+// lost response may leave an unused attachment behind.
+func (s *UserDataService) UploadUserDataAttachmentFrom(name string, reader io.Reader) (string, error) {
+	content, err := EncodeUserData(reader, MaxUserDataAttachmentSize)
+	if err != nil {
+		return "", err
+	}
+	input := &UploadUserDataAttachmentInput{AttachmentContent: String(content)}
+	if name != "" {
+		input.AttachmentName = String(name)
+	}
+	output, err := s.UploadUserDataAttachment(input)
+	if err != nil {
+		return "", err
+	}
+	if StringValue(output.AttachmentID) == "" {
+		return "", fmt.Errorf("upload user data attachment [%s] response error", name)
+	}
+	return *output.AttachmentID, nil
+}
@@ -0,0 +1,67 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yunify/qingcloud-sdk-go/config"
+)
+
+func TestUploadUserDataAttachmentFrom(t *testing.T) {
+	script := "#!/bin/sh\necho hello > /tmp/hello\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "UploadUserDataAttachment", r.FormValue("action"))
+		assert.Equal(t, "init.sh", r.FormValue("attachment_name"))
+		content, err := base64.StdEncoding.DecodeString(r.FormValue("attachment_content"))
+		assert.Nil(t, err)
+		assert.Equal(t, script, string(content))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ret_code": 0, "attachment_id": "uda-abcdefgh"}`))
+	}))
+	defer server.Close()
+
+	conf, err := config.NewWithEndpoint("AccessKeyID", "SecretAccessKey", server.URL+"/iaas")
+	assert.Nil(t, err)
+	qcService, err := Init(conf)
+	assert.Nil(t, err)
+	userDataService, err := qcService.UserData("pek3a")
+	assert.Nil(t, err)
+
+	attachmentID, err := userDataService.UploadUserDataAttachmentFrom("init.sh", strings.NewReader(script))
+	assert.Nil(t, err)
+	assert.Equal(t, "uda-abcdefgh", attachmentID)
+
+	_, err = userDataService.UploadUserDataAttachmentFrom("large", bytes.NewReader(make([]byte, MaxUserDataAttachmentSize+1)))
+	assert.NotNil(t, err)
+}
+
+func TestEncodeUserData(t *testing.T) {
+	encoded, err := EncodeUserData(strings.NewReader("hello"), 5)
+	assert.Nil(t, err)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("hello")), encoded)
+
+	_, err = EncodeUserData(strings.NewReader("hello!"), 5)
+	assert.NotNil(t, err)
+}
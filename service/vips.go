@@ -172,10 +172,15 @@ func (s *VIPService) DescribeVxNetsVIPs(i *DescribeVxNetsVIPsInput) (*DescribeVx
 }
 
 type DescribeVxNetsVIPsInput struct {
-	Limit   *int      `json:"limit" name:"limit" default:"20" location:"params"`
-	Offset  *int      `json:"offset" name:"offset" default:"0" location:"params"`
-	VIPName *string   `json:"vip_name" name:"vip_name" location:"params"`
-	VxNets  []*string `json:"vxnets" name:"vxnets" location:"elements"` // Required
+	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
+	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
+	VIPName    *string   `json:"vip_name" name:"vip_name" location:"params"`
+	VxNets     []*string `json:"vxnets" name:"vxnets" location:"elements"` // Required
 }
 
 func (v *DescribeVxNetsVIPsInput) Validate() error {
@@ -197,3 +202,211 @@ type DescribeVxNetsVIPsOutput struct {
 	RetCode    *int    `json:"ret_code" name:"ret_code" location:"elements"`
 	TotalCount *int    `json:"total_count" name:"total_count" location:"elements"`
 }
+
+func (s *VIPService) AssociateVIP(i *AssociateVIPInput) (*AssociateVIPOutput, error) {
+	if i == nil {
+		i = &AssociateVIPInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "AssociateVip",
+		RequestMethod: "GET",
+	}
+
+	x := &AssociateVIPOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type AssociateVIPInput struct {
+	Instance *string `json:"instance" name:"instance" location:"params"`
+	NIC      *string `json:"nic" name:"nic" location:"params"`
+	VIP      *string `json:"vip" name:"vip" location:"params"` // Required
+}
+
+func (v *AssociateVIPInput) Validate() error {
+
+	if v.VIP == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "VIP",
+			ParentName:    "AssociateVIPInput",
+		}
+	}
+
+	if v.Instance == nil && v.NIC == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "Instance",
+			ParentName:    "AssociateVIPInput",
+		}
+	}
+
+	return nil
+}
+
+type AssociateVIPOutput struct {
+	Message *string `json:"message" name:"message"`
+	Action  *string `json:"action" name:"action" location:"elements"`
+	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
+	JobID   *string `json:"job_id" name:"job_id" location:"elements"`
+}
+
+func (s *VIPService) DescribeVIPs(i *DescribeVIPsInput) (*DescribeVIPsOutput, error) {
+	if i == nil {
+		i = &DescribeVIPsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "DescribeVips",
+		RequestMethod: "GET",
+	}
+
+	x := &DescribeVIPsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type DescribeVIPsInput struct {
+	Console    *string   `json:"console" name:"console" location:"params"`
+	Instances  []*string `json:"instances" name:"instances" location:"params"`
+	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
+	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
+	VIPAddrs   []*string `json:"vip_addrs" name:"vip_addrs" location:"params"`
+	VIPName    *string   `json:"vip_name" name:"vip_name" location:"params"`
+	VIPs       []*string `json:"vips" name:"vips" location:"params"`
+	VxNets     []*string `json:"vxnets" name:"vxnets" location:"params"`
+}
+
+func (v *DescribeVIPsInput) Validate() error {
+
+	return nil
+}
+
+type DescribeVIPsOutput struct {
+	Message    *string `json:"message" name:"message"`
+	Action     *string `json:"action" name:"action" location:"elements"`
+	RetCode    *int    `json:"ret_code" name:"ret_code" location:"elements"`
+	VIPSet     []*VIP  `json:"vip_set" name:"vip_set" location:"elements"`
+	TotalCount *int    `json:"total_count" name:"total_count" location:"elements"`
+}
+
+func (s *VIPService) DissociateVIPs(i *DissociateVIPsInput) (*DissociateVIPsOutput, error) {
+	if i == nil {
+		i = &DissociateVIPsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "DissociateVips",
+		RequestMethod: "GET",
+	}
+
+	x := &DissociateVIPsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type DissociateVIPsInput struct {
+	VIPs []*string `json:"vips" name:"vips" location:"params"` // Required
+}
+
+func (v *DissociateVIPsInput) Validate() error {
+
+	if len(v.VIPs) == 0 {
+		return errors.ParameterRequiredError{
+			ParameterName: "VIPs",
+			ParentName:    "DissociateVIPsInput",
+		}
+	}
+
+	return nil
+}
+
+type DissociateVIPsOutput struct {
+	Message *string `json:"message" name:"message"`
+	Action  *string `json:"action" name:"action" location:"elements"`
+	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
+	JobID   *string `json:"job_id" name:"job_id" location:"elements"`
+}
+
+func (s *VIPService) ModifyVIPAttributes(i *ModifyVIPAttributesInput) (*ModifyVIPAttributesOutput, error) {
+	if i == nil {
+		i = &ModifyVIPAttributesInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "ModifyVipAttributes",
+		RequestMethod: "GET",
+	}
+
+	x := &ModifyVIPAttributesOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type ModifyVIPAttributesInput struct {
+	Description *string `json:"description" name:"description" location:"params"`
+	VIP         *string `json:"vip" name:"vip" location:"params"` // Required
+	VIPName     *string `json:"vip_name" name:"vip_name" location:"params"`
+}
+
+func (v *ModifyVIPAttributesInput) Validate() error {
+
+	if v.VIP == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "VIP",
+			ParentName:    "ModifyVIPAttributesInput",
+		}
+	}
+
+	return nil
+}
+
+type ModifyVIPAttributesOutput struct {
+	Message *string `json:"message" name:"message"`
+	Action  *string `json:"action" name:"action" location:"elements"`
+	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
+}
@@ -378,6 +378,8 @@ func (s *VolumeService) DescribeVolumes(i *DescribeVolumesInput) (*DescribeVolum
 }
 
 type DescribeVolumesInput struct {
+	Console    *string   `json:"console" name:"console" location:"params"`
+	Fields     []*string `json:"fields" name:"fields" location:"params"`
 	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner      *string   `json:"owner" name:"owner" location:"params"`
@@ -549,6 +551,7 @@ func (s *VolumeService) ModifyVolumeAttributes(i *ModifyVolumeAttributesInput) (
 
 type ModifyVolumeAttributesInput struct {
 	Description *string `json:"description" name:"description" location:"params"`
+	ResourceQos *string `json:"resource_qos" name:"resource_qos" location:"params"`
 	Volume      *string `json:"volume" name:"volume" location:"params"` // Required
 	VolumeName  *string `json:"volume_name" name:"volume_name" location:"params"`
 	// 云服务器 ID
@@ -0,0 +1,132 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Volume types, other values accepted by CreateVolumes are kept for the
+// legacy and private cloud storages.
+const (
+	VolumeTypePerformance          = 0
+	VolumeTypeHighCapacity         = 2
+	VolumeTypeSuperHighPerformance = 3
+	VolumeTypeNeonSAN              = 5
+	VolumeTypeNeonSANCapacity      = 6
+	VolumeTypeBasic                = 100
+	VolumeTypeSSDEnterprise        = 200
+)
+
+// VolumeClass describes the performance limits of a volume type. The limits
+// are informative, the sizes and QoS of the volumes are validated by the API
+// since they differ between zones.
+type VolumeClass struct {
+	Type int
+	Name string
+	// MaxIOPS and MaxThroughput (MB/s) are the upper limits of the QoS,
+	// the QoS is not supported if they are zero.
+	MaxIOPS       int
+	MaxThroughput int
+}
+
+// VolumeClasses are the known volume classes indexed by the volume type.
+var VolumeClasses = map[int]*VolumeClass{
+	VolumeTypePerformance:          {Type: VolumeTypePerformance, Name: "performance"},
+	VolumeTypeHighCapacity:         {Type: VolumeTypeHighCapacity, Name: "high capacity"},
+	VolumeTypeSuperHighPerformance: {Type: VolumeTypeSuperHighPerformance, Name: "super high performance"},
+	VolumeTypeNeonSAN:              {Type: VolumeTypeNeonSAN, Name: "NeonSAN", MaxIOPS: 50000, MaxThroughput: 1000},
+	VolumeTypeNeonSANCapacity:      {Type: VolumeTypeNeonSANCapacity, Name: "NeonSAN capacity", MaxIOPS: 10000, MaxThroughput: 500},
+	VolumeTypeBasic:                {Type: VolumeTypeBasic, Name: "basic"},
+	VolumeTypeSSDEnterprise:        {Type: VolumeTypeSSDEnterprise, Name: "SSD enterprise", MaxIOPS: 30000, MaxThroughput: 350},
+}
+
+// SupportsQoS checks whether the IOPS and throughput of the volumes of this
+// class can be limited.
+func (v *VolumeClass) SupportsQoS() bool {
+	return v.MaxIOPS > 0 || v.MaxThroughput > 0
+}
+
+// VolumeQoS is the typed form of the "resource_qos" parameter, the zero
+// values mean no limit.
+type VolumeQoS struct {
+	IOPS int `json:"iops,omitempty"`
+	// Throughput is in MB/s.
+	Throughput int `json:"throughput,omitempty"`
+}
+
+// ParseVolumeQoS parses the "resource_qos" of the volume.
+func ParseVolumeQoS(value string) (*VolumeQoS, error) {
+	qos := &VolumeQoS{}
+	if value == "" {
+		return qos, nil
+	}
+	if err := json.Unmarshal([]byte(value), qos); err != nil {
+		return nil, fmt.Errorf(`"ResourceQos" is invalid, got "%s"`, value)
+	}
+	return qos, nil
+}
+
+// String encodes the VolumeQoS as the "resource_qos" parameter.
+func (v *VolumeQoS) String() string {
+	content, _ := json.Marshal(v)
+	return string(content)
+}
+
+// Validate validates the VolumeQoS, its limits are validated against the
+// volume type by the API.
+func (v *VolumeQoS) Validate() error {
+	if v.IOPS < 0 || v.Throughput < 0 {
+		return fmt.Errorf(`"IOPS" and "Throughput" of VolumeQoS should not be negative`)
+	}
+	return nil
+}
+
+// ApplyTo validates the QoS and sets the "resource_qos" of the CreateVolumesInput.
+func (v *VolumeQoS) ApplyTo(input *CreateVolumesInput) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	input.ResourceQos = String(v.String())
+	return nil
+}
+
+// ApplyToModify validates the QoS and sets the "resource_qos" of the ModifyVolumeAttributesInput.
+func (v *VolumeQoS) ApplyToModify(input *ModifyVolumeAttributesInput) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	input.ResourceQos = String(v.String())
+	return nil
+}
+
+// QoS returns the QoS of the volume, it is nil if the volume has no limit.
+func (v *Volume) QoS() (*VolumeQoS, error) {
+	if StringValue(v.ResourceQos) == "" {
+		return nil, nil
+	}
+	return ParseVolumeQoS(*v.ResourceQos)
+}
+
+// Class returns the class of the volume, it is nil for unknown volume types.
+func (v *Volume) Class() *VolumeClass {
+	if v.VolumeType == nil {
+		return nil
+	}
+	return VolumeClasses[*v.VolumeType]
+}
@@ -0,0 +1,55 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolumeQoSApplyTo(t *testing.T) {
+	input := &CreateVolumesInput{Size: Int(150), VolumeType: Int(VolumeTypeNeonSAN)}
+	qos := &VolumeQoS{IOPS: 8000, Throughput: 200}
+	assert.Nil(t, qos.ApplyTo(input))
+	assert.Equal(t, `{"iops":8000,"throughput":200}`, StringValue(input.ResourceQos))
+	assert.Nil(t, input.Validate())
+	assert.NotNil(t, (&VolumeQoS{IOPS: -1}).ApplyTo(&CreateVolumesInput{}))
+
+	modify := &ModifyVolumeAttributesInput{Volume: String("vol-1")}
+	assert.Nil(t, (&VolumeQoS{IOPS: 3000}).ApplyToModify(modify))
+	assert.Equal(t, `{"iops":3000}`, StringValue(modify.ResourceQos))
+	assert.NotNil(t, (&VolumeQoS{Throughput: -1}).ApplyToModify(modify))
+	assert.Equal(t, `{"iops":3000}`, StringValue(modify.ResourceQos))
+}
+
+func TestVolumeQoS(t *testing.T) {
+	volume := &Volume{VolumeType: Int(VolumeTypeSSDEnterprise), ResourceQos: String(`{"iops":3000}`)}
+	qos, err := volume.QoS()
+	assert.Nil(t, err)
+	assert.Equal(t, 3000, qos.IOPS)
+	assert.Equal(t, 0, qos.Throughput)
+	assert.True(t, volume.Class().SupportsQoS())
+
+	qos, err = (&Volume{}).QoS()
+	assert.Nil(t, err)
+	assert.Nil(t, qos)
+	assert.Nil(t, (&Volume{}).Class())
+
+	_, err = ParseVolumeQoS("iops=3000")
+	assert.NotNil(t, err)
+}
@@ -353,14 +353,20 @@ type DescribeBorderStaticsInput struct {
 	Border *string `json:"border" name:"border" location:"params"`
 	// the comma separated IDs of border_statics you want to list.
 	BorderStatics []*string `json:"border_statics" name:"border_statics" location:"params"`
+	// filter by console.
+	Console *string `json:"console" name:"console" location:"params"`
 	// specify the number of the returning results.
 	Limit *int `json:"limit" name:"limit" location:"params"`
 	// the starting offset of the returning results.
 	Offset *int `json:"offset" name:"offset" location:"params"`
 	// filter by owner
-	Owner *string `json:"owner" name:"owner" location:"params"`
+	Owner      *string `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string `json:"project_id" name:"project_id" location:"params"`
+	SearchWord *string `json:"search_word" name:"search_word" location:"params"`
 	// a list of static type. 0: route.
 	StaticType []*string `json:"static_type" name:"static_type" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	// the number to specify the verbose level
 	Verbose *int `json:"verbose" name:"verbose" location:"params"`
 }
@@ -405,13 +411,19 @@ func (s *VpcBorderService) DescribeBorderVxNets(i *DescribeBorderVxNetsInput) (*
 
 type DescribeBorderVxNetsInput struct {
 	Border *string `json:"border" name:"border" location:"params"`
+	// filter by console.
+	Console *string `json:"console" name:"console" location:"params"`
 	// specify the number of the returning results.
 	Limit *int `json:"limit" name:"limit" location:"params"`
 	// the starting offset of the returning results.
 	Offset *int `json:"offset" name:"offset" location:"params"`
 	// filter by owner.
-	Owner *string `json:"owner" name:"owner" location:"params"`
-	VxNet *string `json:"vxnet" name:"vxnet" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
+	VxNet      *string   `json:"vxnet" name:"vxnet" location:"params"`
 }
 
 func (v *DescribeBorderVxNetsInput) Validate() error {
@@ -456,13 +468,18 @@ func (s *VpcBorderService) DescribeVpcBorders(i *DescribeVpcBordersInput) (*Desc
 type DescribeVpcBordersInput struct {
 	BorderName *string   `json:"border_name" name:"border_name" location:"params"`
 	BorderType *string   `json:"border_type" name:"border_type" location:"params"`
+	Console    *string   `json:"console" name:"console" location:"params"`
 	L3vni      *int      `json:"l3vni" name:"l3vni" location:"params"`
 	Limit      *int      `json:"limit" name:"limit" location:"params"`
 	Offset     *int      `json:"offset" name:"offset" location:"params"`
 	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
 	RouterID   *string   `json:"router_id" name:"router_id" location:"params"`
 	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
 	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	Verbose    *int      `json:"verbose" name:"verbose" location:"params"`
 	VpcBorders []*string `json:"vpc_borders" name:"vpc_borders" location:"params"`
 }
@@ -200,7 +200,11 @@ type DescribeVxNetInstancesInput struct {
 	Instances    []*string `json:"instances" name:"instances" location:"params"`
 	Limit        *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset       *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner        *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID    *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord   *string   `json:"search_word" name:"search_word" location:"params"`
 	Status       *string   `json:"status" name:"status" location:"params"`
+	Tags         []*string `json:"tags" name:"tags" location:"params"`
 	VxNet        *string   `json:"vxnet" name:"vxnet" location:"params"` // Required
 }
 
@@ -251,11 +255,16 @@ func (s *VxNetService) DescribeVxNets(i *DescribeVxNetsInput) (*DescribeVxNetsOu
 }
 
 type DescribeVxNetsInput struct {
+	Console    *string   `json:"console" name:"console" location:"params"`
+	Fields     []*string `json:"fields" name:"fields" location:"params"`
 	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
 	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
 	Owner      *string   `json:"owner" name:"owner" location:"params"`
 	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
 	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
 	Tags       []*string `json:"tags" name:"tags" location:"params"`
 	// Verbose's available values: 0, 1
 	Verbose *int `json:"verbose" name:"verbose" default:"0" location:"params"`
@@ -0,0 +1,719 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yunify/qingcloud-sdk-go/config"
+	"github.com/yunify/qingcloud-sdk-go/request"
+	"github.com/yunify/qingcloud-sdk-go/request/data"
+	"github.com/yunify/qingcloud-sdk-go/request/errors"
+)
+
+var _ fmt.State
+var _ time.Time
+
+type WAFService struct {
+	Config     *config.Config
+	Properties *WAFServiceProperties
+}
+
+type WAFServiceProperties struct {
+	// QingCloud Zone ID
+	Zone *string `json:"zone" name:"zone"` // Required
+}
+
+func (s *QingCloudService) WAF(zone string) (*WAFService, error) {
+	properties := &WAFServiceProperties{
+		Zone: &zone,
+	}
+
+	return &WAFService{Config: s.Config, Properties: properties}, nil
+}
+
+// Documentation URL: https://docs.qingcloud.com/api/waf/add_waf_domains.html
+func (s *WAFService) AddWAFDomains(i *AddWAFDomainsInput) (*AddWAFDomainsOutput, error) {
+	if i == nil {
+		i = &AddWAFDomainsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "AddWafDomains",
+		RequestMethod: "GET",
+	}
+
+	x := &AddWAFDomainsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type AddWAFDomainsInput struct {
+	Domains []*WAFDomain `json:"domains" name:"domains" location:"params"` // Required
+	WAF     *string      `json:"waf" name:"waf" location:"params"`         // Required
+}
+
+func (v *AddWAFDomainsInput) Validate() error {
+
+	if len(v.Domains) == 0 {
+		return errors.ParameterRequiredError{
+			ParameterName: "Domains",
+			ParentName:    "AddWAFDomainsInput",
+		}
+	}
+
+	if len(v.Domains) > 0 {
+		for _, property := range v.Domains {
+			if err := property.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if v.WAF == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "WAF",
+			ParentName:    "AddWAFDomainsInput",
+		}
+	}
+
+	return nil
+}
+
+type AddWAFDomainsOutput struct {
+	Message    *string   `json:"message" name:"message"`
+	Action     *string   `json:"action" name:"action" location:"elements"`
+	RetCode    *int      `json:"ret_code" name:"ret_code" location:"elements"`
+	WAFDomains []*string `json:"waf_domains" name:"waf_domains" location:"elements"`
+}
+
+// Documentation URL: https://docs.qingcloud.com/api/waf/apply_waf_rule_set.html
+func (s *WAFService) ApplyWAFRuleSet(i *ApplyWAFRuleSetInput) (*ApplyWAFRuleSetOutput, error) {
+	if i == nil {
+		i = &ApplyWAFRuleSetInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "ApplyWafRuleSet",
+		RequestMethod: "GET",
+	}
+
+	x := &ApplyWAFRuleSetOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type ApplyWAFRuleSetInput struct {
+	RuleSet    *string   `json:"rule_set" name:"rule_set" location:"params"`       // Required
+	WAFDomains []*string `json:"waf_domains" name:"waf_domains" location:"params"` // Required
+}
+
+func (v *ApplyWAFRuleSetInput) Validate() error {
+
+	if v.RuleSet == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "RuleSet",
+			ParentName:    "ApplyWAFRuleSetInput",
+		}
+	}
+
+	if len(v.WAFDomains) == 0 {
+		return errors.ParameterRequiredError{
+			ParameterName: "WAFDomains",
+			ParentName:    "ApplyWAFRuleSetInput",
+		}
+	}
+
+	return nil
+}
+
+type ApplyWAFRuleSetOutput struct {
+	Message *string `json:"message" name:"message"`
+	Action  *string `json:"action" name:"action" location:"elements"`
+	JobID   *string `json:"job_id" name:"job_id" location:"elements"`
+	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
+}
+
+// Documentation URL: https://docs.qingcloud.com/api/waf/create_waf.html
+func (s *WAFService) CreateWAF(i *CreateWAFInput) (*CreateWAFOutput, error) {
+	if i == nil {
+		i = &CreateWAFInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "CreateWaf",
+		RequestMethod: "GET",
+	}
+
+	x := &CreateWAFOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type CreateWAFInput struct {
+	Description  *string `json:"description" name:"description" location:"params"`
+	LoadBalancer *string `json:"loadbalancer" name:"loadbalancer" location:"params"` // Required
+	WAFName      *string `json:"waf_name" name:"waf_name" location:"params"`
+}
+
+func (v *CreateWAFInput) Validate() error {
+
+	if v.LoadBalancer == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "LoadBalancer",
+			ParentName:    "CreateWAFInput",
+		}
+	}
+
+	return nil
+}
+
+type CreateWAFOutput struct {
+	Message *string `json:"message" name:"message"`
+	Action  *string `json:"action" name:"action" location:"elements"`
+	JobID   *string `json:"job_id" name:"job_id" location:"elements"`
+	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
+	WAFID   *string `json:"waf_id" name:"waf_id" location:"elements"`
+}
+
+// Documentation URL: https://docs.qingcloud.com/api/waf/create_waf_rule_set.html
+func (s *WAFService) CreateWAFRuleSet(i *CreateWAFRuleSetInput) (*CreateWAFRuleSetOutput, error) {
+	if i == nil {
+		i = &CreateWAFRuleSetInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "CreateWafRuleSet",
+		RequestMethod: "GET",
+	}
+
+	x := &CreateWAFRuleSetOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type CreateWAFRuleSetInput struct {
+	Description *string    `json:"description" name:"description" location:"params"`
+	Rules       []*WAFRule `json:"rules" name:"rules" location:"params"`
+	RuleSetName *string    `json:"rule_set_name" name:"rule_set_name" location:"params"` // Required
+}
+
+func (v *CreateWAFRuleSetInput) Validate() error {
+
+	if len(v.Rules) > 0 {
+		for _, property := range v.Rules {
+			if err := property.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if v.RuleSetName == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "RuleSetName",
+			ParentName:    "CreateWAFRuleSetInput",
+		}
+	}
+
+	return nil
+}
+
+type CreateWAFRuleSetOutput struct {
+	Message   *string `json:"message" name:"message"`
+	Action    *string `json:"action" name:"action" location:"elements"`
+	RetCode   *int    `json:"ret_code" name:"ret_code" location:"elements"`
+	RuleSetID *string `json:"rule_set_id" name:"rule_set_id" location:"elements"`
+}
+
+// Documentation URL: https://docs.qingcloud.com/api/waf/delete_waf_domains.html
+func (s *WAFService) DeleteWAFDomains(i *DeleteWAFDomainsInput) (*DeleteWAFDomainsOutput, error) {
+	if i == nil {
+		i = &DeleteWAFDomainsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "DeleteWafDomains",
+		RequestMethod: "GET",
+	}
+
+	x := &DeleteWAFDomainsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type DeleteWAFDomainsInput struct {
+	WAFDomains []*string `json:"waf_domains" name:"waf_domains" location:"params"` // Required
+}
+
+func (v *DeleteWAFDomainsInput) Validate() error {
+
+	if len(v.WAFDomains) == 0 {
+		return errors.ParameterRequiredError{
+			ParameterName: "WAFDomains",
+			ParentName:    "DeleteWAFDomainsInput",
+		}
+	}
+
+	return nil
+}
+
+type DeleteWAFDomainsOutput struct {
+	Message    *string   `json:"message" name:"message"`
+	Action     *string   `json:"action" name:"action" location:"elements"`
+	RetCode    *int      `json:"ret_code" name:"ret_code" location:"elements"`
+	WAFDomains []*string `json:"waf_domains" name:"waf_domains" location:"elements"`
+}
+
+// Documentation URL: https://docs.qingcloud.com/api/waf/delete_waf_rule_sets.html
+func (s *WAFService) DeleteWAFRuleSets(i *DeleteWAFRuleSetsInput) (*DeleteWAFRuleSetsOutput, error) {
+	if i == nil {
+		i = &DeleteWAFRuleSetsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "DeleteWafRuleSets",
+		RequestMethod: "GET",
+	}
+
+	x := &DeleteWAFRuleSetsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type DeleteWAFRuleSetsInput struct {
+	RuleSets []*string `json:"rule_sets" name:"rule_sets" location:"params"` // Required
+}
+
+func (v *DeleteWAFRuleSetsInput) Validate() error {
+
+	if len(v.RuleSets) == 0 {
+		return errors.ParameterRequiredError{
+			ParameterName: "RuleSets",
+			ParentName:    "DeleteWAFRuleSetsInput",
+		}
+	}
+
+	return nil
+}
+
+type DeleteWAFRuleSetsOutput struct {
+	Message  *string   `json:"message" name:"message"`
+	Action   *string   `json:"action" name:"action" location:"elements"`
+	RetCode  *int      `json:"ret_code" name:"ret_code" location:"elements"`
+	RuleSets []*string `json:"rule_sets" name:"rule_sets" location:"elements"`
+}
+
+// Documentation URL: https://docs.qingcloud.com/api/waf/delete_wafs.html
+func (s *WAFService) DeleteWAFs(i *DeleteWAFsInput) (*DeleteWAFsOutput, error) {
+	if i == nil {
+		i = &DeleteWAFsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "DeleteWafs",
+		RequestMethod: "GET",
+	}
+
+	x := &DeleteWAFsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type DeleteWAFsInput struct {
+	WAFs []*string `json:"wafs" name:"wafs" location:"params"` // Required
+}
+
+func (v *DeleteWAFsInput) Validate() error {
+
+	if len(v.WAFs) == 0 {
+		return errors.ParameterRequiredError{
+			ParameterName: "WAFs",
+			ParentName:    "DeleteWAFsInput",
+		}
+	}
+
+	return nil
+}
+
+type DeleteWAFsOutput struct {
+	Message *string `json:"message" name:"message"`
+	Action  *string `json:"action" name:"action" location:"elements"`
+	JobID   *string `json:"job_id" name:"job_id" location:"elements"`
+	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
+}
+
+// Documentation URL: https://docs.qingcloud.com/api/waf/describe_waf_attack_logs.html
+func (s *WAFService) DescribeWAFAttackLogs(i *DescribeWAFAttackLogsInput) (*DescribeWAFAttackLogsOutput, error) {
+	if i == nil {
+		i = &DescribeWAFAttackLogsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "DescribeWafAttackLogs",
+		RequestMethod: "GET",
+	}
+
+	x := &DescribeWAFAttackLogsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type DescribeWAFAttackLogsInput struct {
+	Category   *string    `json:"category" name:"category" location:"params"`
+	ClientIP   *string    `json:"client_ip" name:"client_ip" location:"params"`
+	Domain     *string    `json:"domain" name:"domain" location:"params"`
+	EndTime    *time.Time `json:"end_time" name:"end_time" format:"ISO 8601" location:"params"`
+	Limit      *int       `json:"limit" name:"limit" default:"20" location:"params"`
+	Offset     *int       `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string    `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string    `json:"project_id" name:"project_id" location:"params"`
+	SearchWord *string    `json:"search_word" name:"search_word" location:"params"`
+	StartTime  *time.Time `json:"start_time" name:"start_time" format:"ISO 8601" location:"params"`
+	Status     []*string  `json:"status" name:"status" location:"params"`
+	Tags       []*string  `json:"tags" name:"tags" location:"params"`
+	WAF        *string    `json:"waf" name:"waf" location:"params"` // Required
+}
+
+func (v *DescribeWAFAttackLogsInput) Validate() error {
+
+	if v.WAF == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "WAF",
+			ParentName:    "DescribeWAFAttackLogsInput",
+		}
+	}
+
+	return nil
+}
+
+type DescribeWAFAttackLogsOutput struct {
+	Message      *string         `json:"message" name:"message"`
+	Action       *string         `json:"action" name:"action" location:"elements"`
+	AttackLogSet []*WAFAttackLog `json:"attack_log_set" name:"attack_log_set" location:"elements"`
+	RetCode      *int            `json:"ret_code" name:"ret_code" location:"elements"`
+	TotalCount   *int            `json:"total_count" name:"total_count" location:"elements"`
+}
+
+// Documentation URL: https://docs.qingcloud.com/api/waf/describe_waf_domains.html
+func (s *WAFService) DescribeWAFDomains(i *DescribeWAFDomainsInput) (*DescribeWAFDomainsOutput, error) {
+	if i == nil {
+		i = &DescribeWAFDomainsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "DescribeWafDomains",
+		RequestMethod: "GET",
+	}
+
+	x := &DescribeWAFDomainsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type DescribeWAFDomainsInput struct {
+	Console    *string   `json:"console" name:"console" location:"params"`
+	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
+	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
+	Verbose    *int      `json:"verbose" name:"verbose" location:"params"`
+	WAF        *string   `json:"waf" name:"waf" location:"params"`
+	WAFDomains []*string `json:"waf_domains" name:"waf_domains" location:"params"`
+}
+
+func (v *DescribeWAFDomainsInput) Validate() error {
+
+	return nil
+}
+
+type DescribeWAFDomainsOutput struct {
+	Message      *string      `json:"message" name:"message"`
+	Action       *string      `json:"action" name:"action" location:"elements"`
+	RetCode      *int         `json:"ret_code" name:"ret_code" location:"elements"`
+	TotalCount   *int         `json:"total_count" name:"total_count" location:"elements"`
+	WAFDomainSet []*WAFDomain `json:"waf_domain_set" name:"waf_domain_set" location:"elements"`
+}
+
+// Documentation URL: https://docs.qingcloud.com/api/waf/describe_waf_rule_sets.html
+func (s *WAFService) DescribeWAFRuleSets(i *DescribeWAFRuleSetsInput) (*DescribeWAFRuleSetsOutput, error) {
+	if i == nil {
+		i = &DescribeWAFRuleSetsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "DescribeWafRuleSets",
+		RequestMethod: "GET",
+	}
+
+	x := &DescribeWAFRuleSetsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type DescribeWAFRuleSetsInput struct {
+	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
+	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	RuleSets   []*string `json:"rule_sets" name:"rule_sets" location:"params"`
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
+	Verbose    *int      `json:"verbose" name:"verbose" default:"0" location:"params"`
+}
+
+func (v *DescribeWAFRuleSetsInput) Validate() error {
+
+	return nil
+}
+
+type DescribeWAFRuleSetsOutput struct {
+	Message    *string       `json:"message" name:"message"`
+	Action     *string       `json:"action" name:"action" location:"elements"`
+	RetCode    *int          `json:"ret_code" name:"ret_code" location:"elements"`
+	RuleSetSet []*WAFRuleSet `json:"rule_set_set" name:"rule_set_set" location:"elements"`
+	TotalCount *int          `json:"total_count" name:"total_count" location:"elements"`
+}
+
+// Documentation URL: https://docs.qingcloud.com/api/waf/describe_wafs.html
+func (s *WAFService) DescribeWAFs(i *DescribeWAFsInput) (*DescribeWAFsOutput, error) {
+	if i == nil {
+		i = &DescribeWAFsInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "DescribeWafs",
+		RequestMethod: "GET",
+	}
+
+	x := &DescribeWAFsOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type DescribeWAFsInput struct {
+	Console    *string   `json:"console" name:"console" location:"params"`
+	Limit      *int      `json:"limit" name:"limit" default:"20" location:"params"`
+	Offset     *int      `json:"offset" name:"offset" default:"0" location:"params"`
+	Owner      *string   `json:"owner" name:"owner" location:"params"`
+	ProjectID  *string   `json:"project_id" name:"project_id" location:"params"`
+	Reverse    *int      `json:"reverse" name:"reverse" location:"params"`
+	SearchWord *string   `json:"search_word" name:"search_word" location:"params"`
+	SortKey    *string   `json:"sort_key" name:"sort_key" location:"params"`
+	Status     []*string `json:"status" name:"status" location:"params"`
+	Tags       []*string `json:"tags" name:"tags" location:"params"`
+	Verbose    *int      `json:"verbose" name:"verbose" default:"0" location:"params"`
+	WAFs       []*string `json:"wafs" name:"wafs" location:"params"`
+}
+
+func (v *DescribeWAFsInput) Validate() error {
+
+	return nil
+}
+
+type DescribeWAFsOutput struct {
+	Message    *string `json:"message" name:"message"`
+	Action     *string `json:"action" name:"action" location:"elements"`
+	RetCode    *int    `json:"ret_code" name:"ret_code" location:"elements"`
+	TotalCount *int    `json:"total_count" name:"total_count" location:"elements"`
+	WAFSet     []*WAF  `json:"waf_set" name:"waf_set" location:"elements"`
+}
+
+// Documentation URL: https://docs.qingcloud.com/api/waf/modify_waf_domain_attributes.html
+func (s *WAFService) ModifyWAFDomainAttributes(i *ModifyWAFDomainAttributesInput) (*ModifyWAFDomainAttributesOutput, error) {
+	if i == nil {
+		i = &ModifyWAFDomainAttributesInput{}
+	}
+	o := &data.Operation{
+		Config:        s.Config,
+		Properties:    s.Properties,
+		APIName:       "ModifyWafDomainAttributes",
+		RequestMethod: "GET",
+	}
+
+	x := &ModifyWAFDomainAttributesOutput{}
+	r, err := request.New(o, i, x)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Send()
+	if err != nil {
+		return nil, err
+	}
+
+	return x, err
+}
+
+type ModifyWAFDomainAttributesInput struct {
+	Backend *string `json:"backend" name:"backend" location:"params"`
+	// Mode's available values: block, monitor, off
+	Mode      *string `json:"mode" name:"mode" location:"params"`
+	WAFDomain *string `json:"waf_domain" name:"waf_domain" location:"params"` // Required
+}
+
+func (v *ModifyWAFDomainAttributesInput) Validate() error {
+
+	if v.Mode != nil {
+		modeValidValues := []string{"block", "monitor", "off"}
+		modeParameterValue := fmt.Sprint(*v.Mode)
+
+		modeIsValid := false
+		for _, value := range modeValidValues {
+			if value == modeParameterValue {
+				modeIsValid = true
+			}
+		}
+
+		if !modeIsValid {
+			return errors.ParameterValueNotAllowedError{
+				ParameterName:  "Mode",
+				ParameterValue: modeParameterValue,
+				AllowedValues:  modeValidValues,
+			}
+		}
+	}
+
+	if v.WAFDomain == nil {
+		return errors.ParameterRequiredError{
+			ParameterName: "WAFDomain",
+			ParentName:    "ModifyWAFDomainAttributesInput",
+		}
+	}
+
+	return nil
+}
+
+type ModifyWAFDomainAttributesOutput struct {
+	Message *string `json:"message" name:"message"`
+	Action  *string `json:"action" name:"action" location:"elements"`
+	RetCode *int    `json:"ret_code" name:"ret_code" location:"elements"`
+}
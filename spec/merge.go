@@ -0,0 +1,426 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+//go:build ignore
+// +build ignore
+
+// merge applies the overlays in this directory to the upstream swagger specs
+// before the service code is generated from them. An overlay declares the
+// operations and definitions the SDK relies on but the upstream specs lack:
+//
+//	{
+//	  "operations": {
+//	    "<operationId>": {
+//	      "service": "<tag of a new operation>",
+//	      "method": "<request method of a new operation>",
+//	      "summary": "...",
+//	      "externalDocs": {"url": "..."},
+//	      "parameters": [<swagger parameter>, ...],
+//	      "response": {"<property>": <swagger schema>, ...}
+//	    }
+//	  },
+//	  "definitions": {
+//	    "<name>": {"properties": {...}, "required": [...]}
+//	  }
+//	}
+//
+// Parameters replace the upstream ones of the same name, response properties
+// and definition properties are merged into the upstream schemas, and missing
+// operations and definitions are added.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var (
+	upstreamFile = flag.String("f", "", "the upstream swagger specs")
+	overlayDir   = flag.String("d", ".", "the directory of the overlays")
+	outputFile   = flag.String("o", "", "the merged swagger specs")
+)
+
+var methods = []string{"get", "post", "put", "patch", "delete"}
+
+type overlay struct {
+	Operations  map[string]*operation  `json:"operations"`
+	Definitions map[string]*definition `json:"definitions"`
+}
+
+type operation struct {
+	Service      string                   `json:"service"`
+	Method       string                   `json:"method"`
+	Summary      string                   `json:"summary"`
+	ExternalDocs map[string]interface{}   `json:"externalDocs"`
+	Parameters   []map[string]interface{} `json:"parameters"`
+	Response     map[string]interface{}   `json:"response"`
+}
+
+type definition struct {
+	Properties map[string]interface{} `json:"properties"`
+	Required   []string               `json:"required"`
+}
+
+// upstreamOperation is an operation of the upstream specs.
+type upstreamOperation struct {
+	ID     string
+	Path   string
+	Method string
+	Value  map[string]interface{}
+}
+
+type specs struct {
+	Root        map[string]interface{}
+	Paths       map[string]interface{}
+	Definitions map[string]interface{}
+	Operations  map[string]*upstreamOperation
+}
+
+func main() {
+	flag.Parse()
+	if *upstreamFile == "" || *outputFile == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	s, err := loadSpecs(*upstreamFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(*overlayDir, "*.json"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		o := &overlay{}
+		if err := readJSON(file, o); err != nil {
+			log.Fatalf("%s: %s", file, err)
+		}
+		// Definitions go first so that the operations refer to the merged ones.
+		for _, name := range sortedKeys(o.Definitions) {
+			s.mergeDefinition(name, o.Definitions[name])
+		}
+		for _, id := range sortedKeys(o.Operations) {
+			if err := s.mergeOperation(id, o.Operations[id]); err != nil {
+				log.Fatalf("%s: %s", file, err)
+			}
+		}
+	}
+
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(s.Root); err != nil {
+		log.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(*outputFile), 0755); err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*outputFile, buffer.Bytes(), 0644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("merged %d overlays into %s\n", len(files), *outputFile)
+}
+
+func loadSpecs(file string) (*specs, error) {
+	root := map[string]interface{}{}
+	if err := readJSON(file, &root); err != nil {
+		return nil, err
+	}
+	s := &specs{
+		Root:        root,
+		Paths:       object(root, "paths"),
+		Definitions: object(root, "definitions"),
+		Operations:  map[string]*upstreamOperation{},
+	}
+	for path, item := range s.Paths {
+		item, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, method := range methods {
+			value, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			id, _ := value["operationId"].(string)
+			if id == "" {
+				continue
+			}
+			s.Operations[normalize(id)] = &upstreamOperation{ID: id, Path: path, Method: method, Value: value}
+		}
+	}
+	return s, nil
+}
+
+func (s *specs) mergeDefinition(name string, d *definition) {
+	key := s.definitionKey(name)
+	value, ok := s.Definitions[key].(map[string]interface{})
+	if !ok {
+		key, value = name, map[string]interface{}{"type": "object"}
+		s.Definitions[key] = value
+	}
+	properties := object(value, "properties")
+	for property, schema := range d.Properties {
+		properties[property] = s.resolveRefs(schema)
+	}
+	if required := mergeRequired(value["required"], d.Required); len(required) != 0 {
+		value["required"] = required
+	}
+}
+
+func (s *specs) mergeOperation(id string, o *operation) error {
+	upstream, ok := s.Operations[normalize(id)]
+	if !ok {
+		var err error
+		if upstream, err = s.addOperation(id, o); err != nil {
+			return err
+		}
+	}
+	value := upstream.Value
+	if o.Summary != "" {
+		value["summary"] = o.Summary
+	}
+	if o.ExternalDocs != nil {
+		value["externalDocs"] = o.ExternalDocs
+	}
+
+	parameters, _ := value["parameters"].([]interface{})
+	for _, parameter := range o.Parameters {
+		parameter := s.resolveRefs(parameter)
+		replaced := false
+		for i, p := range parameters {
+			if p, ok := p.(map[string]interface{}); ok && p["name"] == parameter.(map[string]interface{})["name"] {
+				parameters[i], replaced = parameter, true
+				break
+			}
+		}
+		if !replaced {
+			parameters = append(parameters, parameter)
+		}
+	}
+	value["parameters"] = parameters
+
+	if len(o.Response) != 0 {
+		schema := object(object(object(value, "responses"), "200"), "schema")
+		if ref, ok := schema["$ref"].(string); ok {
+			schema, _ = s.Definitions[strings.TrimPrefix(ref, "#/definitions/")].(map[string]interface{})
+			if schema == nil {
+				return fmt.Errorf("response of %s refers to unknown %s", id, ref)
+			}
+		}
+		properties := object(schema, "properties")
+		for property, propertySchema := range o.Response {
+			properties[property] = s.resolveRefs(propertySchema)
+		}
+	}
+	return nil
+}
+
+// addOperation adds an operation modelled on an upstream one of the same
+// service, keeping the parameters outside the query, which carry the service
+// properties, and dropping the others.
+func (s *specs) addOperation(id string, o *operation) (*upstreamOperation, error) {
+	if o.Service == "" || o.Method == "" {
+		return nil, fmt.Errorf("new operation %s requires service and method", id)
+	}
+	tag, model := s.serviceTag(o.Service)
+	if model == nil {
+		return nil, fmt.Errorf("no upstream operation to model %s on", id)
+	}
+	if !strings.Contains(model.Path, model.ID) {
+		return nil, fmt.Errorf("cannot derive the path of %s from %s", id, model.Path)
+	}
+
+	value := clone(model.Value).(map[string]interface{})
+	value["operationId"] = id
+	value["tags"] = []interface{}{tag}
+	delete(value, "summary")
+	delete(value, "description")
+	delete(value, "externalDocs")
+	parameters := []interface{}{}
+	if modelParameters, ok := value["parameters"].([]interface{}); ok {
+		for _, p := range modelParameters {
+			if p, ok := p.(map[string]interface{}); ok && p["in"] != "query" {
+				parameters = append(parameters, p)
+			}
+		}
+	}
+	value["parameters"] = parameters
+	value["responses"] = map[string]interface{}{
+		"200": map[string]interface{}{
+			"description": "OK",
+			"schema":      map[string]interface{}{"type": "object", "properties": map[string]interface{}{}},
+		},
+	}
+
+	path := strings.Replace(model.Path, model.ID, id, 1)
+	item, ok := s.Paths[path].(map[string]interface{})
+	if !ok {
+		item = map[string]interface{}{}
+		s.Paths[path] = item
+	}
+	method := strings.ToLower(o.Method)
+	item[method] = value
+
+	upstream := &upstreamOperation{ID: id, Path: path, Method: method, Value: value}
+	s.Operations[normalize(id)] = upstream
+	return upstream, nil
+}
+
+// serviceTag returns the upstream tag of the service and an upstream operation
+// to model a new one on. A service missing upstream is added as a new tag and
+// its operations are modelled on the first upstream one.
+func (s *specs) serviceTag(service string) (string, *upstreamOperation) {
+	ids := []string{}
+	for id := range s.Operations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		upstream := s.Operations[id]
+		tags, _ := upstream.Value["tags"].([]interface{})
+		for _, tag := range tags {
+			if tag, ok := tag.(string); ok && normalize(tag) == normalize(service) {
+				return tag, upstream
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return service, nil
+	}
+
+	if tags, ok := s.Root["tags"].([]interface{}); ok {
+		s.Root["tags"] = append(tags, map[string]interface{}{"name": service})
+	}
+	return service, s.Operations[ids[0]]
+}
+
+// definitionKey returns the upstream key of the definition, matching names
+// regardless of case and underscores.
+func (s *specs) definitionKey(name string) string {
+	for key := range s.Definitions {
+		if normalize(key) == normalize(name) {
+			return key
+		}
+	}
+	return name
+}
+
+// resolveRefs rewrites the definition references of the value into the
+// upstream keys of the definitions.
+func (s *specs) resolveRefs(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			if ref, ok := v.(string); ok && k == "$ref" && strings.HasPrefix(ref, "#/definitions/") {
+				value[k] = "#/definitions/" + s.definitionKey(strings.TrimPrefix(ref, "#/definitions/"))
+				continue
+			}
+			value[k] = s.resolveRefs(v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = s.resolveRefs(v)
+		}
+	}
+	return value
+}
+
+func mergeRequired(upstream interface{}, required []string) []interface{} {
+	list, _ := upstream.([]interface{})
+	for _, name := range required {
+		found := false
+		for _, v := range list {
+			if v == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, name)
+		}
+	}
+	return list
+}
+
+func object(parent map[string]interface{}, key string) map[string]interface{} {
+	value, ok := parent[key].(map[string]interface{})
+	if !ok {
+		value = map[string]interface{}{}
+		parent[key] = value
+	}
+	return value
+}
+
+func clone(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			m[k] = clone(v)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(value))
+		for i, v := range value {
+			l[i] = clone(v)
+		}
+		return l
+	}
+	return value
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.Replace(name, "_", "", -1))
+}
+
+func sortedKeys(m interface{}) []string {
+	keys := []string{}
+	switch m := m.(type) {
+	case map[string]*operation:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*definition:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func readJSON(file string, v interface{}) error {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
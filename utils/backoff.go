@@ -0,0 +1,129 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package utils
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// A Backoff computes the intervals between the attempts of polling, growing from
+// Initial by Multiplier each attempt up to Max, and randomized by Jitter.
+type Backoff struct {
+	// Initial is the interval before the first attempt.
+	Initial time.Duration
+	// Max caps the interval, 0 means no limit.
+	Max time.Duration
+	// Multiplier grows the interval each attempt, values below 1 keep it constant.
+	Multiplier float64
+	// Jitter randomizes each interval by up to the fraction of it, e.g. 0.1 for ±10%,
+	// so that many clients polling together do not hit the API at the same time.
+	Jitter float64
+}
+
+// DefaultBackoff is the Backoff polling from 1 second up to 30 seconds, doubling each attempt.
+var DefaultBackoff = Backoff{
+	Initial:    time.Second,
+	Max:        30 * time.Second,
+	Multiplier: 2,
+	Jitter:     0.1,
+}
+
+// ConstantBackoff returns the Backoff waiting the interval between every attempt.
+func ConstantBackoff(interval time.Duration) Backoff {
+	return Backoff{Initial: interval}
+}
+
+// Interval returns the time waited before the attempt, counted from 0.
+func (b Backoff) Interval(attempt int) time.Duration {
+	interval := float64(b.Initial)
+	if b.Multiplier > 1 && attempt > 0 && interval > 0 {
+		interval *= math.Pow(b.Multiplier, float64(attempt))
+	}
+	if b.Max > 0 && interval > float64(b.Max) {
+		interval = float64(b.Max)
+	}
+	// the interval grows to +Inf without Max, which time.Duration can not hold
+	if interval > math.MaxInt64 {
+		interval = math.MaxInt64
+	}
+	if b.Jitter > 0 {
+		interval += interval * b.Jitter * (2*rand.Float64() - 1)
+	}
+	if interval < 0 {
+		return 0
+	}
+	if interval >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(interval)
+}
+
+// WaitWithBackoff waits f return true or error, calling it after each interval of the backoff.
+// It returns TimeoutError once timeout passes, and the error of ctx once ctx is done.
+// The waiting is timed by DefaultClock.
+func WaitWithBackoff(ctx context.Context, f func() (bool, error), timeout time.Duration, backoff Backoff) error {
+	err := waitWithBackoff(f, timeout, backoff, ctx.Done())
+	if err == ErrWaitCanceled {
+		return ctx.Err()
+	}
+	return err
+}
+
+// WaitForResult waits f return a result with true or error, calling it after each interval of the backoff,
+// and returns the last result of f, e.g. the resource reaching the expected status, which the caller
+// asserts to its type. It returns the errors of WaitWithBackoff otherwise.
+func WaitForResult(ctx context.Context, f func() (interface{}, bool, error), timeout time.Duration, backoff Backoff) (interface{}, error) {
+	var result interface{}
+	err := WaitWithBackoff(ctx, func() (bool, error) {
+		var stop bool
+		var err error
+		result, stop, err = f()
+		return stop, err
+	}, timeout, backoff)
+	return result, err
+}
+
+func waitWithBackoff(f func() (bool, error), timeout time.Duration, backoff Backoff, done <-chan struct{}) error {
+	clock := DefaultClock
+	deadline := clock.Now().Add(timeout)
+
+	for attempt := 0; ; attempt++ {
+		wait := backoff.Interval(attempt)
+		timedOut := false
+		if remaining := deadline.Sub(clock.Now()); remaining < wait {
+			wait, timedOut = remaining, true
+		}
+		select {
+		case <-clock.After(wait):
+		case <-done:
+			return ErrWaitCanceled
+		}
+		if timedOut {
+			return NewTimeoutError(timeout)
+		}
+		stop, err := f()
+		if err != nil {
+			return err
+		}
+		if stop {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoffInterval(t *testing.T) {
+	backoff := Backoff{Initial: time.Second, Max: 5 * time.Second, Multiplier: 2}
+	assert.Equal(t, time.Second, backoff.Interval(0))
+	assert.Equal(t, 2*time.Second, backoff.Interval(1))
+	assert.Equal(t, 4*time.Second, backoff.Interval(2))
+	assert.Equal(t, 5*time.Second, backoff.Interval(3))
+	assert.Equal(t, 5*time.Second, backoff.Interval(100))
+
+	assert.Equal(t, 3*time.Second, ConstantBackoff(3*time.Second).Interval(10))
+
+	// without Max the interval overflowing time.Duration is capped at its maximum
+	backoff = Backoff{Initial: time.Second, Multiplier: 2}
+	for _, attempt := range []int{64, 2000, math.MaxInt32} {
+		assert.Equal(t, time.Duration(math.MaxInt64), backoff.Interval(attempt), attempt)
+	}
+	backoff.Jitter = 0.1
+	for i := 0; i < 100; i++ {
+		interval := backoff.Interval(2000)
+		assert.True(t, interval >= time.Duration(math.MaxInt64/10*9), interval.String())
+	}
+	assert.Equal(t, time.Duration(0), Backoff{Multiplier: 2}.Interval(2000))
+
+	backoff = Backoff{Initial: time.Second, Jitter: 0.1}
+	for i := 0; i < 100; i++ {
+		interval := backoff.Interval(i)
+		assert.True(t, interval >= 900*time.Millisecond && interval <= 1100*time.Millisecond, interval.String())
+	}
+}
+
+func TestWaitWithBackoff(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	defer SetDefaultClock(clock)()
+
+	times := 0
+	err := WaitWithBackoff(context.Background(), func() (bool, error) {
+		times++
+		return times == 4, nil
+	}, time.Minute, Backoff{Initial: time.Second, Multiplier: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, times)
+	assert.Equal(t, 15*time.Second, clock.Slept())
+
+	err = WaitWithBackoff(context.Background(), func() (bool, error) {
+		return false, errors.New("error")
+	}, time.Minute, DefaultBackoff)
+	assert.EqualError(t, err, "error")
+
+	times = 0
+	err = WaitWithBackoff(context.Background(), func() (bool, error) {
+		times++
+		return false, nil
+	}, 10*time.Second, Backoff{Initial: time.Second, Multiplier: 2})
+	assert.IsType(t, &TimeoutError{}, err)
+	assert.Equal(t, 3, times)
+}
+
+func TestWaitWithBackoffCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := WaitWithBackoff(ctx, func() (bool, error) {
+		return false, nil
+	}, time.Minute, ConstantBackoff(time.Minute))
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestWaitForResult(t *testing.T) {
+	defer SetDefaultClock(NewFakeClock(time.Unix(0, 0)))()
+
+	times := 0
+	result, err := WaitForResult(context.Background(), func() (interface{}, bool, error) {
+		times++
+		return times, times == 3, nil
+	}, time.Minute, DefaultBackoff)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result.(int))
+}
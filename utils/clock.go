@@ -0,0 +1,125 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// A Sleeper blocks the caller for a duration, like the retries waiting between attempts.
+type Sleeper interface {
+	Sleep(d time.Duration)
+}
+
+// A Clock tells the time and waits for durations, the SDK uses it in the
+// retries, waiters and token refresh so that they can be simulated in tests.
+type Clock interface {
+	Sleeper
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// RealClock is the Clock of the time package.
+type RealClock struct{}
+
+// Now returns time.Now().
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep calls time.Sleep(d).
+func (RealClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// After returns time.After(d).
+func (RealClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// DefaultClock is the Clock of the waiters and of the configs without Clock.
+var DefaultClock Clock = RealClock{}
+
+// SetDefaultClock replaces DefaultClock with the clock, e.g. a FakeClock in
+// tests, and returns the function restoring the previous one.
+// It is meant for tests only and is not safe for concurrent use, the tests
+// calling it must not run in parallel with other tests, prefer setting the
+// Clock of the config where the code under test takes it.
+func SetDefaultClock(clock Clock) (restore func()) {
+	previous := DefaultClock
+	DefaultClock = clock
+	return func() {
+		DefaultClock = previous
+	}
+}
+
+// FakeClock is a Clock which never blocks, Sleep and After advance the time
+// at once instead, so the code waiting for minutes in real time runs instantly.
+type FakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+	slept time.Duration
+}
+
+// NewFakeClock creates a FakeClock starting at now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// Advance moves the time of the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.advance(d)
+}
+
+func (c *FakeClock) advance(d time.Duration) time.Time {
+	if d > 0 {
+		c.now = c.now.Add(d)
+		c.slept += d
+	}
+	return c.now
+}
+
+// Sleep advances the time of the clock by d and returns at once.
+func (c *FakeClock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
+
+// After advances the time of the clock by d and returns a channel which is ready at once.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	ch := make(chan time.Time, 1)
+	ch <- c.advance(d)
+	return ch
+}
+
+// Slept returns the total duration the clock has been advanced by.
+func (c *FakeClock) Slept() time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.slept
+}
@@ -0,0 +1,49 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	clock.Sleep(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), clock.Now())
+	assert.Equal(t, start.Add(2*time.Minute), <-clock.After(time.Minute))
+	clock.Advance(-time.Minute)
+	assert.Equal(t, 2*time.Minute, clock.Slept())
+
+	restore := SetDefaultClock(clock)
+	times := 0
+	began := time.Now()
+	err := WaitForSpecificOrError(func() (bool, error) {
+		times++
+		return false, nil
+	}, 10*time.Minute+30*time.Second, time.Minute)
+	restore()
+	_, ok := err.(*TimeoutError)
+	assert.True(t, ok)
+	assert.Equal(t, 10, times)
+	assert.Equal(t, start.Add(2*time.Minute+10*time.Minute+30*time.Second), clock.Now())
+	assert.True(t, time.Since(began) < time.Second)
+	assert.Equal(t, RealClock{}, DefaultClock)
+}
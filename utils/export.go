@@ -0,0 +1,121 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package utils
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// RecordEncoder encodes records, like the resources of a Describe API, to a
+// stream one by one. Flush should be called after the last record.
+type RecordEncoder interface {
+	Encode(record interface{}) error
+	Flush() error
+}
+
+// CSVEncoder encodes records as CSV rows of the selected columns, the columns
+// are the keys of the records flattened by FlattenStruct, like "vxnets.0.vxnet_id".
+type CSVEncoder struct {
+	writer  *csv.Writer
+	columns []string
+	header  bool
+	records int
+}
+
+// NewCSVEncoder creates a CSVEncoder writing to w, the header row of columns
+// is written before the first record unless noHeader is set.
+func NewCSVEncoder(w io.Writer, columns []string, noHeader bool) *CSVEncoder {
+	return &CSVEncoder{
+		writer:  csv.NewWriter(w),
+		columns: columns,
+		header:  !noHeader,
+	}
+}
+
+// Encode writes the record as a CSV row, the columns absent in the record are empty.
+func (e *CSVEncoder) Encode(record interface{}) error {
+	if len(e.columns) == 0 {
+		return fmt.Errorf("no column selected for CSV")
+	}
+	flat, err := FlattenStruct(record)
+	if err != nil {
+		return err
+	}
+	if e.header && e.records == 0 {
+		if err := e.writer.Write(e.columns); err != nil {
+			return err
+		}
+	}
+	row := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		row[i] = flat[column]
+	}
+	e.records++
+	return e.writer.Write(row)
+}
+
+// Flush writes the buffered rows to the underlying writer.
+func (e *CSVEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// JSONLinesEncoder encodes records as JSON objects, one per line. If columns
+// are selected, the object only has the selected keys flattened by FlattenStruct,
+// otherwise the whole record is encoded.
+type JSONLinesEncoder struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+	columns []string
+}
+
+// NewJSONLinesEncoder creates a JSONLinesEncoder writing to w.
+func NewJSONLinesEncoder(w io.Writer, columns []string) *JSONLinesEncoder {
+	writer := bufio.NewWriter(w)
+	return &JSONLinesEncoder{
+		writer:  writer,
+		encoder: json.NewEncoder(writer),
+		columns: columns,
+	}
+}
+
+// Encode writes the record as a JSON line.
+func (e *JSONLinesEncoder) Encode(record interface{}) error {
+	if len(e.columns) == 0 {
+		return e.encoder.Encode(record)
+	}
+	flat, err := FlattenStruct(record)
+	if err != nil {
+		return err
+	}
+	selected := make(map[string]string, len(e.columns))
+	for _, column := range e.columns {
+		if value, ok := flat[column]; ok {
+			selected[column] = value
+		}
+	}
+	return e.encoder.Encode(selected)
+}
+
+// Flush writes the buffered lines to the underlying writer.
+func (e *JSONLinesEncoder) Flush() error {
+	return e.writer.Flush()
+}
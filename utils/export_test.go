@@ -0,0 +1,68 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVEncoder(t *testing.T) {
+	id, ip, name := "i-xxxxxxxx", "192.168.0.2", "web, \"prod\""
+	instances := []*flatInstance{
+		{InstanceID: &id, VxNets: []*flatVxNet{{PrivateIP: &ip}}},
+		{InstanceID: &name},
+	}
+
+	buffer := &bytes.Buffer{}
+	encoder := NewCSVEncoder(buffer, []string{"instance_id", "vxnets.0.private_ip"}, false)
+	for _, instance := range instances {
+		assert.Nil(t, encoder.Encode(instance))
+	}
+	assert.Nil(t, encoder.Flush())
+	assert.Equal(t, "instance_id,vxnets.0.private_ip\ni-xxxxxxxx,192.168.0.2\n\"web, \"\"prod\"\"\",\n", buffer.String())
+
+	buffer.Reset()
+	encoder = NewCSVEncoder(buffer, []string{"instance_id"}, true)
+	assert.Nil(t, encoder.Encode(instances[0]))
+	assert.Nil(t, encoder.Flush())
+	assert.Equal(t, "i-xxxxxxxx\n", buffer.String())
+
+	assert.NotNil(t, NewCSVEncoder(buffer, nil, false).Encode(instances[0]))
+	assert.NotNil(t, NewCSVEncoder(buffer, []string{"instance_id"}, false).Encode("i-xxxxxxxx"))
+}
+
+func TestJSONLinesEncoder(t *testing.T) {
+	id, ip := "i-xxxxxxxx", "192.168.0.2"
+	instance := &flatInstance{InstanceID: &id, VxNets: []*flatVxNet{{PrivateIP: &ip}}}
+
+	buffer := &bytes.Buffer{}
+	encoder := NewJSONLinesEncoder(buffer, []string{"instance_id", "vxnets.0.private_ip", "missing"})
+	assert.Nil(t, encoder.Encode(instance))
+	assert.Nil(t, encoder.Encode(&flatInstance{}))
+	assert.Equal(t, "", buffer.String())
+	assert.Nil(t, encoder.Flush())
+	assert.Equal(t, "{\"instance_id\":\"i-xxxxxxxx\",\"vxnets.0.private_ip\":\"192.168.0.2\"}\n{}\n", buffer.String())
+
+	buffer.Reset()
+	encoder = NewJSONLinesEncoder(buffer, nil)
+	assert.Nil(t, encoder.Encode(&flatVxNet{PrivateIP: &ip}))
+	assert.Nil(t, encoder.Flush())
+	assert.Equal(t, "{\"vxnet_id\":null,\"private_ip\":\"192.168.0.2\"}\n", buffer.String())
+}
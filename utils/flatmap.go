@@ -0,0 +1,287 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// MaxFlatLength is the maximum length of the slices and maps UnflattenStruct
+// decodes, the "#" and "%" lengths may come from untrusted input.
+const MaxFlatLength = 1 << 16
+
+// The map keys are escaped so that they can contain "." and "%".
+var (
+	flatKeyEscaper   = strings.NewReplacer("%", "%25", ".", "%2E")
+	flatKeyUnescaper = strings.NewReplacer("%2E", ".", "%25", "%")
+)
+
+// FlattenStruct converts given struct to a flat map keyed by the json names of
+// the fields. The keys of nested fields are joined by ".", the elements of
+// slices and maps are keyed by their index or key, with the length at "#" and
+// "%", like "vxnets.#" and "vxnets.0.vxnet_id". The "%" and "." in map keys
+// are escaped as "%25" and "%2E". Nil fields are omitted.
+func FlattenStruct(source interface{}) (map[string]string, error) {
+	value := reflect.ValueOf(source)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, fmt.Errorf("can not flatten nil %s", value.Type())
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("can not flatten %s, struct expected", value.Type())
+	}
+	flat := map[string]string{}
+	if err := flattenValue(value, "", flat); err != nil {
+		return nil, err
+	}
+	return flat, nil
+}
+
+// UnflattenStruct decodes given flat map created by FlattenStruct to the
+// struct destination points to. It fails on the lengths over MaxFlatLength.
+func UnflattenStruct(flat map[string]string, destination interface{}) error {
+	value := reflect.ValueOf(destination)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("can not unflatten to %T, struct pointer expected", destination)
+	}
+	paths := map[string]bool{}
+	for key := range flat {
+		for path := key; ; {
+			paths[path] = true
+			index := strings.LastIndex(path, ".")
+			if index == -1 {
+				break
+			}
+			path = path[:index]
+		}
+	}
+	return unflattenValue(flat, paths, "", value.Elem())
+}
+
+func flattenValue(value reflect.Value, path string, flat map[string]string) error {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+		return flattenValue(value.Elem(), path, flat)
+	case reflect.Struct:
+		if value.Type() == timeType {
+			flat[path] = value.Interface().(time.Time).Format(time.RFC3339Nano)
+			return nil
+		}
+		for i := 0; i < value.NumField(); i++ {
+			name, ok := flatFieldName(value.Type().Field(i))
+			if !ok {
+				continue
+			}
+			if err := flattenValue(value.Field(i), jsonPath(path, name), flat); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		if value.IsNil() {
+			return nil
+		}
+		flat[jsonPath(path, "#")] = strconv.Itoa(value.Len())
+		for i := 0; i < value.Len(); i++ {
+			if err := flattenValue(value.Index(i), jsonPath(path, strconv.Itoa(i)), flat); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if value.IsNil() {
+			return nil
+		}
+		if value.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("can not flatten %s at \"%s\", string keys expected", value.Type(), path)
+		}
+		flat[jsonPath(path, "%")] = strconv.Itoa(value.Len())
+		for _, key := range value.MapKeys() {
+			if err := flattenValue(value.MapIndex(key), jsonPath(path, flatKeyEscaper.Replace(key.String())), flat); err != nil {
+				return err
+			}
+		}
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		content, err := json.Marshal(value.Interface())
+		if err != nil {
+			return err
+		}
+		flat[path] = string(content)
+	case reflect.String:
+		flat[path] = value.String()
+	case reflect.Bool:
+		flat[path] = strconv.FormatBool(value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		flat[path] = strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		flat[path] = strconv.FormatUint(value.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		flat[path] = strconv.FormatFloat(value.Float(), 'g', -1, 64)
+	default:
+		return fmt.Errorf("can not flatten %s at \"%s\"", value.Type(), path)
+	}
+	return nil
+}
+
+func unflattenValue(flat map[string]string, paths map[string]bool, path string, value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if !paths[path] && path != "" {
+			return nil
+		}
+		element := reflect.New(value.Type().Elem())
+		if err := unflattenValue(flat, paths, path, element.Elem()); err != nil {
+			return err
+		}
+		value.Set(element)
+		return nil
+	case reflect.Struct:
+		if value.Type() == timeType {
+			t, err := time.Parse(time.RFC3339Nano, flat[path])
+			if err != nil {
+				return fmt.Errorf("can not unflatten \"%s\": %s", path, err)
+			}
+			value.Set(reflect.ValueOf(t))
+			return nil
+		}
+		for i := 0; i < value.NumField(); i++ {
+			name, ok := flatFieldName(value.Type().Field(i))
+			if !ok {
+				continue
+			}
+			if err := unflattenValue(flat, paths, jsonPath(path, name), value.Field(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice:
+		length, ok, err := flatLength(flat, jsonPath(path, "#"))
+		if !ok || err != nil {
+			return err
+		}
+		slice := reflect.MakeSlice(value.Type(), length, length)
+		for i := 0; i < length; i++ {
+			if err := unflattenValue(flat, paths, jsonPath(path, strconv.Itoa(i)), slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+		return nil
+	case reflect.Map:
+		length, ok, err := flatLength(flat, jsonPath(path, "%"))
+		if !ok || err != nil {
+			return err
+		}
+		if value.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("can not unflatten %s at \"%s\", string keys expected", value.Type(), path)
+		}
+		m := reflect.MakeMapWithSize(value.Type(), length)
+		prefix := jsonPath(path, "")
+		for key := range paths {
+			if !strings.HasPrefix(key, prefix) || strings.Contains(key[len(prefix):], ".") || key[len(prefix):] == "%" {
+				continue
+			}
+			element := reflect.New(value.Type().Elem()).Elem()
+			if err := unflattenValue(flat, paths, key, element); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(flatKeyUnescaper.Replace(key[len(prefix):])).Convert(value.Type().Key()), element)
+		}
+		value.Set(m)
+		return nil
+	}
+
+	raw, ok := flat[path]
+	if !ok {
+		return nil
+	}
+	var err error
+	switch value.Kind() {
+	case reflect.Interface:
+		leaf := reflect.New(value.Type())
+		if err = json.Unmarshal([]byte(raw), leaf.Interface()); err == nil {
+			value.Set(leaf.Elem())
+		}
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(raw); err == nil {
+			value.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if i, err = strconv.ParseInt(raw, 10, value.Type().Bits()); err == nil {
+			value.SetInt(i)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		if u, err = strconv.ParseUint(raw, 10, value.Type().Bits()); err == nil {
+			value.SetUint(u)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(raw, value.Type().Bits()); err == nil {
+			value.SetFloat(f)
+		}
+	default:
+		return fmt.Errorf("can not unflatten %s at \"%s\"", value.Type(), path)
+	}
+	if err != nil {
+		return fmt.Errorf("can not unflatten \"%s\": %s", path, err)
+	}
+	return nil
+}
+
+func flatFieldName(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+func flatLength(flat map[string]string, key string) (int, bool, error) {
+	raw, ok := flat[key]
+	if !ok {
+		return 0, false, nil
+	}
+	length, err := strconv.Atoi(raw)
+	if err != nil || length < 0 || length > MaxFlatLength {
+		return 0, false, fmt.Errorf("can not unflatten \"%s\": invalid length \"%s\"", key, raw)
+	}
+	return length, true, nil
+}
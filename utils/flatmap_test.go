@@ -0,0 +1,106 @@
+// +-------------------------------------------------------------------------
+// | Copyright (C) 2016 Yunify, Inc.
+// +-------------------------------------------------------------------------
+// | Licensed under the Apache License, Version 2.0 (the "License");
+// | you may not use this work except in compliance with the License.
+// | You may obtain a copy of the License in the LICENSE file, or at:
+// |
+// | http://www.apache.org/licenses/LICENSE-2.0
+// |
+// | Unless required by applicable law or agreed to in writing, software
+// | distributed under the License is distributed on an "AS IS" BASIS,
+// | WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// | See the License for the specific language governing permissions and
+// | limitations under the License.
+// +-------------------------------------------------------------------------
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type flatVxNet struct {
+	VxNetID   *string `json:"vxnet_id"`
+	PrivateIP *string `json:"private_ip"`
+}
+
+type flatInstance struct {
+	InstanceID *string            `json:"instance_id"`
+	CPU        *int               `json:"vcpus_current"`
+	Deleted    bool               `json:"deleted,omitempty"`
+	CreateTime *time.Time         `json:"create_time"`
+	VxNets     []*flatVxNet       `json:"vxnets"`
+	Labels     map[string]*string `json:"labels"`
+	Extra      interface{}        `json:"extra"`
+	Ignored    *string            `json:"-"`
+	Empty      []*string          `json:"empty"`
+	Missing    *string            `json:"missing"`
+}
+
+func TestFlattenStruct(t *testing.T) {
+	id, ip, env := "i-xxxxxxxx", "192.168.0.2", "prod"
+	cpu := 2
+	createTime := time.Date(2026, 10, 14, 8, 0, 0, 500, time.UTC)
+	instance := &flatInstance{
+		InstanceID: &id,
+		CPU:        &cpu,
+		CreateTime: &createTime,
+		VxNets:     []*flatVxNet{{VxNetID: &id, PrivateIP: &ip}, nil},
+		Labels:     map[string]*string{"env": &env},
+		Extra:      map[string]interface{}{"a": float64(1)},
+		Ignored:    &id,
+		Empty:      []*string{},
+	}
+
+	flat, err := FlattenStruct(instance)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"instance_id":         "i-xxxxxxxx",
+		"vcpus_current":       "2",
+		"deleted":             "false",
+		"create_time":         "2026-10-14T08:00:00.0000005Z",
+		"vxnets.#":            "2",
+		"vxnets.0.vxnet_id":   "i-xxxxxxxx",
+		"vxnets.0.private_ip": "192.168.0.2",
+		"labels.%":            "1",
+		"labels.env":          "prod",
+		"extra":               `{"a":1}`,
+		"empty.#":             "0",
+	}, flat)
+
+	restored := &flatInstance{}
+	assert.Nil(t, UnflattenStruct(flat, restored))
+	instance.Ignored = nil
+	assert.Equal(t, instance, restored)
+
+	_, err = FlattenStruct("string")
+	assert.NotNil(t, err)
+	assert.NotNil(t, UnflattenStruct(flat, flatInstance{}))
+	assert.NotNil(t, UnflattenStruct(map[string]string{"vcpus_current": "two"}, &flatInstance{}))
+	assert.NotNil(t, UnflattenStruct(map[string]string{"vxnets.#": "-1"}, &flatInstance{}))
+	assert.NotNil(t, UnflattenStruct(map[string]string{"vxnets.#": "1000000000000"}, &flatInstance{}))
+	assert.NotNil(t, UnflattenStruct(map[string]string{"labels.%": "1000000000000"}, &flatInstance{}))
+}
+
+func TestFlattenStructDottedMapKeys(t *testing.T) {
+	domain, ratio, percent := "example.com", "50%", "x"
+	instance := &flatInstance{Labels: map[string]*string{"app.kubernetes.io/name": &domain, "cpu%": &ratio, "%2E": &percent}}
+
+	flat, err := FlattenStruct(instance)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"deleted":                           "false",
+		"labels.%":                          "3",
+		"labels.app%2Ekubernetes%2Eio/name": "example.com",
+		"labels.cpu%25":                     "50%",
+		"labels.%252E":                      "x",
+	}, flat)
+
+	restored := &flatInstance{}
+	assert.Nil(t, UnflattenStruct(flat, restored))
+	assert.Equal(t, instance, restored)
+}
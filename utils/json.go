@@ -19,6 +19,9 @@ package utils
 import (
 	"bytes"
 	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
 )
 
 // JSONEncode encode given interface to json byte slice.
@@ -54,6 +57,179 @@ func JSONDecode(content []byte, destinations ...interface{}) (interface{}, error
 	return destination, err
 }
 
+// JSONDecodeLenient decode given json byte slice to the destination pointer like
+// JSONDecode, but the values that do not fit the type of destination field are
+// skipped instead of failing the whole decoding. It returns the paths of the
+// skipped values, an error is returned only if the content is not valid json.
+func JSONDecodeLenient(content []byte, destination interface{}) ([]string, error) {
+	return jsonDecodeLenient(content, destination, false)
+}
+
+// JSONDecodeUseNumber decode given json byte slice to the destination pointer
+// like JSONDecode, but the numbers in interface{} values are decoded as
+// json.Number instead of float64, so large integers keep their precision.
+func JSONDecodeUseNumber(content []byte, destination interface{}) error {
+	return unmarshalJSON(content, destination, true)
+}
+
+// JSONDecodeLenientUseNumber decode given json byte slice leniently like
+// JSONDecodeLenient, with numbers in interface{} values decoded as json.Number.
+func JSONDecodeLenientUseNumber(content []byte, destination interface{}) ([]string, error) {
+	return jsonDecodeLenient(content, destination, true)
+}
+
+// unmarshalJSON works like json.Unmarshal, optionally decoding numbers in
+// interface{} values as json.Number.
+func unmarshalJSON(content []byte, destination interface{}, useNumber bool) error {
+	if !useNumber {
+		return json.Unmarshal(content, destination)
+	}
+	if !json.Valid(content) {
+		return json.Unmarshal(content, &json.RawMessage{})
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	return decoder.Decode(destination)
+}
+
+func jsonDecodeLenient(content []byte, destination interface{}, useNumber bool) ([]string, error) {
+	if !json.Valid(content) {
+		return nil, json.Unmarshal(content, &json.RawMessage{})
+	}
+	value := reflect.ValueOf(destination)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return nil, &json.InvalidUnmarshalError{Type: reflect.TypeOf(destination)}
+	}
+
+	skipped := []string{}
+	if !decodeLenient(content, value.Elem(), "", &skipped, useNumber) {
+		skipped = append(skipped, ".")
+	}
+	return skipped, nil
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func decodeLenient(raw []byte, value reflect.Value, path string, skipped *[]string, useNumber bool) bool {
+	if string(bytes.TrimSpace(raw)) == "null" {
+		return true
+	}
+	if reflect.PtrTo(value.Type()).Implements(jsonUnmarshalerType) {
+		return decodeLeaf(raw, value, useNumber)
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		element := reflect.New(value.Type().Elem())
+		if !decodeLenient(raw, element.Elem(), path, skipped, useNumber) {
+			return false
+		}
+		value.Set(element)
+		return true
+	case reflect.Struct:
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return false
+		}
+		for key, fieldRaw := range fields {
+			field, ok := jsonField(value, key)
+			if !ok {
+				continue
+			}
+			fieldPath := jsonPath(path, key)
+			if !decodeLenient(fieldRaw, field, fieldPath, skipped, useNumber) {
+				*skipped = append(*skipped, fieldPath)
+			}
+		}
+		return true
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return decodeLeaf(raw, value, useNumber)
+		}
+		items := []json.RawMessage{}
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return false
+		}
+		slice := reflect.MakeSlice(value.Type(), 0, len(items))
+		for index, itemRaw := range items {
+			item := reflect.New(value.Type().Elem()).Elem()
+			itemPath := jsonPath(path, strconv.Itoa(index))
+			if !decodeLenient(itemRaw, item, itemPath, skipped, useNumber) {
+				*skipped = append(*skipped, itemPath)
+				continue
+			}
+			slice = reflect.Append(slice, item)
+		}
+		value.Set(slice)
+		return true
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return decodeLeaf(raw, value, useNumber)
+		}
+		items := map[string]json.RawMessage{}
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return false
+		}
+		result := reflect.MakeMap(value.Type())
+		for key, itemRaw := range items {
+			item := reflect.New(value.Type().Elem()).Elem()
+			itemPath := jsonPath(path, key)
+			if !decodeLenient(itemRaw, item, itemPath, skipped, useNumber) {
+				*skipped = append(*skipped, itemPath)
+				continue
+			}
+			result.SetMapIndex(reflect.ValueOf(key).Convert(value.Type().Key()), item)
+		}
+		value.Set(result)
+		return true
+	default:
+		return decodeLeaf(raw, value, useNumber)
+	}
+}
+
+func decodeLeaf(raw []byte, value reflect.Value, useNumber bool) bool {
+	leaf := reflect.New(value.Type())
+	if err := unmarshalJSON(raw, leaf.Interface(), useNumber); err != nil {
+		return false
+	}
+	value.Set(leaf.Elem())
+	return true
+}
+
+func jsonPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func jsonField(value reflect.Value, key string) (reflect.Value, bool) {
+	fallback := -1
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if name == key {
+			return value.Field(i), true
+		}
+		if fallback == -1 && strings.EqualFold(name, key) {
+			fallback = i
+		}
+	}
+	if fallback != -1 {
+		return value.Field(fallback), true
+	}
+	return reflect.Value{}, false
+}
+
 // JSONFormatToReadable formats given json byte slice prettily.
 func JSONFormatToReadable(source []byte) ([]byte, error) {
 	var out bytes.Buffer
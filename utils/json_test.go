@@ -17,6 +17,7 @@
 package utils
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -77,3 +78,68 @@ func TestJSONFormatToReadable(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "{\n  \"name\": \"NAME\"\n}", string(jsonBytes))
 }
+
+func TestJSONDecodeLenient(t *testing.T) {
+	type Item struct {
+		ID   *string `json:"id"`
+		Size *int    `json:"size"`
+	}
+	type SampleJSON struct {
+		Name  *string          `json:"name"`
+		Count *int             `json:"count"`
+		Items []*Item          `json:"items"`
+		Tags  map[string]*int  `json:"tags"`
+		Extra *json.RawMessage `json:"extra"`
+	}
+	sampleJSONString := `{
+		"name": "NAME",
+		"count": "10",
+		"items": [{"id": "a", "size": 1}, {"id": "b", "size": "2"}, "c"],
+		"tags": {"x": 1, "y": "2"},
+		"unknown": true
+	}`
+
+	sample := SampleJSON{}
+	skipped, err := JSONDecodeLenient([]byte(sampleJSONString), &sample)
+	assert.Nil(t, err)
+	assert.Equal(t, "NAME", *sample.Name)
+	assert.Nil(t, sample.Count)
+	assert.Equal(t, 2, len(sample.Items))
+	assert.Equal(t, "b", *sample.Items[1].ID)
+	assert.Nil(t, sample.Items[1].Size)
+	assert.Equal(t, 1, *sample.Tags["x"])
+	assert.Equal(t, 1, len(sample.Tags))
+	assert.ElementsMatch(t, []string{"count", "items.1.size", "items.2", "tags.y"}, skipped)
+
+	_, err = JSONDecodeLenient([]byte(`{"name": `), &sample)
+	assert.NotNil(t, err)
+	_, err = JSONDecodeLenient([]byte(`{}`), sample)
+	assert.NotNil(t, err)
+}
+
+func TestJSONDecodeUseNumber(t *testing.T) {
+	type SampleJSON struct {
+		Total *int64      `json:"total"`
+		Data  interface{} `json:"data"`
+		Count *int        `json:"count"`
+	}
+	sampleJSONString := `{"total": 9007199254740993, "data": [9007199254740993, "NA"], "count": 1}`
+
+	sample := SampleJSON{}
+	assert.Nil(t, JSONDecodeUseNumber([]byte(sampleJSONString), &sample))
+	assert.Equal(t, int64(9007199254740993), *sample.Total)
+	assert.Equal(t, []interface{}{json.Number("9007199254740993"), "NA"}, sample.Data)
+
+	sample = SampleJSON{}
+	_, err := JSONDecode([]byte(sampleJSONString), &sample)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(9007199254740992), sample.Data.([]interface{})[0])
+
+	sample = SampleJSON{}
+	skipped, err := JSONDecodeLenientUseNumber([]byte(`{"data": {"a": 9007199254740993}, "count": "1"}`), &sample)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"count"}, skipped)
+	assert.Equal(t, map[string]interface{}{"a": json.Number("9007199254740993")}, sample.Data)
+
+	assert.NotNil(t, JSONDecodeUseNumber([]byte(`{"total": 1} {}`), &sample))
+}
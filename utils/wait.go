@@ -1,10 +1,14 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"time"
 )
 
+// ErrWaitCanceled is returned when the waiting is canceled before it finishes.
+var ErrWaitCanceled = errors.New("Wait canceled")
+
 // TimeoutError An Error represents a timeout error.
 type TimeoutError struct {
 	timeout time.Duration
@@ -23,25 +27,13 @@ func NewTimeoutError(timeout time.Duration) *TimeoutError {
 
 // WaitForSpecificOrError wait a function return true or error.
 func WaitForSpecificOrError(f func() (bool, error), timeout time.Duration, waitInterval time.Duration) error {
-	ticker := time.NewTicker(waitInterval)
-	defer ticker.Stop()
-	timer := time.NewTimer(timeout)
-	defer timer.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			stop, err := f()
-			if err != nil {
-				return err
-			}
-			if stop {
-				return nil
-			}
-		case <-timer.C:
-			return NewTimeoutError(timeout)
-		}
-	}
+	return WaitForSpecificOrErrorUntil(f, timeout, waitInterval, nil)
+}
+
+// WaitForSpecificOrErrorUntil wait a function return true or error, it returns ErrWaitCanceled once done is closed.
+// The waiting is timed by DefaultClock.
+func WaitForSpecificOrErrorUntil(f func() (bool, error), timeout time.Duration, waitInterval time.Duration, done <-chan struct{}) error {
+	return waitWithBackoff(f, timeout, ConstantBackoff(waitInterval), done)
 }
 
 // WaitForSpecific wait a function return true.
@@ -47,3 +47,19 @@ func TestWaitForSpecificOrError(t *testing.T) {
 	assert.Equal(t, timeout, tErr.timeout)
 	assert.Equal(t, 10, times)
 }
+
+func TestWaitForSpecificOrErrorUntil(t *testing.T) {
+	waitInterval := 100 * time.Millisecond
+	done := make(chan struct{})
+	times := 0
+	go func() {
+		time.Sleep(3*waitInterval + waitInterval/2)
+		close(done)
+	}()
+	err := WaitForSpecificOrErrorUntil(func() (bool, error) {
+		times++
+		return false, nil
+	}, 10*waitInterval, waitInterval, done)
+	assert.Equal(t, ErrWaitCanceled, err)
+	assert.Equal(t, 3, times)
+}